@@ -1,29 +1,52 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 )
 
 const (
-	requestIDKey = "requestID"
+	requestIDKey    = "requestID"
+	traceContextKey = "traceContext"
 )
 
 var (
-	serverPort int
-	logLevel   string
-	logFile    *os.File
-	startTime  time.Time
+	serverPort      int
+	logLevel        string
+	tlsCert         string
+	tlsKey          string
+	drainDelay      time.Duration
+	shutdownTimeout time.Duration
+	logFile         *os.File
+	startTime       time.Time
+
+	// readiness is what /ready reports: true once the server accepts
+	// connections, false again as soon as shutdown starts
+	readiness atomic.Bool
 )
 
 // Logger for structured logging
@@ -85,8 +108,9 @@ func closeLogging() {
 func (l *Logger) LogRequest(ctx *fasthttp.RequestCtx, requestID string, startTime time.Time) {
 	duration := time.Since(startTime)
 
-	log.Printf("[REQUEST] ID=%s | %s %s | Status=%d | Duration=%v | IP=%s | UserAgent=%s | Size=%d bytes",
+	log.Printf("[REQUEST] ID=%s | TraceID=%s | %s %s | Status=%d | Duration=%v | IP=%s | UserAgent=%s | Size=%d bytes",
 		requestID,
+		getTraceContext(ctx).TraceID,
 		string(ctx.Method()),
 		string(ctx.RequestURI()),
 		ctx.Response.StatusCode(),
@@ -107,6 +131,30 @@ func (l *Logger) LogInfo(requestID, message string) {
 	log.Printf("[INFO] ID=%s | %s", requestID, message)
 }
 
+// Middleware wraps a handler with behaviour that runs around it
+type Middleware func(next fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// Chain wraps handler in middlewares. The first middleware is the outermost:
+// it sees the request first and the response last, so
+//
+//	Chain(h, a, b, c) == a(b(c(h)))
+//
+// fasthttp's own wrappers such as fasthttp.CompressHandler are Middlewares
+// too, so CORS, compression or rate limiting are one more list entry.
+func Chain(handler fasthttp.RequestHandler, middlewares ...Middleware) fasthttp.RequestHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Adapt a middleware that also takes the logger to a Middleware
+func withLogger(middleware func(fasthttp.RequestHandler, *Logger) fasthttp.RequestHandler, logger *Logger) Middleware {
+	return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return middleware(next, logger)
+	}
+}
+
 // Middleware for request logging and tracing
 func loggingMiddleware(next fasthttp.RequestHandler, logger *Logger) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
@@ -116,12 +164,17 @@ func loggingMiddleware(next fasthttp.RequestHandler, logger *Logger) fasthttp.Re
 		requestID := uuid.New().String()
 		ctx.SetUserValue(requestIDKey, requestID)
 
+		// Join the caller's distributed trace, or start one
+		trace := newTraceContext(ctx)
+		ctx.SetUserValue(traceContextKey, trace)
+
 		// Log incoming request
-		logger.LogInfo(requestID, fmt.Sprintf("Incoming request: %s %s from %s",
-			string(ctx.Method()), string(ctx.RequestURI()), ctx.RemoteIP().String()))
+		logger.LogInfo(requestID, fmt.Sprintf("Incoming request: %s %s from %s | TraceID=%s SpanID=%s",
+			string(ctx.Method()), string(ctx.RequestURI()), ctx.RemoteIP().String(), trace.TraceID, trace.SpanID))
 
-		// Set request ID in response header for client-side tracing
+		// Set request and trace IDs in response headers for client-side tracing
 		ctx.Response.Header.Set("X-Request-ID", requestID)
+		ctx.Response.Header.Set("X-Trace-ID", trace.TraceID)
 
 		// Call next handler
 		next(ctx)
@@ -131,6 +184,52 @@ func loggingMiddleware(next fasthttp.RequestHandler, logger *Logger) fasthttp.Re
 	}
 }
 
+// Prometheus metrics for every request
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fasthttp_requests_total",
+		Help: "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fasthttp_request_duration_seconds",
+		Help:    "HTTP request duration by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "fasthttp_requests_in_flight",
+		Help: "HTTP requests currently being served.",
+	})
+
+	// promhttp serves net/http; the adaptor bridges it to fasthttp
+	metricsHandler = fasthttpadaptor.NewFastHTTPHandler(promhttp.Handler())
+)
+
+// Route label for metrics; unknown paths share one label so scanners
+// can't create a series per path
+func routeLabel(ctx *fasthttp.RequestCtx) string {
+	if path := string(ctx.Path()); routes[path] != nil {
+		return path
+	}
+	return "other"
+}
+
+// Metrics middleware to count and time requests
+func metricsMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		startTime := time.Now()
+		requestsInFlight.Inc()
+		defer requestsInFlight.Dec()
+
+		next(ctx)
+
+		route, method := routeLabel(ctx), string(ctx.Method())
+		requestsTotal.WithLabelValues(route, method, strconv.Itoa(ctx.Response.StatusCode())).Inc()
+		requestDuration.WithLabelValues(route, method).Observe(time.Since(startTime).Seconds())
+	}
+}
+
 // Recovery middleware to handle panics
 func recoveryMiddleware(next fasthttp.RequestHandler, logger *Logger) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
@@ -155,86 +254,218 @@ func getRequestID(ctx *fasthttp.RequestCtx) string {
 	return "unknown"
 }
 
+// routeHandler handles one route of the server
+type routeHandler func(ctx *fasthttp.RequestCtx, requestID string, logger *Logger)
+
+// Route registry: mainHandler dispatches on it and metrics label requests
+// with its paths
+var routes = map[string]routeHandler{
+	"/":              handleRoot,
+	"/health":        handleHealth,
+	"/ready":         handleReady,
+	"/api/v1/status": handleStatus,
+	"/metrics":       handleMetrics,
+}
+
+// TraceContext is the W3C Trace Context (https://www.w3.org/TR/trace-context/)
+// of a request: the caller's trace with a new span for this server
+type TraceContext struct {
+	TraceID string // 32 hex digits shared by every span of the trace
+	SpanID  string // 16 hex digits identifying this server's span
+	Flags   string // 2 hex digits; 01 means sampled
+	State   string // vendor tracestate, passed on unchanged
+}
+
+// Build the request's trace context from its traceparent and tracestate
+// headers; without a valid traceparent a new, sampled trace starts
+func newTraceContext(ctx *fasthttp.RequestCtx) TraceContext {
+	trace := TraceContext{SpanID: randomHex(8)}
+	if traceID, flags, ok := parseTraceparent(string(ctx.Request.Header.Peek("traceparent"))); ok {
+		trace.TraceID, trace.Flags = traceID, flags
+		trace.State = string(ctx.Request.Header.Peek("tracestate"))
+		return trace
+	}
+	trace.TraceID, trace.Flags = randomHex(16), "01"
+	return trace
+}
+
+// Parse a traceparent header, "version-traceid-parentid-flags". Versions after
+// 00 may append fields, which are ignored as the spec asks.
+func parseTraceparent(header string) (traceID, flags string, ok bool) {
+	if len(header) < 55 || (len(header) > 55 && (header[:2] == "00" || header[55] != '-')) {
+		return "", "", false
+	}
+	parts := strings.Split(header[:55], "-")
+	if len(parts) != 4 {
+		return "", "", false
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isHex(version, 2) || version == "ff" || !isHex(flags, 2) ||
+		!isHex(traceID, 32) || traceID == strings.Repeat("0", 32) ||
+		!isHex(parentID, 16) || parentID == strings.Repeat("0", 16) {
+		return "", "", false
+	}
+	return traceID, flags, true
+}
+
+// Check s is n lowercase hex digits
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// Helper function to get trace context from context
+func getTraceContext(ctx *fasthttp.RequestCtx) TraceContext {
+	if trace, ok := ctx.UserValue(traceContextKey).(TraceContext); ok {
+		return trace
+	}
+	return TraceContext{}
+}
+
 // Main request handler
 func mainHandler(ctx *fasthttp.RequestCtx) {
 	requestID := getRequestID(ctx)
 	logger := NewLogger(logLevel)
 
-	switch string(ctx.Path()) {
-	case "/":
-		handleRoot(ctx, requestID, logger)
-	case "/health":
-		handleHealth(ctx, requestID, logger)
-	case "/api/v1/status":
-		handleStatus(ctx, requestID, logger)
-	default:
-		handleNotFound(ctx, requestID, logger)
+	if handle, ok := routes[string(ctx.Path())]; ok {
+		handle(ctx, requestID, logger)
+		return
 	}
+	handleNotFound(ctx, requestID, logger)
+}
+
+// RootResponse is the body of GET /
+type RootResponse struct {
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Timestamp string `json:"timestamp"`
+	Version   string `json:"version"`
+}
+
+// HealthResponse is the body of GET /health and GET /ready
+type HealthResponse struct {
+	Status    string `json:"status"`
+	RequestID string `json:"request_id"`
+	Timestamp string `json:"timestamp"`
+}
+
+// StatusResponse is the body of GET /api/v1/status
+type StatusResponse struct {
+	Server      string `json:"server"`
+	Uptime      string `json:"uptime"`
+	RequestID   string `json:"request_id"`
+	Timestamp   string `json:"timestamp"`
+	GoVersion   string `json:"go_version"`
+	MemoryUsage string `json:"memory_usage"`
+}
+
+// ErrorResponse is the body of error responses such as 404
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Write a JSON response; encoding escapes values such as a path with quotes
+func writeJSON(ctx *fasthttp.RequestCtx, requestID string, logger *Logger, statusCode int, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		logger.LogError(requestID, "Failed to encode response", err)
+		ctx.Error("Internal Server Error", fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetStatusCode(statusCode)
+	ctx.Write(body)
 }
 
 // Root endpoint handler
 func handleRoot(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
 	logger.LogInfo(requestID, "Handling root endpoint")
 
-	response := fmt.Sprintf(`{
-		"message": "Welcome to FastHTTP Server",
-		"request_id": "%s",
-		"timestamp": "%s",
-		"version": "1.0.0"
-	}`, requestID, time.Now().Format(time.RFC3339))
-
-	ctx.SetContentType("application/json")
-	ctx.SetStatusCode(fasthttp.StatusOK)
-	ctx.WriteString(response)
+	writeJSON(ctx, requestID, logger, fasthttp.StatusOK, RootResponse{
+		Message:   "Welcome to FastHTTP Server",
+		RequestID: requestID,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Version:   "1.0.0",
+	})
 }
 
 // Health check endpoint handler
 func handleHealth(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
 	logger.LogInfo(requestID, "Health check requested")
 
-	response := fmt.Sprintf(`{
-		"status": "healthy",
-		"request_id": "%s",
-		"timestamp": "%s"
-	}`, requestID, time.Now().Format(time.RFC3339))
+	writeJSON(ctx, requestID, logger, fasthttp.StatusOK, HealthResponse{
+		Status:    "healthy",
+		RequestID: requestID,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+}
+
+// Readiness endpoint handler; unlike /health it fails until startup completes
+// and again during shutdown, so load balancers stop sending traffic
+func handleReady(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
+	logger.LogInfo(requestID, "Readiness check requested")
+
+	status, code := "ready", fasthttp.StatusOK
+	if !readiness.Load() {
+		status, code = "not ready", fasthttp.StatusServiceUnavailable
+	}
+
+	writeJSON(ctx, requestID, logger, code, HealthResponse{
+		Status:    status,
+		RequestID: requestID,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+}
 
-	ctx.SetContentType("application/json")
-	ctx.SetStatusCode(fasthttp.StatusOK)
-	ctx.WriteString(response)
+// Metrics endpoint handler, serving the Prometheus registry
+func handleMetrics(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
+	logger.LogInfo(requestID, "Metrics requested")
+	metricsHandler(ctx)
 }
 
 // Status endpoint handler
 func handleStatus(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
 	logger.LogInfo(requestID, "Status endpoint requested")
 
-	response := fmt.Sprintf(`{
-		"server": "fasthttp",
-		"uptime": "%s",
-		"request_id": "%s",
-		"timestamp": "%s",
-		"go_version": "go1.21+",
-		"memory_usage": "calculated_in_production"
-	}`, time.Since(startTime).String(), requestID, time.Now().Format(time.RFC3339))
-
-	ctx.SetContentType("application/json")
-	ctx.SetStatusCode(fasthttp.StatusOK)
-	ctx.WriteString(response)
+	writeJSON(ctx, requestID, logger, fasthttp.StatusOK, StatusResponse{
+		Server:      "fasthttp",
+		Uptime:      time.Since(startTime).String(),
+		RequestID:   requestID,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		GoVersion:   "go1.21+",
+		MemoryUsage: "calculated_in_production",
+	})
 }
 
 // 404 handler
 func handleNotFound(ctx *fasthttp.RequestCtx, requestID string, logger *Logger) {
 	logger.LogInfo(requestID, fmt.Sprintf("404 Not Found: %s", string(ctx.RequestURI())))
 
-	response := fmt.Sprintf(`{
-		"error": "Not Found",
-		"message": "The requested resource was not found",
-		"request_id": "%s",
-		"timestamp": "%s"
-	}`, requestID, time.Now().Format(time.RFC3339))
-
-	ctx.SetContentType("application/json")
-	ctx.SetStatusCode(fasthttp.StatusNotFound)
-	ctx.WriteString(response)
+	writeJSON(ctx, requestID, logger, fasthttp.StatusNotFound, ErrorResponse{
+		Error:     "Not Found",
+		Message:   fmt.Sprintf("The requested resource %s was not found", string(ctx.Path())),
+		RequestID: requestID,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
 }
 
 // Server command
@@ -247,6 +478,20 @@ var serverCmd = &cobra.Command{
 	},
 }
 
+// Check the TLS flags; TLS is on when both are set and the pair loads
+func tlsEnabled() (bool, error) {
+	if tlsCert == "" && tlsKey == "" {
+		return false, nil
+	}
+	if tlsCert == "" || tlsKey == "" {
+		return false, errors.New("--tls-cert and --tls-key must be set together")
+	}
+	if _, err := tls.LoadX509KeyPair(tlsCert, tlsKey); err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate and key: %v", err)
+	}
+	return true, nil
+}
+
 // Start the HTTP server
 func startServer() {
 	// Setup logging to both console and file
@@ -257,12 +502,21 @@ func startServer() {
 	// Ensure logging is closed on exit
 	defer closeLogging()
 
+	// Validate the certificate before binding the port
+	useTLS, err := tlsEnabled()
+	if err != nil {
+		log.Fatalf("[SERVER] Invalid TLS configuration: %v", err)
+	}
+
 	logger := NewLogger(logLevel)
 
-	// Create request handler with middleware chain
-	handler := loggingMiddleware(
-		recoveryMiddleware(mainHandler, logger),
-		logger,
+	// Create request handler with middleware chain, outermost first: logging
+	// assigns the request ID everything else uses, and metrics sit outside
+	// recovery so they count the 500s of recovered panics
+	handler := Chain(mainHandler,
+		withLogger(loggingMiddleware, logger),
+		metricsMiddleware,
+		withLogger(recoveryMiddleware, logger),
 	)
 
 	// Configure server
@@ -291,19 +545,43 @@ func startServer() {
 	log.Printf("[SERVER] Starting FastHTTP server at %s", startTime.Format("2006-01-02 15:04:05"))
 	log.Printf("[SERVER] Server port: %d", serverPort)
 	log.Printf("[SERVER] Logging level: %s", logLevel)
+	if useTLS {
+		log.Printf("[SERVER] TLS: enabled (cert: %s, key: %s)", tlsCert, tlsKey)
+	} else {
+		log.Printf("[SERVER] TLS: disabled, serving plain HTTP")
+	}
 	log.Printf("[SERVER] Process ID: %d", os.Getpid())
 	log.Printf("[SERVER] Available endpoints:")
 	log.Printf("[SERVER]   GET  /           - Root endpoint")
 	log.Printf("[SERVER]   GET  /health     - Health check")
+	log.Printf("[SERVER]   GET  /ready      - Readiness check")
 	log.Printf("[SERVER]   GET  /api/v1/status - Server status")
+	log.Printf("[SERVER]   GET  /metrics    - Prometheus metrics")
+
+	// Bind first, so the server is ready exactly when it accepts connections;
+	// tcp4 is what fasthttp's ListenAndServe uses
+	tcpLn, err := net.Listen("tcp4", addr)
+	if err != nil {
+		log.Fatalf("[SERVER] Failed to start server: %v", err)
+	}
+	ln := newTrackingListener(tcpLn.(*net.TCPListener))
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("[SERVER] Server listening on %s", addr)
-		if err := server.ListenAndServe(addr); err != nil {
+		var err error
+		if useTLS {
+			log.Printf("[SERVER] Server listening on %s (HTTPS)", addr)
+			err = server.ServeTLS(ln, tlsCert, tlsKey)
+		} else {
+			log.Printf("[SERVER] Server listening on %s (HTTP)", addr)
+			err = server.Serve(ln)
+		}
+		if err != nil {
 			log.Fatalf("[SERVER] Failed to start server: %v", err)
 		}
 	}()
+	readiness.Store(true)
+	log.Println("[SERVER] Server is ready")
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
@@ -311,16 +589,82 @@ func startServer() {
 	<-quit
 
 	log.Printf("[SERVER] Received shutdown signal at %s", time.Now().Format("2006-01-02 15:04:05"))
+	readiness.Store(false)
+	if drainDelay > 0 {
+		log.Printf("[SERVER] Not ready, waiting %v for load balancers to drain...", drainDelay)
+		time.Sleep(drainDelay)
+	}
 	log.Println("[SERVER] Shutting down server...")
 
-	// Graceful shutdown with timeout
-	if err := server.Shutdown(); err != nil {
+	// Graceful shutdown with timeout; connections still open when it expires
+	// are closed so the process always exits
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err = server.ShutdownWithContext(ctx)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		closed := ln.closeAll()
+		log.Printf("[SERVER] Shutdown timed out after %v, force-closed %d connection(s)", shutdownTimeout, closed)
+	case err != nil:
 		log.Printf("[SERVER] Error during shutdown: %v", err)
-	} else {
+	default:
 		log.Println("[SERVER] Server shutdown completed successfully")
 	}
 }
 
+// Listener that remembers its open connections, so shutdown can close the
+// ones that outlive the shutdown timeout
+type trackingListener struct {
+	*net.TCPListener
+
+	mu    sync.Mutex
+	conns map[*trackedConn]struct{}
+}
+
+func newTrackingListener(ln *net.TCPListener) *trackingListener {
+	return &trackingListener{TCPListener: ln, conns: make(map[*trackedConn]struct{})}
+}
+
+// Accept returns connections that embed *net.TCPConn, so fasthttp can still
+// set TCP keepalive on them
+func (l *trackingListener) Accept() (net.Conn, error) {
+	tcpConn, err := l.AcceptTCP()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &trackedConn{TCPConn: tcpConn, ln: l}
+	l.mu.Lock()
+	l.conns[conn] = struct{}{}
+	l.mu.Unlock()
+	return conn, nil
+}
+
+// Close every connection still open and return how many there were
+func (l *trackingListener) closeAll() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	closed := len(l.conns)
+	for conn := range l.conns {
+		conn.TCPConn.Close()
+	}
+	clear(l.conns)
+	return closed
+}
+
+type trackedConn struct {
+	*net.TCPConn
+	ln *trackingListener
+}
+
+func (c *trackedConn) Close() error {
+	c.ln.mu.Lock()
+	delete(c.ln.conns, c)
+	c.ln.mu.Unlock()
+	return c.TCPConn.Close()
+}
+
 // Root command
 var rootCmd = &cobra.Command{
 	Use:   "fasthttp-server",
@@ -333,9 +677,16 @@ func init() {
 	// Add server command to root
 	rootCmd.AddCommand(serverCmd)
 
+	// Register request metrics next to the default Go and process collectors
+	prometheus.MustRegister(requestsTotal, requestDuration, requestsInFlight)
+
 	// Server command flags
 	serverCmd.Flags().IntVarP(&serverPort, "port", "p", 8080, "Server port")
 	serverCmd.Flags().StringVarP(&logLevel, "log-level", "l", "info", "Log level (debug, info, warn, error)")
+	serverCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "TLS certificate file (PEM); serves HTTPS together with --tls-key")
+	serverCmd.Flags().StringVar(&tlsKey, "tls-key", "", "TLS private key file (PEM); serves HTTPS together with --tls-cert")
+	serverCmd.Flags().DurationVar(&drainDelay, "drain-delay", 0, "How long /ready reports not ready before shutdown closes connections")
+	serverCmd.Flags().DurationVar(&shutdownTimeout, "shutdown-timeout", 10*time.Second, "How long shutdown waits for open connections before force-closing them")
 }
 
 // Main function
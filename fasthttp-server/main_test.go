@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantTrace string
+		wantFlags string
+		wantOK    bool
+	}{
+		{
+			name:      "valid",
+			header:    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTrace: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantFlags: "01",
+			wantOK:    true,
+		},
+		{
+			name:      "future version with extra fields",
+			header:    "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra",
+			wantTrace: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantFlags: "00",
+			wantOK:    true,
+		},
+		{name: "empty", header: ""},
+		{name: "malformed", header: "not-a-traceparent"},
+		{name: "uppercase hex", header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01"},
+		{name: "wrong separator", header: "00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01"},
+		{name: "version 00 with extra fields", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{name: "all-zero trace-id", header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "all-zero parent-id", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "unsupported version ff", header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceID, flags, ok := parseTraceparent(tt.header)
+			if ok != tt.wantOK || traceID != tt.wantTrace || flags != tt.wantFlags {
+				t.Errorf("parseTraceparent(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.header, traceID, flags, ok, tt.wantTrace, tt.wantFlags, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+			return func(ctx *fasthttp.RequestCtx) {
+				calls = append(calls, name+" in")
+				next(ctx)
+				calls = append(calls, name+" out")
+			}
+		}
+	}
+	handler := func(ctx *fasthttp.RequestCtx) {
+		calls = append(calls, "handler")
+	}
+
+	tests := []struct {
+		name        string
+		middlewares []Middleware
+		want        []string
+	}{
+		{name: "no middleware", want: []string{"handler"}},
+		{
+			name:        "one middleware",
+			middlewares: []Middleware{record("a")},
+			want:        []string{"a in", "handler", "a out"},
+		},
+		{
+			name:        "first is outermost",
+			middlewares: []Middleware{record("a"), record("b"), record("c")},
+			want:        []string{"a in", "b in", "c in", "handler", "c out", "b out", "a out"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			Chain(handler, tt.middlewares...)(&fasthttp.RequestCtx{})
+			if got, want := strings.Join(calls, ", "), strings.Join(tt.want, ", "); got != want {
+				t.Errorf("calls = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -36,9 +36,15 @@ curl -i http://localhost:8080/
 echo -e "\n=== Health endpoint ==="
 curl -i http://localhost:8080/health
 
+echo -e "\n=== Ready endpoint ==="
+curl -i http://localhost:8080/ready
+
 echo -e "\n=== Status endpoint ==="
 curl -i http://localhost:8080/api/v1/status
 
+echo -e "\n=== Metrics endpoint ==="
+curl -s http://localhost:8080/metrics | grep '^fasthttp_'
+
 echo -e "\n=== 404 test ==="
 curl -i http://localhost:8080/notfound
 
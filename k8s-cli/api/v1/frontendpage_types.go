@@ -34,8 +34,12 @@ type FrontendPageSpec struct {
 	// URL path for the frontend page
 	Path string `json:"path"`
 
-	// Template to use for rendering
+	// Template selects how the frontend is served: static (plain nginx),
+	// spa (falls back to index.html for client-side routes) or proxy
+	// (forwards to config["upstream"]). Defaults to static.
 	// +optional
+	// +kubebuilder:validation:Enum=static;spa;proxy
+	// +kubebuilder:default=static
 	Template string `json:"template,omitempty"`
 
 	// Configuration for the frontend page
@@ -48,12 +52,38 @@ type FrontendPageSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas,omitempty"`
 
-	// Image for the frontend container
+	// Image for the frontend container; defaults to the template's image.
+	// The spa and proxy templates configure nginx, so the image must be nginx-based.
 	// +optional
-	// +kubebuilder:default="nginx:1.20"
 	Image string `json:"image,omitempty"`
 }
 
+// Templates a FrontendPage can be rendered with
+const (
+	TemplateStatic = "static"
+	TemplateSPA    = "spa"
+	TemplateProxy  = "proxy"
+
+	// DefaultTemplate is used when Spec.Template is empty
+	DefaultTemplate = TemplateStatic
+
+	// UpstreamConfigKey is the Spec.Config key holding the proxy template's upstream URL
+	UpstreamConfigKey = "upstream"
+)
+
+// Condition types and reasons the controller sets in Status.Conditions
+const (
+	// ConditionDegraded is True while the FrontendPage's Service would not
+	// route to its Deployment's pods
+	ConditionDegraded = "Degraded"
+
+	ReasonSelectorMismatch = "SelectorMismatch"
+	ReasonSelectorMatches  = "SelectorMatches"
+)
+
+// Templates lists every supported Spec.Template value
+var Templates = []string{TemplateStatic, TemplateSPA, TemplateProxy}
+
 // FrontendPageStatus defines the observed state of FrontendPage
 type FrontendPageStatus struct {
 	// Phase represents the current phase of the FrontendPage
@@ -68,6 +98,10 @@ type FrontendPageStatus struct {
 	// +optional
 	URL string `json:"url,omitempty"`
 
+	// Replicas is the number of pods currently running for the frontend deployment
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
 	// DeploymentName is the name of the created deployment
 	// +optional
 	DeploymentName string `json:"deploymentName,omitempty"`
@@ -87,14 +121,25 @@ type FrontendPageStatus struct {
 	// Message is a human-readable message indicating details about the status
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Conditions hold the controller's observations that Phase doesn't cover,
+	// such as Degraded when the Service selects none of the Deployment's pods
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Title",type="string",JSONPath=".spec.title"
 //+kubebuilder:printcolumn:name="Path",type="string",JSONPath=".spec.path"
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
 //+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 //+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // FrontendPage is the Schema for the frontendpages API
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The k8s-cli Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the FrontendPage webhooks with the manager.
+// Because v1 is the hub, this also serves the /convert endpoint for the other versions.
+// defaultRegistry, when set, is prefixed to images that don't name a registry.
+func (r *FrontendPage) SetupWebhookWithManager(mgr ctrl.Manager, defaultRegistry string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&FrontendPageDefaulter{DefaultRegistry: defaultRegistry}).
+		WithValidator(&FrontendPageValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-k8scli-dev-v1-frontendpage,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8scli.dev,resources=frontendpages,verbs=create;update,versions=v1,name=mfrontendpage.k8scli.dev,admissionReviewVersions=v1
+
+// FrontendPageDefaulter sets an empty template to DefaultTemplate and rewrites
+// FrontendPage images to come from DefaultRegistry, for environments where
+// every image must be pulled from an internal registry
+type FrontendPageDefaulter struct {
+	DefaultRegistry string
+}
+
+var _ webhook.CustomDefaulter = &FrontendPageDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *FrontendPageDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	page, ok := obj.(*FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a FrontendPage but got %T", obj)
+	}
+
+	if page.Spec.Template == "" {
+		page.Spec.Template = DefaultTemplate
+	}
+
+	if d.DefaultRegistry == "" || page.Spec.Image == "" {
+		return nil
+	}
+
+	page.Spec.Image = withRegistry(page.Spec.Image, d.DefaultRegistry)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-k8scli-dev-v1-frontendpage,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8scli.dev,resources=frontendpages,verbs=create;update,versions=v1,name=vfrontendpage.k8scli.dev,admissionReviewVersions=v1
+
+// FrontendPageValidator rejects FrontendPages that can't be rendered: an
+// unknown template, or a proxy without an http(s) upstream in its config
+type FrontendPageValidator struct{}
+
+var _ webhook.CustomValidator = &FrontendPageValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *FrontendPageValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *FrontendPageValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator; deletes are always allowed
+func (v *FrontendPageValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *FrontendPageValidator) validate(obj runtime.Object) error {
+	page, ok := obj.(*FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a FrontendPage but got %T", obj)
+	}
+	return ValidateFrontendPage(page)
+}
+
+// ValidateFrontendPage returns an Invalid error when page can't be rendered.
+// The controller checks it too, so a page that got past a missing or
+// bypassed webhook is marked Failed instead of rendering a broken config.
+func ValidateFrontendPage(page *FrontendPage) error {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+
+	// The defaulter runs first, so an empty template only gets here when
+	// the webhook is bypassed; treat it like the default
+	template := page.Spec.Template
+	if template == "" {
+		template = DefaultTemplate
+	}
+	if !slices.Contains(Templates, template) {
+		errs = append(errs, field.NotSupported(specPath.Child("template"), template, Templates))
+	}
+
+	if template == TemplateProxy {
+		upstreamPath := specPath.Child("config").Key(UpstreamConfigKey)
+		upstream := page.Spec.Config[UpstreamConfigKey]
+		if upstream == "" {
+			errs = append(errs, field.Required(upstreamPath, "the proxy template needs an upstream URL"))
+		} else if u, err := url.Parse(upstream); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(upstreamPath, upstream, "must be an http or https URL"))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("FrontendPage").GroupKind(), page.Name, errs)
+}
+
+// withRegistry prefixes registry to image unless the image already names a
+// registry host. Like Docker, the first path component is a host when it
+// contains a "." or ":" or is "localhost"; single-name images such as
+// "nginx:1.20" are official images and go under "library/".
+func withRegistry(image, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+
+	first, _, hasPath := strings.Cut(image, "/")
+	if hasPath && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return image
+	}
+	if !hasPath {
+		return registry + "/library/" + image
+	}
+	return registry + "/" + image
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestFrontendPageDefaulterImageRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry string
+		image    string
+		want     string
+	}{
+		{name: "official image", registry: "registry.internal", image: "nginx:1.20", want: "registry.internal/library/nginx:1.20"},
+		{name: "official image without tag", registry: "registry.internal", image: "nginx", want: "registry.internal/library/nginx"},
+		{name: "official image by digest", registry: "registry.internal", image: "nginx@sha256:abc", want: "registry.internal/library/nginx@sha256:abc"},
+		{name: "namespaced image", registry: "registry.internal", image: "bitnami/nginx:1.25", want: "registry.internal/bitnami/nginx:1.25"},
+		{name: "registry with trailing slash", registry: "registry.internal/", image: "nginx:1.20", want: "registry.internal/library/nginx:1.20"},
+		{name: "already on internal registry", registry: "registry.internal", image: "registry.internal/library/nginx:1.20", want: "registry.internal/library/nginx:1.20"},
+		{name: "other registry host", registry: "registry.internal", image: "ghcr.io/org/app:v1", want: "ghcr.io/org/app:v1"},
+		{name: "registry with port", registry: "registry.internal", image: "myregistry:5000/app:v1", want: "myregistry:5000/app:v1"},
+		{name: "localhost registry", registry: "registry.internal", image: "localhost/app:v1", want: "localhost/app:v1"},
+		{name: "no default registry", registry: "", image: "nginx:1.20", want: "nginx:1.20"},
+		{name: "empty image", registry: "registry.internal", image: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &FrontendPage{Spec: FrontendPageSpec{Image: tt.image}}
+			d := &FrontendPageDefaulter{DefaultRegistry: tt.registry}
+
+			if err := d.Default(context.Background(), page); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if page.Spec.Image != tt.want {
+				t.Errorf("Image = %q, want %q", page.Spec.Image, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrontendPageDefaulterRejectsOtherTypes(t *testing.T) {
+	d := &FrontendPageDefaulter{DefaultRegistry: "registry.internal"}
+	if err := d.Default(context.Background(), &corev1.Pod{}); err == nil {
+		t.Fatal("Default() expected error for non-FrontendPage object")
+	}
+}
+
+func TestFrontendPageDefaulterTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "empty template", template: "", want: TemplateStatic},
+		{name: "explicit template", template: TemplateSPA, want: TemplateSPA},
+		{name: "unknown template is left for validation", template: "modern", want: "modern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &FrontendPage{Spec: FrontendPageSpec{Template: tt.template}}
+			if err := (&FrontendPageDefaulter{}).Default(context.Background(), page); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if page.Spec.Template != tt.want {
+				t.Errorf("Template = %q, want %q", page.Spec.Template, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrontendPageValidatorTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		config   map[string]string
+		wantErr  string
+	}{
+		{name: "static", template: TemplateStatic},
+		{name: "spa", template: TemplateSPA},
+		{name: "empty template", template: ""},
+		{name: "proxy with upstream", template: TemplateProxy, config: map[string]string{"upstream": "http://backend.default.svc:8080"}},
+		{name: "unknown template", template: "modern", wantErr: "spec.template"},
+		{name: "proxy without upstream", template: TemplateProxy, wantErr: "spec.config[upstream]"},
+		{name: "proxy with relative upstream", template: TemplateProxy, config: map[string]string{"upstream": "backend:8080"}, wantErr: "must be an http or https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &FrontendPage{Spec: FrontendPageSpec{Template: tt.template, Config: tt.config}}
+			v := &FrontendPageValidator{}
+
+			_, createErr := v.ValidateCreate(context.Background(), page)
+			_, updateErr := v.ValidateUpdate(context.Background(), &FrontendPage{}, page)
+			for _, err := range []error{createErr, updateErr} {
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+					continue
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				if !apierrors.IsInvalid(err) {
+					t.Errorf("error = %v, want an Invalid status error", err)
+				}
+			}
+		})
+	}
+}
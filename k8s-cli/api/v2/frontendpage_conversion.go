@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The k8s-cli Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+// ConvertTo converts this FrontendPage to the Hub version (v1)
+func (src *FrontendPage) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*k8scliv1.FrontendPage)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Title = src.Spec.Title
+	dst.Spec.Description = src.Spec.Description
+	dst.Spec.Path = src.Spec.Path
+	dst.Spec.Template = src.Spec.Template
+	dst.Spec.Replicas = src.Spec.Replicas
+	dst.Spec.Image = src.Spec.Image
+
+	// v2 Env list -> v1 Config map
+	dst.Spec.Config = nil
+	if len(src.Spec.Env) > 0 {
+		dst.Spec.Config = make(map[string]string, len(src.Spec.Env))
+		for _, env := range src.Spec.Env {
+			dst.Spec.Config[env.Name] = env.Value
+		}
+	}
+
+	dst.Status = k8scliv1.FrontendPageStatus(src.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version
+func (dst *FrontendPage) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*k8scliv1.FrontendPage)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Title = src.Spec.Title
+	dst.Spec.Description = src.Spec.Description
+	dst.Spec.Path = src.Spec.Path
+	dst.Spec.Template = src.Spec.Template
+	dst.Spec.Replicas = src.Spec.Replicas
+	dst.Spec.Image = src.Spec.Image
+
+	// v1 Config map -> v2 Env list, sorted so conversion is deterministic
+	dst.Spec.Env = nil
+	if len(src.Spec.Config) > 0 {
+		names := make([]string, 0, len(src.Spec.Config))
+		for name := range src.Spec.Config {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		dst.Spec.Env = make([]EnvVar, 0, len(names))
+		for _, name := range names {
+			dst.Spec.Env = append(dst.Spec.Env, EnvVar{Name: name, Value: src.Spec.Config[name]})
+		}
+	}
+
+	dst.Status = FrontendPageStatus(src.Status)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+func TestConvertFromHub(t *testing.T) {
+	src := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: k8scliv1.FrontendPageSpec{
+			Title:       "Demo",
+			Description: "Demo page",
+			Path:        "/demo",
+			Replicas:    2,
+			Image:       "nginx:1.21",
+			Config: map[string]string{
+				"THEME":       "dark",
+				"ENVIRONMENT": "demo",
+			},
+		},
+		Status: k8scliv1.FrontendPageStatus{Phase: "Running", Ready: true},
+	}
+
+	var dst FrontendPage
+	if err := dst.ConvertFrom(src); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+
+	wantEnv := []EnvVar{
+		{Name: "ENVIRONMENT", Value: "demo"},
+		{Name: "THEME", Value: "dark"},
+	}
+	if !reflect.DeepEqual(dst.Spec.Env, wantEnv) {
+		t.Errorf("Expected env %v, got %v", wantEnv, dst.Spec.Env)
+	}
+	if dst.Name != "demo" || dst.Spec.Replicas != 2 || dst.Spec.Image != "nginx:1.21" {
+		t.Errorf("Unexpected converted object: %+v", dst)
+	}
+	if dst.Status.Phase != "Running" || !dst.Status.Ready {
+		t.Errorf("Status was not carried over: %+v", dst.Status)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	src := &FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: FrontendPageSpec{
+			Title: "Demo",
+			Path:  "/demo",
+			Env: []EnvVar{
+				{Name: "A", Value: "1"},
+				{Name: "B", Value: "2"},
+			},
+		},
+	}
+
+	var hub k8scliv1.FrontendPage
+	if err := src.ConvertTo(&hub); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if hub.Spec.Config["A"] != "1" || hub.Spec.Config["B"] != "2" {
+		t.Errorf("Expected config to hold env vars, got %v", hub.Spec.Config)
+	}
+
+	var back FrontendPage
+	if err := back.ConvertFrom(&hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.Spec, src.Spec) {
+		t.Errorf("Round trip mismatch: got %+v, want %+v", back.Spec, src.Spec)
+	}
+}
+
+func TestConvertEmptyConfig(t *testing.T) {
+	var dst FrontendPage
+	if err := dst.ConvertFrom(&k8scliv1.FrontendPage{}); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	if dst.Spec.Env != nil {
+		t.Errorf("Expected nil env for empty config, got %v", dst.Spec.Env)
+	}
+}
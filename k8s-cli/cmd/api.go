@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,6 +13,11 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"k8s-cli/internal/k8s"
 )
 
 var (
@@ -24,12 +28,27 @@ var (
 
 // Step 7+: JSON API Response structures
 type APIResponse struct {
-	Status string      `json:"status"`
-	Data   interface{} `json:"data,omitempty"`
-	Error  string      `json:"error,omitempty"`
-	Count  int         `json:"count,omitempty"`
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Count     int         `json:"count,omitempty"`
 }
 
+// Error codes returned in the "code" field of error responses from the cache APIs.
+// The codes are stable; clients should branch on them rather than on the message text.
+const (
+	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED" // HTTP method not supported by the endpoint
+	ErrCodeInvalidPath      = "INVALID_PATH"       // malformed resource path, e.g. missing namespace or name
+	ErrCodeInvalidSelector  = "INVALID_SELECTOR"   // labelSelector query parameter could not be parsed
+	ErrCodeInvalidParameter = "INVALID_PARAMETER"  // other query parameter is malformed or names an unknown field
+	ErrCodeNotFound         = "NOT_FOUND"          // requested object is not in the cache
+	ErrCodeForbidden        = "FORBIDDEN"          // endpoint is disabled by configuration
+	ErrCodeUnavailable      = "UNAVAILABLE"        // the cache behind the endpoint isn't running; retry later
+	ErrCodeInternal         = "INTERNAL_ERROR"     // unexpected server-side failure
+)
+
 type DeploymentSummary struct {
 	Name              string            `json:"name"`
 	Namespace         string            `json:"namespace"`
@@ -54,9 +73,11 @@ func (e *EventProcessor) StartAPIServer() {
 	mux.HandleFunc("/api/v1/deployments/", e.handleDeploymentByNameAPI)
 	mux.HandleFunc("/api/v1/health", e.handleHealthAPI)
 	mux.HandleFunc("/api/v1/cache/stats", e.handleCacheStatsAPI)
+	mux.HandleFunc("/api/v1/frontendpages", e.handleFrontendPagesAPI)
+	mux.HandleFunc("/openapi.json", serveOpenAPI(apiServerOpenAPI()))
 
-	// Enable CORS
-	handler := enableCORS(mux)
+	// Enable CORS and request IDs
+	handler := withRequestID(enableCORS(mux))
 
 	port := e.config.APIServer.Port
 	log.Printf("🌐 Starting API server on port %d", port)
@@ -66,6 +87,10 @@ func (e *EventProcessor) StartAPIServer() {
 	log.Printf("  GET /api/v1/deployments/{namespace}/{name} - Get specific deployment")
 	log.Printf("  GET /api/v1/health - Health check")
 	log.Printf("  GET /api/v1/cache/stats - Cache statistics")
+	if e.config.WatchFrontendPages {
+		log.Printf("  GET /api/v1/frontendpages - List FrontendPages from cache")
+	}
+	log.Printf("  GET /openapi.json - OpenAPI 3.0 specification")
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -95,6 +120,8 @@ func (e *EventProcessor) handleRootAPI(w http.ResponseWriter, r *http.Request) {
 			"GET /api/v1/deployments/{namespace}/{name}": "Get specific deployment",
 			"GET /api/v1/health":                         "Health check",
 			"GET /api/v1/cache/stats":                    "Cache statistics",
+			"GET /api/v1/frontendpages":                  "List FrontendPages (with --watch-frontendpages)",
+			"GET /openapi.json":                          "OpenAPI 3.0 specification",
 		},
 		"features": []string{
 			"Informer cache access",
@@ -104,7 +131,7 @@ func (e *EventProcessor) handleRootAPI(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	writeJSONResponse(w, APIResponse{
+	writeJSONResponse(w, r, APIResponse{
 		Status: "success",
 		Data:   apiInfo,
 	})
@@ -112,7 +139,7 @@ func (e *EventProcessor) handleRootAPI(w http.ResponseWriter, r *http.Request) {
 
 func (e *EventProcessor) handleDeploymentsAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeErrorResponse(w, r, ErrCodeMethodNotAllowed, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -120,27 +147,38 @@ func (e *EventProcessor) handleDeploymentsAPI(w http.ResponseWriter, r *http.Req
 	namespaceFilter := r.URL.Query().Get("namespace")
 	labelSelector := r.URL.Query().Get("labelSelector")
 
+	selector, err := parseLabelSelector(labelSelector)
+	if err != nil {
+		writeErrorResponse(w, r, ErrCodeInvalidSelector, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var deployments []DeploymentSummary
 
 	// Use informer cache for efficient access
-	for _, obj := range e.cacheIndexer.List() {
-		if deployment, ok := obj.(*appsv1.Deployment); ok {
-			// Apply namespace filter
-			if namespaceFilter != "" && deployment.Namespace != namespaceFilter {
-				continue
-			}
-
-			// Apply label selector filter
-			if labelSelector != "" && !matchesLabelSelector(deployment.Labels, labelSelector) {
-				continue
-			}
-
-			summary := e.createDeploymentSummary(deployment)
-			deployments = append(deployments, summary)
+	candidates := e.filterCandidates(map[string]string{"namespace": namespaceFilter, "labelSelector": labelSelector})
+	for _, deployment := range candidates {
+		// Stop building the response once the client has gone away
+		if err := r.Context().Err(); err != nil {
+			logCancelledRequest(r, err)
+			return
+		}
+
+		// Apply namespace filter
+		if namespaceFilter != "" && deployment.Namespace != namespaceFilter {
+			continue
 		}
+
+		// Apply label selector filter
+		if !selector.Matches(labels.Set(deployment.Labels)) {
+			continue
+		}
+
+		summary := e.createDeploymentSummary(deployment)
+		deployments = append(deployments, summary)
 	}
 
-	writeJSONResponse(w, APIResponse{
+	writeJSONResponse(w, r, APIResponse{
 		Status: "success",
 		Data:   deployments,
 		Count:  len(deployments),
@@ -149,7 +187,7 @@ func (e *EventProcessor) handleDeploymentsAPI(w http.ResponseWriter, r *http.Req
 
 func (e *EventProcessor) handleDeploymentByNameAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeErrorResponse(w, r, ErrCodeMethodNotAllowed, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -158,7 +196,7 @@ func (e *EventProcessor) handleDeploymentByNameAPI(w http.ResponseWriter, r *htt
 	parts := strings.Split(strings.Trim(path, "/"), "/")
 
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		writeErrorResponse(w, "Invalid path. Use /api/v1/deployments/{namespace}/{name}", http.StatusBadRequest)
+		writeErrorResponse(w, r, ErrCodeInvalidPath, "Invalid path. Use /api/v1/deployments/{namespace}/{name}", http.StatusBadRequest)
 		return
 	}
 
@@ -166,53 +204,46 @@ func (e *EventProcessor) handleDeploymentByNameAPI(w http.ResponseWriter, r *htt
 	key := fmt.Sprintf("%s/%s", namespace, name)
 
 	// Check cache first
-	if deployment, exists := e.deploymentCache[key]; exists {
+	if deployment, exists := e.cachedDeployment(key); exists {
 		summary := e.createDeploymentSummary(deployment)
-		writeJSONResponse(w, APIResponse{
+		writeJSONResponse(w, r, APIResponse{
 			Status: "success",
 			Data:   summary,
 		})
 		return
 	}
 
-	// Fallback to indexer
-	obj, exists, err := e.cacheIndexer.GetByKey(key)
-	if err != nil {
-		writeErrorResponse(w, fmt.Sprintf("Error accessing cache: %v", err), http.StatusInternalServerError)
-		return
-	}
-
+	// Fallback to the informer cache
+	deployment, exists := e.informer.Get(key)
 	if !exists {
-		writeErrorResponse(w, "Deployment not found", http.StatusNotFound)
+		writeErrorResponse(w, r, ErrCodeNotFound, "Deployment not found", http.StatusNotFound)
 		return
 	}
 
-	if deployment, ok := obj.(*appsv1.Deployment); ok {
-		summary := e.createDeploymentSummary(deployment)
-		writeJSONResponse(w, APIResponse{
-			Status: "success",
-			Data:   summary,
-		})
-	} else {
-		writeErrorResponse(w, "Invalid object type in cache", http.StatusInternalServerError)
-	}
+	summary := e.createDeploymentSummary(deployment)
+	writeJSONResponse(w, r, APIResponse{
+		Status: "success",
+		Data:   summary,
+	})
 }
 
 func (e *EventProcessor) handleHealthAPI(w http.ResponseWriter, r *http.Request) {
 	uptime := time.Since(e.startTime).Round(time.Second)
 
-	writeJSONResponse(w, APIResponse{
+	health := map[string]interface{}{
+		"service":      "k8s-cli API Server",
+		"step":         "Step 7+ - Cache Access",
+		"workers":      e.config.Workers,
+		"cache_size":   e.cacheSize(),
+		"indexer_size": len(e.informer.List()),
+		"uptime":       uptime.String(),
+		"start_time":   e.startTime.Format(time.RFC3339),
+	}
+	e.addWatchHealth(health)
+
+	writeJSONResponse(w, r, APIResponse{
 		Status: "success",
-		Data: map[string]interface{}{
-			"status":       "healthy",
-			"service":      "k8s-cli API Server",
-			"step":         "Step 7+ - Cache Access",
-			"workers":      e.config.Workers,
-			"cache_size":   len(e.deploymentCache),
-			"indexer_size": len(e.cacheIndexer.List()),
-			"uptime":       uptime.String(),
-			"start_time":   e.startTime.Format(time.RFC3339),
-		},
+		Data:   health,
 	})
 }
 
@@ -222,26 +253,23 @@ func (e *EventProcessor) handleCacheStatsAPI(w http.ResponseWriter, r *http.Requ
 	var totalReplicas int32
 	var healthyDeployments, unhealthyDeployments int
 
-	for _, obj := range e.cacheIndexer.List() {
-		if deployment, ok := obj.(*appsv1.Deployment); ok {
-			namespaceStats[deployment.Namespace]++
+	for _, deployment := range e.informer.List() {
+		namespaceStats[deployment.Namespace]++
 
-			if deployment.Spec.Replicas != nil {
-				totalReplicas += *deployment.Spec.Replicas
-			}
+		if deployment.Spec.Replicas != nil {
+			totalReplicas += *deployment.Spec.Replicas
+		}
 
-			if deployment.Status.ReadyReplicas == deployment.Status.Replicas &&
-				deployment.Status.Replicas > 0 {
-				healthyDeployments++
-			} else {
-				unhealthyDeployments++
-			}
+		if deploymentStatus(deployment) == "Healthy" {
+			healthyDeployments++
+		} else {
+			unhealthyDeployments++
 		}
 	}
 
 	stats := map[string]interface{}{
-		"cache_size":            len(e.deploymentCache),
-		"indexer_size":          len(e.cacheIndexer.List()),
+		"cache_size":            e.cacheSize(),
+		"indexer_size":          len(e.informer.List()),
 		"resync_period":         e.config.ResyncPeriod.String(),
 		"workers":               e.config.Workers,
 		"namespaces":            e.config.Namespaces,
@@ -258,7 +286,7 @@ func (e *EventProcessor) handleCacheStatsAPI(w http.ResponseWriter, r *http.Requ
 		},
 	}
 
-	writeJSONResponse(w, APIResponse{
+	writeJSONResponse(w, r, APIResponse{
 		Status: "success",
 		Data:   stats,
 	})
@@ -277,16 +305,6 @@ func (e *EventProcessor) createDeploymentSummary(deployment *appsv1.Deployment)
 
 	age := time.Since(deployment.CreationTimestamp.Time).Round(time.Second).String()
 
-	// Determine deployment status
-	status := "Unknown"
-	if deployment.Status.ReadyReplicas == deployment.Status.Replicas && deployment.Status.Replicas > 0 {
-		status = "Healthy"
-	} else if deployment.Status.ReadyReplicas == 0 {
-		status = "Unhealthy"
-	} else {
-		status = "Progressing"
-	}
-
 	return DeploymentSummary{
 		Name:              deployment.Name,
 		Namespace:         deployment.Namespace,
@@ -298,27 +316,53 @@ func (e *EventProcessor) createDeploymentSummary(deployment *appsv1.Deployment)
 		Labels:            deployment.Labels,
 		CreationTime:      deployment.CreationTimestamp.Time,
 		Age:               age,
-		Status:            status,
+		Status:            deploymentStatus(deployment),
+	}
+}
+
+// deploymentStatus derives Healthy/Unhealthy/Progressing from desired and ready replicas.
+// A nil spec.replicas means the API default of 1. A deployment scaled to zero is Healthy
+// once its pods are gone, and surplus ready pods during a scale-down still count as Healthy.
+func deploymentStatus(deployment *appsv1.Deployment) string {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	if desired == 0 {
+		if deployment.Status.Replicas == 0 {
+			return "Healthy"
+		}
+		return "Progressing"
+	}
+
+	switch {
+	case deployment.Status.ReadyReplicas >= desired:
+		return "Healthy"
+	case deployment.Status.ReadyReplicas == 0:
+		return "Unhealthy"
+	default:
+		return "Progressing"
 	}
 }
 
 // Helper functions
-func writeJSONResponse(w http.ResponseWriter, response APIResponse) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+func writeJSONResponse(w http.ResponseWriter, r *http.Request, response APIResponse) {
+	response.RequestID = w.Header().Get(requestIDHeader)
+	if err := writeJSON(w, r, http.StatusOK, response); err != nil {
 		log.Printf("❌ Error encoding JSON response: %v", err)
 	}
 }
 
-func writeErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// writeErrorResponse writes an error with a stable code and the request's correlation ID
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, code, message string, statusCode int) {
 	response := APIResponse{
-		Status: "error",
-		Error:  message,
+		Status:    "error",
+		Error:     message,
+		Code:      code,
+		RequestID: requestIDFromContext(r.Context()),
 	}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	if err := writeJSON(w, r, statusCode, response); err != nil {
 		log.Printf("❌ Error encoding error response: %v", err)
 	}
 }
@@ -327,7 +371,8 @@ func enableCORS(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -338,32 +383,49 @@ func enableCORS(handler http.Handler) http.Handler {
 	})
 }
 
-func matchesLabelSelector(labels map[string]string, selector string) bool {
-	if labels == nil {
-		return false
+// parseLabelSelector parses a labelSelector query parameter with the
+// Kubernetes selector syntax; an empty one selects everything
+func parseLabelSelector(selector string) (labels.Selector, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labelSelector %q: %v", selector, err)
 	}
+	return sel, nil
+}
 
-	// Handle simple key=value selectors
-	if strings.Contains(selector, "=") {
-		parts := strings.SplitN(selector, "=", 2)
-		if len(parts) == 2 {
-			key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
-			return labels[key] == value
-		}
+// validateNamespaceParam rejects namespace query parameters that can't be a
+// namespace name, so a typo like "team_a" isn't answered with empty results
+func validateNamespaceParam(namespace string) error {
+	if namespace == "" {
+		return nil
 	}
-
-	// Handle key existence selectors
-	if strings.Contains(selector, "!=") {
-		parts := strings.SplitN(selector, "!=", 2)
-		if len(parts) == 2 {
-			key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
-			return labels[key] != value
-		}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
 	}
+	return nil
+}
 
-	// Simple key existence check
-	_, exists := labels[selector]
-	return exists
+// labelIndexValue returns the informer.LabelIndex value that holds every
+// deployment selector matches, if there is one: a selector made of a single
+// key=value (or key==value) requirement with a non-empty value
+func labelIndexValue(selector string) (string, bool) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return "", false
+	}
+	requirements, _ := sel.Requirements()
+	if len(requirements) != 1 {
+		return "", false
+	}
+	requirement := requirements[0]
+	if op := requirement.Operator(); op != selection.Equals && op != selection.DoubleEquals {
+		return "", false
+	}
+	value, ok := requirement.Values().PopAny()
+	if !ok || value == "" {
+		return "", false
+	}
+	return requirement.Key() + "=" + value, true
 }
 
 // Step 7+: API server command
@@ -372,6 +434,7 @@ var apiServerCmd = &cobra.Command{
 	Short: "Start JSON API server for cache access (Step 7+)",
 	Long:  "Start a JSON API server that provides access to deployment data from informer cache",
 	Run: func(cmd *cobra.Command, args []string) {
+		informerResyncPeriodSet = cmd.Flags().Changed("resync-period")
 		runAPIServer()
 	},
 }
@@ -398,13 +461,20 @@ func runAPIServer() {
 		log.Fatalf("❌ Failed to create Kubernetes client: %v", err)
 	}
 
-	serverVersion, err := clientset.Discovery().ServerVersion()
+	serverVersion, err := k8s.WaitForServer(context.Background(), clientset.Discovery(), connectAttempts, connectBackoff)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to Kubernetes cluster: %v", err)
 	}
 	log.Printf("✅ Successfully connected to Kubernetes cluster (version: %s)", serverVersion.String())
 
 	processor := NewEventProcessor(clientset, config)
+	if config.WatchFrontendPages {
+		dynamicClient, err := GetDynamicClient()
+		if err != nil {
+			log.Fatalf("❌ Failed to create dynamic client: %v", err)
+		}
+		processor.SetDynamicClient(dynamicClient)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -416,6 +486,7 @@ func runAPIServer() {
 
 	// Start API server
 	go processor.StartAPIServer()
+	go startPprofServer(pprofBindAddress(enablePprof, pprofPort))
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
@@ -444,8 +515,14 @@ func init() {
 	// Add flags for Step 7+ API
 	apiServerCmd.Flags().IntVar(&apiPort, "port", 8080, "API server port")
 	apiServerCmd.Flags().StringVar(&configFile, "config", "", "Path to configuration file")
-	apiServerCmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period")
+	apiServerCmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period (default 30s or the config file value; 0 disables resync)")
 	apiServerCmd.Flags().IntVar(&informerWorkers, "workers", 0, "Number of worker goroutines")
+	apiServerCmd.Flags().IntVar(&connectAttempts, "connect-attempts", 5, "Number of attempts to reach the cluster on startup")
+	apiServerCmd.Flags().DurationVar(&connectBackoff, "connect-backoff", time.Second, "Initial wait between connection attempts, doubled after each failure")
+	apiServerCmd.Flags().DurationVar(&informerStaleThreshold, "stale-threshold", 0, "Report the watch as degraded after this long without events or resyncs (default 5m or the config file value)")
+	apiServerCmd.Flags().BoolVar(&watchFrontendPages, "watch-frontendpages", false, "Also watch and serve FrontendPage custom resources (skipped if the CRD is not installed)")
+	apiServerCmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve net/http/pprof on localhost at --pprof-port")
+	apiServerCmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "Port for pprof debug endpoints")
 
 	// Register command
 	RootCmd.AddCommand(apiServerCmd)
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestDeploymentStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		desired       *int32
+		replicas      int32
+		readyReplicas int32
+		want          string
+	}{
+		{name: "all replicas ready", desired: int32Ptr(3), replicas: 3, readyReplicas: 3, want: "Healthy"},
+		{name: "no replicas ready", desired: int32Ptr(3), replicas: 3, readyReplicas: 0, want: "Unhealthy"},
+		{name: "some replicas ready", desired: int32Ptr(3), replicas: 3, readyReplicas: 1, want: "Progressing"},
+		{name: "scaling up with existing pods ready", desired: int32Ptr(3), replicas: 2, readyReplicas: 2, want: "Progressing"},
+		{name: "ready above desired during scale-down", desired: int32Ptr(1), replicas: 3, readyReplicas: 3, want: "Healthy"},
+		{name: "scaled to zero", desired: int32Ptr(0), replicas: 0, readyReplicas: 0, want: "Healthy"},
+		{name: "scaling to zero with pods terminating", desired: int32Ptr(0), replicas: 2, readyReplicas: 1, want: "Progressing"},
+		{name: "nil replicas defaults to one ready", desired: nil, replicas: 1, readyReplicas: 1, want: "Healthy"},
+		{name: "nil replicas defaults to one not ready", desired: nil, replicas: 1, readyReplicas: 0, want: "Unhealthy"},
+		{name: "new deployment without status", desired: int32Ptr(2), replicas: 0, readyReplicas: 0, want: "Unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: tt.desired},
+				Status: appsv1.DeploymentStatus{
+					Replicas:      tt.replicas,
+					ReadyReplicas: tt.readyReplicas,
+				},
+			}
+
+			if got := deploymentStatus(deployment); got != tt.want {
+				t.Errorf("deploymentStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
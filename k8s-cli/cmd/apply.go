@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"k8s-cli/internal/k8s"
+	"k8s-cli/internal/utils"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // applyCmd represents the apply command
@@ -26,17 +33,81 @@ var applyFileCmd = &cobra.Command{
   k8s-cli apply file pod.yaml
 
   # Apply file in specific namespace
-  k8s-cli apply file deployment.yaml -n my-app`,
+  k8s-cli apply file deployment.yaml -n my-app
+
+  # Server-side apply, recommended for CRDs and other large objects
+  k8s-cli apply file frontendpage-crd.yaml --server-side
+
+  # Take ownership of fields managed by another tool
+  k8s-cli apply file deployment.yaml --server-side --force-conflicts
+
+  # Apply an environment overlay: patch.yaml is merged into the objects of
+  # base.yaml with the same kind and name
+  k8s-cli apply file base.yaml --patch prod-patch.yaml -n production
+
+  # Block until the deployment has rolled out, e.g. as a CI deploy step
+  k8s-cli apply file deployment.yaml --wait --timeout 3m`,
 	RunE: runApplyFile,
 }
 
+// applyDirCmd applies every manifest in a directory
+var applyDirCmd = &cobra.Command{
+	Use:   "dir <directory>",
+	Short: "Apply all YAML files in a directory",
+	Long: `Server-side apply every .yaml, .yml and .json manifest under a directory,
+including multi-document files.
+
+With --prune, objects that match the label selector but are no longer in any
+manifest are deleted afterwards, like kubectl apply --prune. Pruning only looks
+at the kinds being applied plus ConfigMaps, Secrets, Services and Deployments,
+in the namespaces the manifests were applied to, and only at objects k8s-cli
+applied itself, so resources created by hand or by other tools are never
+pruned. It is skipped if any manifest failed to apply.
+
+With --wait, the command then blocks until every applied Deployment and
+StatefulSet has all replicas updated and ready and every Pod is Running, or
+--timeout passes, and fails naming the resources that are not ready.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Apply a directory of manifests
+  k8s-cli apply dir ./manifests -n my-app
+
+  # Apply and delete labelled resources that were removed from the directory
+  k8s-cli apply dir ./manifests --prune -l app=myapp
+
+  # Apply and wait for every Deployment, StatefulSet and Pod to be ready
+  k8s-cli apply dir ./manifests --wait`,
+	RunE: runApplyDir,
+}
+
 func init() {
 	rootCmd.AddCommand(applyCmd)
 	applyCmd.AddCommand(applyFileCmd)
+	applyCmd.AddCommand(applyDirCmd)
+
+	applyFileCmd.Flags().Bool("server-side", false, "Use server-side apply instead of create")
+	applyFileCmd.Flags().Bool("force-conflicts", false, "With --server-side, take ownership of fields managed by others instead of failing")
+	applyFileCmd.Flags().String("patch", "", "Strategic-merge patch file applied to the objects of the same kind and name before creating them")
+	applyFileCmd.Flags().Bool("wait", false, "Wait until applied Deployments and StatefulSets are ready and Pods are Running")
+	applyFileCmd.Flags().Duration("timeout", 5*time.Minute, "How long --wait waits for all resources")
+
+	applyDirCmd.Flags().Bool("force-conflicts", false, "Take ownership of fields managed by others instead of failing")
+	applyDirCmd.Flags().Bool("prune", false, "Delete resources matching --selector that are not in the manifests")
+	applyDirCmd.Flags().StringP("selector", "l", "", "Label selector for --prune (required with --prune)")
+	applyDirCmd.Flags().Bool("wait", false, "Wait until applied Deployments and StatefulSets are ready and Pods are Running")
+	applyDirCmd.Flags().Duration("timeout", 5*time.Minute, "How long --wait waits for all resources")
 }
 
 func runApplyFile(cmd *cobra.Command, args []string) error {
 	filename := args[0]
+	serverSide, _ := cmd.Flags().GetBool("server-side")
+	forceConflicts, _ := cmd.Flags().GetBool("force-conflicts")
+	patchFile, _ := cmd.Flags().GetString("patch")
+	waitReady, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	if forceConflicts && !serverSide {
+		return usageErrorf("--force-conflicts requires --server-side")
+	}
 
 	// Read YAML file
 	yamlData, err := ioutil.ReadFile(filename)
@@ -44,20 +115,217 @@ func runApplyFile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error reading file %s: %w", filename, err)
 	}
 
+	// Without --patch the file is applied as before; with it every patched
+	// document of the base is applied
+	documents := [][]byte{yamlData}
+	if patchFile != "" {
+		patchData, err := ioutil.ReadFile(patchFile)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", patchFile, err)
+		}
+		if documents, err = k8s.PatchManifests(yamlData, patchData); err != nil {
+			return fmt.Errorf("error applying %s to %s: %w", patchFile, filename, err)
+		}
+	}
+
 	// Create Kubernetes client
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		if err := checkResourceType(client, document); err != nil {
+			return err
+		}
+	}
+
 	namespace := viper.GetString("namespace")
 
+	var applied []k8s.ObjectRef
+	for _, document := range documents {
+		ref, err := applyDocument(client, document, filename, namespace, serverSide, forceConflicts)
+		if err != nil {
+			return err
+		}
+		applied = append(applied, ref)
+	}
+
+	if waitReady {
+		return waitForWorkloads(client, applied, timeout)
+	}
+	return nil
+}
+
+// applyDocument creates one manifest, or server-side applies it, and returns what it applied
+func applyDocument(client *k8s.Client, yamlData []byte, filename, namespace string, serverSide, forceConflicts bool) (k8s.ObjectRef, error) {
+	if serverSide {
+		applied, err := client.ServerSideApplyFromYAML(yamlData, namespace, k8s.ApplyOptions{ForceConflicts: forceConflicts})
+		kind, name := yamlObjectRef(yamlData)
+		reportResult(utils.ActionResult{Action: "apply", Kind: kind, Name: name, Namespace: namespace, Result: "serverside-applied"}, err)
+		if err != nil {
+			return k8s.ObjectRef{}, err
+		}
+
+		infof("✅ %s '%s' applied server-side from file: %s\n", applied.GetKind(), applied.GetName(), filename)
+		return k8s.NewObjectRef(applied), nil
+	}
+
 	// Apply YAML
-	err = client.CreateFromYAML(yamlData, namespace)
+	err := client.CreateFromYAML(yamlData, namespace)
+	kind, name := yamlObjectRef(yamlData)
+	reportResult(utils.ActionResult{Action: "apply", Kind: kind, Name: name, Namespace: namespace, Result: "created"}, err)
+	if err != nil {
+		return k8s.ObjectRef{}, fmt.Errorf("error applying YAML: %w", err)
+	}
+
+	infof("✅ Resources successfully created from file: %s\n", filename)
+	return k8s.ManifestObjectRef(yamlData, namespace)
+}
+
+// waitPollInterval is how often apply --wait checks a resource
+const waitPollInterval = 2 * time.Second
+
+// waitForWorkloads blocks until the workloads among refs are ready, all within
+// timeout, and names every one that isn't. Other kinds are not waited for.
+func waitForWorkloads(client *k8s.Client, refs []k8s.ObjectRef, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var failures []string
+	for _, ref := range refs {
+		if !k8s.IsWorkload(ref.Kind) {
+			continue
+		}
+
+		infof("⏳ Waiting for %s to be ready...\n", ref)
+		err := client.WaitForReady(ctx, ref, waitPollInterval)
+		reportResult(utils.ActionResult{Action: "wait", Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace, Result: "ready"}, err)
+		if err != nil {
+			infof("❌ %v\n", err)
+			failures = append(failures, err.Error())
+			continue
+		}
+		infof("✅ %s is ready\n", ref)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d resource(s) not ready within %s: %s", len(failures), timeout, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// manifestFiles returns the YAML and JSON files under dir in lexical order
+func manifestFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+func runApplyDir(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	forceConflicts, _ := cmd.Flags().GetBool("force-conflicts")
+	prune, _ := cmd.Flags().GetBool("prune")
+	selector, _ := cmd.Flags().GetString("selector")
+	waitReady, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	if prune && strings.TrimSpace(selector) == "" {
+		return usageErrorf("--prune requires a label selector (-l), refusing to prune unscoped")
+	}
+
+	files, err := manifestFiles(dir)
 	if err != nil {
-		return fmt.Errorf("error applying YAML: %w", err)
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no YAML or JSON manifests found in %s", dir)
 	}
 
-	fmt.Printf("✅ Resources successfully created from file: %s\n", filename)
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
+	namespace := viper.GetString("namespace")
+
+	var applied []k8s.ObjectRef
+	failed := 0
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", file, err)
+		}
+
+		documents, err := k8s.SplitYAMLDocuments(data)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", file, err)
+		}
+
+		for _, document := range documents {
+			var obj *unstructured.Unstructured
+			err := checkResourceType(client, document)
+			if err == nil {
+				obj, err = client.ServerSideApplyFromYAML(document, namespace, k8s.ApplyOptions{ForceConflicts: forceConflicts})
+			}
+			kind, name := yamlObjectRef(document)
+			reportResult(utils.ActionResult{Action: "apply", Kind: kind, Name: name, Namespace: namespace, Result: "serverside-applied"}, err)
+			if err != nil {
+				failed++
+				infof("❌ %s '%s' from %s: %v\n", kind, name, file, err)
+				continue
+			}
+
+			applied = append(applied, k8s.NewObjectRef(obj))
+			infof("✅ %s '%s' applied from file: %s\n", obj.GetKind(), obj.GetName(), file)
+		}
+	}
+
+	if failed > 0 {
+		if prune {
+			infof("⚠️ Skipping prune because %d manifest(s) failed to apply\n", failed)
+		}
+		return fmt.Errorf("%d of %d manifest(s) failed to apply", failed, failed+len(applied))
+	}
+
+	if prune {
+		pruned, err := client.Prune(applied, k8s.PruneOptions{Selector: selector, Namespace: namespace})
+		for _, ref := range pruned {
+			reportResult(utils.ActionResult{Action: "prune", Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace, Result: "pruned"}, nil)
+			infof("🗑️ %s pruned\n", ref)
+		}
+		if err != nil {
+			return err
+		}
+
+		infof("✅ Applied %d object(s), pruned %d\n", len(applied), len(pruned))
+	}
+
+	if waitReady {
+		return waitForWorkloads(client, applied, timeout)
+	}
 	return nil
 }
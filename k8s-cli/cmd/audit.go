@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry is one line of the --audit-log file
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource,omitempty"`
+	Trigger   string    `json:"trigger,omitempty"`
+	// InputHash fingerprints the inputs without copying values such as secrets into the log
+	InputHash string `json:"input_hash"`
+	Actor     string `json:"actor"`
+	// ClaimedBy is the Port.io user from the payload's context.by; the caller
+	// chooses it freely, so it is recorded next to Actor but never trusted
+	ClaimedBy  string `json:"claimed_by,omitempty"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	Outcome    string `json:"outcome"` // success, error, invalid or failure
+	Message    string `json:"message,omitempty"`
+}
+
+// AuditLogger appends AuditEntries as JSON lines. Writes are serialized so
+// concurrent handlers never interleave lines, and the file is renamed with a
+// timestamp suffix once it would grow past maxSize. Rotated files are kept;
+// pruning them is left to whoever owns the retention policy.
+type AuditLogger struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// NewAuditLogger opens path for appending; maxSize <= 0 disables rotation
+func NewAuditLogger(path string, maxSize int64) (*AuditLogger, error) {
+	a := &AuditLogger{path: path, maxSize: maxSize}
+	if err := a.openLocked(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *AuditLogger) openLocked() error {
+	file, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	a.file, a.size = file, info.Size()
+	return nil
+}
+
+// Log writes entry as one line. It is a no-op on a nil logger, so callers
+// don't have to check whether --audit-log is set.
+func (a *AuditLogger) Log(entry AuditEntry) error {
+	if a == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return errors.New("audit log is closed")
+	}
+	// A failed rotation must not end the log: the entry is still written
+	// and the rotation error returned
+	var rotateErr error
+	if a.maxSize > 0 && a.size > 0 && a.size+int64(len(line)) > a.maxSize {
+		rotateErr = a.rotateLocked()
+	}
+
+	n, err := a.file.Write(line)
+	a.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return rotateErr
+}
+
+// rotateLocked renames the file with a timestamp suffix and opens a new one.
+// When the rename fails, it reopens a.path instead, recreating it if it was
+// removed, so logging goes on in the current file.
+func (a *AuditLogger) rotateLocked() error {
+	rotated := fmt.Sprintf("%s.%s", a.path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.Rename(a.path, rotated); err != nil {
+		if reopenErr := a.reopenLocked(); reopenErr != nil {
+			return fmt.Errorf("error rotating audit log: %w; %v", err, reopenErr)
+		}
+		return fmt.Errorf("error rotating audit log: %w", err)
+	}
+	return a.reopenLocked()
+}
+
+// reopenLocked opens a.path and only then closes the previous file, so a
+// failed open leaves the logger writing where it was
+func (a *AuditLogger) reopenLocked() error {
+	previous := a.file
+	if err := a.openLocked(); err != nil {
+		return err
+	}
+	if err := previous.Close(); err != nil {
+		return fmt.Errorf("error closing rotated audit log: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the file; later Log calls fail
+func (a *AuditLogger) Close() error {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// auditActorContextKey carries the authenticated caller set by requireToken
+type auditActorContextKey struct{}
+
+// auditRemoteAddrContextKey carries the client address set by withAuditRemoteAddr
+type auditRemoteAddrContextKey struct{}
+
+func withAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorContextKey{}, actor)
+}
+
+// withAuditRemoteAddr records the client address for the audit log of any
+// action the request runs
+func withAuditRemoteAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), auditRemoteAddrContextKey{}, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// auditActor is the caller authenticated by requireToken, else "anonymous".
+// Nothing from the request body counts, since any caller can put anything there.
+func auditActor(ctx context.Context) string {
+	if actor, _ := ctx.Value(auditActorContextKey{}).(string); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+func auditRemoteAddr(ctx context.Context) string {
+	remoteAddr, _ := ctx.Value(auditRemoteAddrContextKey{}).(string)
+	return remoteAddr
+}
+
+// hashActionInputs is a SHA-256 over the inputs as JSON; encoding/json sorts
+// map keys, so equal inputs always hash the same
+func hashActionInputs(inputs map[string]interface{}) string {
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// auditAction records how processAction ended. A failing audit log is
+// reported but doesn't fail the action, which has already run.
+func (p *PlatformAPI) auditAction(ctx context.Context, req *ActionRequest, response *ActionResponse, actionErr error) {
+	if p.auditLog == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Timestamp:  time.Now().UTC(),
+		RequestID:  requestIDFromContext(ctx),
+		Action:     req.Action,
+		Resource:   req.ResourceId,
+		Trigger:    req.Trigger,
+		InputHash:  hashActionInputs(req.Inputs),
+		Actor:      auditActor(ctx),
+		RemoteAddr: auditRemoteAddr(ctx),
+		DryRun:     req.DryRun,
+	}
+	if by, _ := req.Context["by"].(string); by != "" {
+		entry.ClaimedBy = by
+	}
+
+	var validationErr *ActionValidationError
+	switch {
+	case errors.As(actionErr, &validationErr):
+		entry.Outcome, entry.Message = "invalid", actionErr.Error()
+	case actionErr != nil:
+		entry.Outcome, entry.Message = "failure", actionErr.Error()
+	case response != nil && response.Status == "error":
+		entry.Outcome, entry.Message = "error", response.Message
+	default:
+		entry.Outcome = "success"
+		if response != nil {
+			entry.Message = response.Message
+		}
+	}
+
+	if err := p.auditLog.Log(entry); err != nil {
+		logRequestf(ctx, "❌ Failed to write audit log: %v", err)
+	}
+}
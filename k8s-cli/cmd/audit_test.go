@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func readAuditEntries(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLoggerConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := NewAuditLogger(path, 0)
+	if err != nil {
+		t.Fatalf("NewAuditLogger() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			entry := AuditEntry{Action: "create_frontend", Resource: fmt.Sprintf("page-%d", i), Message: strings.Repeat("x", 4096)}
+			if err := auditLog.Log(entry); err != nil {
+				t.Errorf("Log() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := auditLog.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := len(readAuditEntries(t, path)); got != 50 {
+		t.Errorf("got %d entries, want 50", got)
+	}
+}
+
+func TestAuditLoggerRotates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	auditLog, err := NewAuditLogger(path, 300)
+	if err != nil {
+		t.Fatalf("NewAuditLogger() error = %v", err)
+	}
+	defer auditLog.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := auditLog.Log(AuditEntry{Action: "scale_frontend", Message: strings.Repeat("x", 100)}); err != nil {
+			t.Fatalf("Log() error = %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) < 2 {
+		t.Fatalf("files = %v, want the log and at least one rotated file", files)
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 300 {
+			t.Errorf("%s is %d bytes, want at most 300", file, info.Size())
+		}
+	}
+}
+
+func TestAuditLoggerKeepsLoggingWhenRotationFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := NewAuditLogger(path, 300)
+	if err != nil {
+		t.Fatalf("NewAuditLogger() error = %v", err)
+	}
+	defer auditLog.Close()
+
+	entry := AuditEntry{Action: "scale_frontend", Message: strings.Repeat("x", 100)}
+	if err := auditLog.Log(entry); err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	// With the file gone the rename fails
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := auditLog.Log(entry); err == nil || !strings.Contains(err.Error(), "error rotating audit log") {
+		t.Fatalf("Log() error = %v, want the rotation error", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("audit log was not reopened: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("reopened audit log has %d entries, want the one logged while rotating:\n%s", lines, data)
+	}
+
+	// The file is back, so the next rotation succeeds
+	if err := auditLog.Log(entry); err != nil {
+		t.Fatalf("Log() after a failed rotation error = %v", err)
+	}
+}
+
+func TestPlatformAuditsProcessedActions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := NewAuditLogger(path, 0)
+	if err != nil {
+		t.Fatalf("NewAuditLogger() error = %v", err)
+	}
+	p := &PlatformAPI{auditLog: auditLog, portClient: &PortClient{}}
+
+	ctx := withAuditActor(context.WithValue(context.Background(), requestIDContextKey{}, "req-1"), "api-token")
+	ctx = context.WithValue(ctx, auditRemoteAddrContextKey{}, "192.0.2.1:51234")
+	p.processAction(ctx, &ActionRequest{Action: "create_frontend", Trigger: "api", Inputs: map[string]interface{}{"title": "Home"}})
+	p.processAction(context.Background(), &ActionRequest{Action: "launch_rockets", Context: map[string]interface{}{"by": "jane@example.com"}})
+	auditLog.Close()
+
+	entries := readAuditEntries(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	invalid := entries[0]
+	if invalid.Outcome != "invalid" || invalid.Actor != "api-token" || invalid.RequestID != "req-1" || invalid.Trigger != "api" || invalid.RemoteAddr != "192.0.2.1:51234" {
+		t.Errorf("entry = %+v, want an invalid run by api-token", invalid)
+	}
+	if invalid.InputHash != hashActionInputs(map[string]interface{}{"title": "Home"}) || !strings.HasPrefix(invalid.InputHash, "sha256:") {
+		t.Errorf("input_hash = %q", invalid.InputHash)
+	}
+	if strings.Contains(invalid.Message, "Home") {
+		t.Errorf("message %q leaks an input value", invalid.Message)
+	}
+
+	unknown := entries[1]
+	// context.by is only a claim, the unauthenticated caller stays anonymous
+	if unknown.Outcome != "error" || unknown.Actor != "anonymous" || unknown.ClaimedBy != "jane@example.com" {
+		t.Errorf("entry = %+v, want an anonymous error run claiming the Port.io user", unknown)
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"k8s-cli/internal/k8s"
+)
+
+// errAccessDenied makes can-i exit with status 1 when the answer is no
+var errAccessDenied = errors.New("access denied")
+
+// canICmd checks whether an action is allowed
+var canICmd = &cobra.Command{
+	Use:   "can-i <verb> <resource>[/<name>] | <verb> <url>",
+	Short: "Check whether an action is allowed",
+	Long: `Check whether the current user may perform an action, like kubectl auth can-i.
+
+Prints yes or no and exits with status 0 or 1. The question is asked with a
+SelfSubjectAccessReview, so combine it with --as and --as-group to check what
+another user or service account can do. Resource types are resolved to their
+API group through discovery; use TYPE.GROUP to pick a group explicitly.`,
+	Args: cobra.ExactArgs(2),
+	Example: `  # Can I create deployments in my-app?
+  k8s-cli can-i create deployments -n my-app
+
+  # Can the controller's service account update FrontendPage status?
+  k8s-cli can-i update frontendpages --subresource status --as system:serviceaccount:default:k8s-cli
+
+  # Can a scraper read the metrics endpoint?
+  k8s-cli can-i get /metrics --as system:serviceaccount:monitoring:prometheus`,
+	RunE: runCanI,
+}
+
+func init() {
+	rootCmd.AddCommand(canICmd)
+
+	canICmd.Flags().String("subresource", "", "Subresource to check, e.g. status or scale")
+}
+
+func runCanI(cmd *cobra.Command, args []string) error {
+	subresource, _ := cmd.Flags().GetString("subresource")
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	result, err := client.CanI(context.TODO(), k8s.AccessCheck{
+		Verb:        args[0],
+		Resource:    args[1],
+		Subresource: subresource,
+		Namespace:   viper.GetString("namespace"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printAccessResult(result)
+	if !result.Allowed {
+		// the answer is already printed, only the exit status is left
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return errAccessDenied
+	}
+	return nil
+}
+
+func printAccessResult(result *k8s.AccessResult) {
+	if viper.GetBool("quiet") {
+		return
+	}
+	if result.Allowed {
+		fmt.Println("yes")
+		return
+	}
+	if result.Reason != "" {
+		fmt.Printf("no - %s\n", result.Reason)
+		return
+	}
+	fmt.Println("no")
+}
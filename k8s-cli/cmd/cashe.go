@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,6 +15,10 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s-cli/internal/informer"
+	"k8s-cli/internal/k8s"
 )
 
 var (
@@ -30,6 +33,8 @@ type Step8APIResponse struct {
 	Status    string       `json:"status"`
 	Data      interface{}  `json:"data,omitempty"`
 	Error     string       `json:"error,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
 	Count     int          `json:"count,omitempty"`
 	Metadata  *APIMetadata `json:"metadata,omitempty"`
 	Timestamp time.Time    `json:"timestamp"`
@@ -38,6 +43,8 @@ type Step8APIResponse struct {
 type APIMetadata struct {
 	Page       int    `json:"page,omitempty"`
 	PageSize   int    `json:"page_size,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
 	TotalCount int    `json:"total_count,omitempty"`
 	SortBy     string `json:"sort_by,omitempty"`
 	FilterBy   string `json:"filter_by,omitempty"`
@@ -68,6 +75,7 @@ type DeploymentStrategy struct {
 }
 
 type CacheMetrics struct {
+	Namespace             string                 `json:"namespace,omitempty"`
 	TotalDeployments      int                    `json:"total_deployments"`
 	NamespaceDistribution map[string]int         `json:"namespace_distribution"`
 	StatusDistribution    map[string]int         `json:"status_distribution"`
@@ -90,6 +98,8 @@ func (e *EventProcessor) StartStep8APIServer() {
 	mux.HandleFunc("/api/v2/cache/search", e.handleStep8CacheSearchAPI)
 	mux.HandleFunc("/api/v2/cache/status", e.handleStep8CacheStatusAPI)
 	mux.HandleFunc("/api/v2/health", e.handleStep8HealthAPI)
+	mux.HandleFunc("/api/v2/ws/deployments", e.handleDeploymentsWebSocket)
+	mux.HandleFunc("/openapi.json", serveOpenAPI(step8OpenAPI()))
 
 	// Debug endpoints
 	if enableDebug {
@@ -103,17 +113,19 @@ func (e *EventProcessor) StartStep8APIServer() {
 	}
 
 	// Enable CORS and middleware
-	handler := e.step8Middleware(enableCORS(mux))
+	handler := withRequestID(e.step8Middleware(enableCORS(mux)))
 
 	port := step8Port
 	log.Printf("🌐 Starting Step 8 Advanced API server on port %d", port)
 	log.Printf("📋 Step 8 Enhanced endpoints:")
 	log.Printf("  GET /api/v2/deployments - Advanced deployment listing with filtering")
 	log.Printf("  GET /api/v2/deployments/{namespace}/{name} - Detailed deployment info")
-	log.Printf("  GET /api/v2/cache/metrics - Cache metrics and analytics")
+	log.Printf("  GET /api/v2/cache/metrics - Cache metrics and analytics (?namespace= for one namespace)")
 	log.Printf("  GET /api/v2/cache/search - Search deployments in cache")
 	log.Printf("  GET /api/v2/cache/status - Cache status and health")
 	log.Printf("  GET /api/v2/health - Service health check")
+	log.Printf("  GET /api/v2/ws/deployments - WebSocket stream of deployment changes")
+	log.Printf("  GET /openapi.json - OpenAPI 3.0 specification")
 
 	if enableDebug {
 		log.Printf("  GET /api/v2/debug/cache-dump - Debug cache contents")
@@ -147,13 +159,13 @@ func (e *EventProcessor) step8Middleware(next http.Handler) http.Handler {
 		w.Header().Set("X-Service", "k8s-cli-step8")
 
 		// Log request
-		log.Printf("📥 API Request: %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		logRequestf(r.Context(), "📥 API Request: %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
 
 		next.ServeHTTP(w, r)
 
 		// Log response time
 		duration := time.Since(start)
-		log.Printf("📤 API Response: %s %s completed in %v", r.Method, r.URL.Path, duration)
+		logRequestf(r.Context(), "📤 API Response: %s %s completed in %v", r.Method, r.URL.Path, duration)
 	})
 }
 
@@ -187,8 +199,9 @@ func (e *EventProcessor) handleStep8RootAPI(w http.ResponseWriter, r *http.Reque
 				"status":  "GET /api/v2/cache/status",
 			},
 			"utility": map[string]string{
-				"health": "GET /api/v2/health",
-				"debug":  "GET /api/v2/debug/*",
+				"health":  "GET /api/v2/health",
+				"debug":   "GET /api/v2/debug/*",
+				"openapi": "GET /openapi.json",
 			},
 		},
 		"query_parameters": map[string]interface{}{
@@ -203,7 +216,7 @@ func (e *EventProcessor) handleStep8RootAPI(w http.ResponseWriter, r *http.Reque
 		},
 	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      apiInfo,
 		Timestamp: time.Now(),
@@ -213,32 +226,50 @@ func (e *EventProcessor) handleStep8RootAPI(w http.ResponseWriter, r *http.Reque
 // Step 8: Advanced deployments listing with filtering, sorting, pagination
 func (e *EventProcessor) handleStep8DeploymentsAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		e.writeStep8ErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		e.writeStep8ErrorResponse(w, r, ErrCodeMethodNotAllowed, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	// Parse query parameters
 	params := e.parseQueryParams(r)
+	ctx := r.Context()
+
+	if _, err := parseLabelSelector(params["labelSelector"]); err != nil {
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidSelector, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	var deployments []DeploymentDetail
-	allDeployments := e.getAllDeploymentsFromCache()
+	candidates := e.filterCandidates(params)
 
 	// Apply filters
-	filteredDeployments := e.filterDeployments(allDeployments, params)
+	filteredDeployments, err := e.filterDeployments(ctx, candidates, params)
+	if err != nil {
+		logCancelledRequest(r, err)
+		return
+	}
 
 	// Sort deployments
-	sortedDeployments := e.sortDeployments(filteredDeployments, params)
+	sortedDeployments, err := e.sortDeployments(ctx, filteredDeployments, params)
+	if err != nil {
+		logCancelledRequest(r, err)
+		return
+	}
 
 	// Apply pagination
 	paginatedDeployments, metadata := e.paginateDeployments(sortedDeployments, params)
 
 	// Convert to detailed format
 	for _, deployment := range paginatedDeployments {
+		if err := ctx.Err(); err != nil {
+			logCancelledRequest(r, err)
+			return
+		}
 		detail := e.createDeploymentDetail(deployment)
 		deployments = append(deployments, detail)
 	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      deployments,
 		Count:     len(deployments),
@@ -250,7 +281,7 @@ func (e *EventProcessor) handleStep8DeploymentsAPI(w http.ResponseWriter, r *htt
 // Step 8: Detailed deployment information
 func (e *EventProcessor) handleStep8DeploymentDetailAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		e.writeStep8ErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed)
+		e.writeStep8ErrorResponse(w, r, ErrCodeMethodNotAllowed, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -259,7 +290,7 @@ func (e *EventProcessor) handleStep8DeploymentDetailAPI(w http.ResponseWriter, r
 	parts := strings.Split(strings.Trim(path, "/"), "/")
 
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		e.writeStep8ErrorResponse(w, "Invalid path. Use /api/v2/deployments/{namespace}/{name}", http.StatusBadRequest)
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidPath, "Invalid path. Use /api/v2/deployments/{namespace}/{name}", http.StatusBadRequest)
 		return
 	}
 
@@ -269,50 +300,79 @@ func (e *EventProcessor) handleStep8DeploymentDetailAPI(w http.ResponseWriter, r
 	// Get from cache
 	deployment := e.getDeploymentFromCache(key)
 	if deployment == nil {
-		e.writeStep8ErrorResponse(w, "Deployment not found in cache", http.StatusNotFound)
+		e.writeStep8ErrorResponse(w, r, ErrCodeNotFound, "Deployment not found in cache", http.StatusNotFound)
 		return
 	}
 
 	detail := e.createDeploymentDetail(deployment)
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      detail,
 		Timestamp: time.Now(),
 	})
 }
 
-// Step 8: Cache metrics and analytics
+// Step 8: Cache metrics and analytics, cluster-wide or for ?namespace=
 func (e *EventProcessor) handleStep8CacheMetricsAPI(w http.ResponseWriter, r *http.Request) {
-	metrics := e.calculateCacheMetrics()
+	namespace := r.URL.Query().Get("namespace")
+	if err := validateNamespaceParam(namespace); err != nil {
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidParameter, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	metrics := e.calculateCacheMetrics(namespace)
+
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      metrics,
 		Timestamp: time.Now(),
 	})
 }
 
-// Step 8: Search deployments in cache
+// Step 8: Search deployments in cache. Matches are ranked by relevance (see
+// searchScore), ties ordered by namespace and name so offset/limit page through
+// them consistently; metadata.total_count is the number of matches before
+// paging. With debug=true each result carries its score.
 func (e *EventProcessor) handleStep8CacheSearchAPI(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	namespace := r.URL.Query().Get("namespace")
-	fields := r.URL.Query().Get("fields")
-	limitStr := r.URL.Query().Get("limit")
 
-	limit := 50 // default
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
+	fields, err := parseSearchFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidParameter, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	results := e.searchDeployments(query, namespace, fields, limit)
+	offset, err := parseNonNegativeParam(r, "offset", 0)
+	if err != nil {
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidParameter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseNonNegativeParam(r, "limit", 50)
+	if err != nil || limit == 0 {
+		e.writeStep8ErrorResponse(w, r, ErrCodeInvalidParameter, "limit must be a positive integer", http.StatusBadRequest)
+		return
+	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
-		Status:    "success",
-		Data:      results,
-		Count:     len(results),
+	includeScore := r.URL.Query().Get("debug") == "true"
+
+	results, total, err := e.searchDeployments(r.Context(), query, namespace, fields, offset, limit, includeScore)
+	if err != nil {
+		logCancelledRequest(r, err)
+		return
+	}
+
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
+		Status: "success",
+		Data:   results,
+		Count:  len(results),
+		Metadata: &APIMetadata{
+			Offset:     offset,
+			Limit:      limit,
+			TotalCount: total,
+			FilterBy:   namespace,
+		},
 		Timestamp: time.Now(),
 	})
 }
@@ -321,8 +381,8 @@ func (e *EventProcessor) handleStep8CacheSearchAPI(w http.ResponseWriter, r *htt
 func (e *EventProcessor) handleStep8CacheStatusAPI(w http.ResponseWriter, r *http.Request) {
 	status := map[string]interface{}{
 		"cache_healthy": true,
-		"cache_size":    len(e.deploymentCache),
-		"indexer_size":  len(e.cacheIndexer.List()),
+		"cache_size":    e.cacheSize(),
+		"indexer_size":  len(e.informer.List()),
 		"last_sync":     time.Now(), // Would track real sync time
 		"sync_status":   "active",
 		"worker_status": "running",
@@ -332,7 +392,7 @@ func (e *EventProcessor) handleStep8CacheStatusAPI(w http.ResponseWriter, r *htt
 		"memory_usage":  "unknown", // Could add runtime.MemStats
 	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      status,
 		Timestamp: time.Now(),
@@ -344,13 +404,12 @@ func (e *EventProcessor) handleStep8HealthAPI(w http.ResponseWriter, r *http.Req
 	uptime := time.Since(e.startTime)
 
 	health := map[string]interface{}{
-		"status":          "healthy",
 		"service":         "k8s-cli Step 8 API",
 		"version":         "2.0.0",
 		"step":            "Step 8 - Advanced Cache Handlers",
 		"uptime":          uptime.String(),
 		"uptime_seconds":  int(uptime.Seconds()),
-		"cache_healthy":   len(e.cacheIndexer.List()) >= 0,
+		"cache_healthy":   len(e.informer.List()) >= 0,
 		"workers_running": e.config.Workers,
 		"api_endpoints":   11, // Count of endpoints
 		"features_enabled": map[string]bool{
@@ -362,8 +421,9 @@ func (e *EventProcessor) handleStep8HealthAPI(w http.ResponseWriter, r *http.Req
 		},
 		"last_activity": time.Now(),
 	}
+	e.addWatchHealth(health)
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      health,
 		Timestamp: time.Now(),
@@ -373,17 +433,17 @@ func (e *EventProcessor) handleStep8HealthAPI(w http.ResponseWriter, r *http.Req
 // Step 8: Debug endpoints
 func (e *EventProcessor) handleStep8CacheDumpAPI(w http.ResponseWriter, r *http.Request) {
 	if !enableDebug {
-		e.writeStep8ErrorResponse(w, "Debug endpoints are disabled", http.StatusForbidden)
+		e.writeStep8ErrorResponse(w, r, ErrCodeForbidden, "Debug endpoints are disabled", http.StatusForbidden)
 		return
 	}
 
 	dump := map[string]interface{}{
 		"cache_keys":      e.getCacheKeys(),
-		"indexer_objects": len(e.cacheIndexer.List()),
+		"indexer_objects": len(e.informer.List()),
 		"cache_sample":    e.getCacheSample(5),
 	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      dump,
 		Timestamp: time.Now(),
@@ -392,7 +452,7 @@ func (e *EventProcessor) handleStep8CacheDumpAPI(w http.ResponseWriter, r *http.
 
 func (e *EventProcessor) handleStep8PerformanceAPI(w http.ResponseWriter, r *http.Request) {
 	if !enableDebug {
-		e.writeStep8ErrorResponse(w, "Debug endpoints are disabled", http.StatusForbidden)
+		e.writeStep8ErrorResponse(w, r, ErrCodeForbidden, "Debug endpoints are disabled", http.StatusForbidden)
 		return
 	}
 
@@ -406,7 +466,7 @@ func (e *EventProcessor) handleStep8PerformanceAPI(w http.ResponseWriter, r *htt
 		"cpu_usage":             "2%",
 	}
 
-	e.writeStep8JSONResponse(w, Step8APIResponse{
+	e.writeStep8JSONResponse(w, r, Step8APIResponse{
 		Status:    "success",
 		Data:      perf,
 		Timestamp: time.Now(),
@@ -428,7 +488,7 @@ k8s_cli_workers_running %d
 # HELP k8s_cli_uptime_seconds Service uptime in seconds
 # TYPE k8s_cli_uptime_seconds counter
 k8s_cli_uptime_seconds %d
-`, len(e.deploymentCache), e.config.Workers, int(time.Since(e.startTime).Seconds()))
+`, e.cacheSize(), e.config.Workers, int(time.Since(e.startTime).Seconds()))
 
 	fmt.Fprint(w, metrics)
 }
@@ -445,51 +505,94 @@ func (e *EventProcessor) parseQueryParams(r *http.Request) map[string]string {
 }
 
 func (e *EventProcessor) getAllDeploymentsFromCache() []*appsv1.Deployment {
-	var deployments []*appsv1.Deployment
-	for _, obj := range e.cacheIndexer.List() {
-		if deployment, ok := obj.(*appsv1.Deployment); ok {
-			deployments = append(deployments, deployment)
-		}
-	}
-	return deployments
+	return e.informer.List()
 }
 
 func (e *EventProcessor) getDeploymentFromCache(key string) *appsv1.Deployment {
-	if deployment, exists := e.deploymentCache[key]; exists {
+	if deployment, exists := e.cachedDeployment(key); exists {
 		return deployment
 	}
 
-	obj, exists, _ := e.cacheIndexer.GetByKey(key)
-	if exists {
-		if deployment, ok := obj.(*appsv1.Deployment); ok {
-			return deployment
-		}
+	if deployment, exists := e.informer.Get(key); exists {
+		return deployment
 	}
 	return nil
 }
 
-func (e *EventProcessor) filterDeployments(deployments []*appsv1.Deployment, params map[string]string) []*appsv1.Deployment {
+// filterCandidates narrows the cache to the deployments that can pass
+// filterDeployments with params, reading an index when a filter targets an
+// indexed field. The result may hold extra deployments; filterDeployments
+// still applies every filter.
+func (e *EventProcessor) filterCandidates(params map[string]string) []*appsv1.Deployment {
+	if !e.indexed {
+		return e.getAllDeploymentsFromCache()
+	}
+	if value, ok := labelIndexValue(params["labelSelector"]); ok {
+		return e.deploymentsByIndex(informer.LabelIndex, value)
+	}
+	if ns := params["namespace"]; ns != "" {
+		return e.deploymentsByIndex(informer.NamespaceIndex, ns)
+	}
+	if img := params["image"]; img != "" {
+		// The filter matches substrings, so check each distinct image once
+		// instead of every deployment
+		return e.deploymentsByIndexMatch(informer.ImageIndex, func(image string) bool {
+			return strings.Contains(image, img)
+		})
+	}
+	return e.getAllDeploymentsFromCache()
+}
+
+func (e *EventProcessor) deploymentsByIndex(indexName, value string) []*appsv1.Deployment {
+	deployments, err := e.informer.ByIndex(indexName, value)
+	if err != nil {
+		log.Printf("⚠️ Index lookup %s=%s failed, scanning the cache: %v", indexName, value, err)
+		return e.getAllDeploymentsFromCache()
+	}
+	return deployments
+}
+
+// deploymentsByIndexMatch returns the deployments indexed in indexName under
+// any value accepted by match, each once
+func (e *EventProcessor) deploymentsByIndexMatch(indexName string, match func(value string) bool) []*appsv1.Deployment {
+	var matched []*appsv1.Deployment
+	seen := make(map[*appsv1.Deployment]bool)
+	for _, value := range e.informer.IndexValues(indexName) {
+		if !match(value) {
+			continue
+		}
+		for _, deployment := range e.deploymentsByIndex(indexName, value) {
+			if !seen[deployment] {
+				seen[deployment] = true
+				matched = append(matched, deployment)
+			}
+		}
+	}
+	return matched
+}
+
+// filterDeployments stops early with ctx.Err() once the request context is cancelled
+func (e *EventProcessor) filterDeployments(ctx context.Context, deployments []*appsv1.Deployment, params map[string]string) ([]*appsv1.Deployment, error) {
+	selector, err := parseLabelSelector(params["labelSelector"])
+	if err != nil {
+		return nil, err
+	}
+
 	var filtered []*appsv1.Deployment
 
 	for _, deployment := range deployments {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Namespace filter
 		if ns := params["namespace"]; ns != "" && deployment.Namespace != ns {
 			continue
 		}
 
 		// Status filter
-		if status := params["status"]; status != "" {
-			deploymentStatus := "Unknown"
-			if deployment.Status.ReadyReplicas == deployment.Status.Replicas && deployment.Status.Replicas > 0 {
-				deploymentStatus = "Healthy"
-			} else if deployment.Status.ReadyReplicas == 0 {
-				deploymentStatus = "Unhealthy"
-			} else {
-				deploymentStatus = "Progressing"
-			}
-			if status != deploymentStatus {
-				continue
-			}
+		if status := params["status"]; status != "" && status != deploymentStatus(deployment) {
+			continue
 		}
 
 		// Image filter
@@ -501,19 +604,22 @@ func (e *EventProcessor) filterDeployments(deployments []*appsv1.Deployment, par
 		}
 
 		// Label selector
-		if labelSelector := params["labelSelector"]; labelSelector != "" {
-			if !matchesLabelSelector(deployment.Labels, labelSelector) {
-				continue
-			}
+		if !selector.Matches(labels.Set(deployment.Labels)) {
+			continue
 		}
 
 		filtered = append(filtered, deployment)
 	}
 
-	return filtered
+	return filtered, nil
 }
 
-func (e *EventProcessor) sortDeployments(deployments []*appsv1.Deployment, params map[string]string) []*appsv1.Deployment {
+// sortDeployments checks the request context before and after sorting; sort.Slice itself can't be interrupted
+func (e *EventProcessor) sortDeployments(ctx context.Context, deployments []*appsv1.Deployment, params map[string]string) ([]*appsv1.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	sortBy := params["sortBy"]
 	order := params["order"]
 
@@ -551,7 +657,11 @@ func (e *EventProcessor) sortDeployments(deployments []*appsv1.Deployment, param
 		return less
 	})
 
-	return deployments
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
 }
 
 func (e *EventProcessor) paginateDeployments(deployments []*appsv1.Deployment, params map[string]string) ([]*appsv1.Deployment, *APIMetadata) {
@@ -637,8 +747,12 @@ func (e *EventProcessor) createDeploymentDetail(deployment *appsv1.Deployment) D
 	return detail
 }
 
-func (e *EventProcessor) calculateCacheMetrics() CacheMetrics {
+// calculateCacheMetrics computes the counts and distributions over the
+// deployments in namespace, or over all of them when namespace is empty.
+// CacheStats and PerformanceMetrics always describe the whole cache.
+func (e *EventProcessor) calculateCacheMetrics(namespace string) CacheMetrics {
 	metrics := CacheMetrics{
+		Namespace:             namespace,
 		NamespaceDistribution: make(map[string]int),
 		StatusDistribution:    make(map[string]int),
 		ImageDistribution:     make(map[string]int),
@@ -649,22 +763,20 @@ func (e *EventProcessor) calculateCacheMetrics() CacheMetrics {
 	}
 
 	deployments := e.getAllDeploymentsFromCache()
-	metrics.TotalDeployments = len(deployments)
-
+	if namespace != "" && e.indexed {
+		deployments = e.deploymentsByIndex(informer.NamespaceIndex, namespace)
+	}
 	for _, deployment := range deployments {
+		if namespace != "" && deployment.Namespace != namespace {
+			continue
+		}
+		metrics.TotalDeployments++
+
 		// Namespace distribution
 		metrics.NamespaceDistribution[deployment.Namespace]++
 
 		// Status distribution
-		status := "Unknown"
-		if deployment.Status.ReadyReplicas == deployment.Status.Replicas && deployment.Status.Replicas > 0 {
-			status = "Healthy"
-		} else if deployment.Status.ReadyReplicas == 0 {
-			status = "Unhealthy"
-		} else {
-			status = "Progressing"
-		}
-		metrics.StatusDistribution[status]++
+		metrics.StatusDistribution[deploymentStatus(deployment)]++
 
 		// Image distribution
 		if len(deployment.Spec.Template.Spec.Containers) > 0 {
@@ -684,8 +796,8 @@ func (e *EventProcessor) calculateCacheMetrics() CacheMetrics {
 	}
 
 	// Cache stats
-	metrics.CacheStats["cache_size"] = len(e.deploymentCache)
-	metrics.CacheStats["indexer_size"] = len(e.cacheIndexer.List())
+	metrics.CacheStats["cache_size"] = e.cacheSize()
+	metrics.CacheStats["indexer_size"] = len(e.informer.List())
 	metrics.CacheStats["workers"] = e.config.Workers
 	metrics.CacheStats["resync_period"] = e.config.ResyncPeriod.String()
 
@@ -697,70 +809,222 @@ func (e *EventProcessor) calculateCacheMetrics() CacheMetrics {
 	return metrics
 }
 
-func (e *EventProcessor) searchDeployments(query, namespace, fields string, limit int) []DeploymentSummary {
-	var results []DeploymentSummary
-	deployments := e.getAllDeploymentsFromCache()
+// searchFields are the deployment fields the cache search can match against
+var searchFields = []string{"name", "namespace", "image", "labels"}
+
+// parseSearchFields splits the comma-separated fields parameter. An unknown
+// field is an error rather than a field that silently never matches.
+func parseSearchFields(fields string) ([]string, error) {
+	if fields == "" {
+		return searchFields, nil
+	}
+
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !containsString(searchFields, field) {
+			return nil, fmt.Errorf("unknown search field '%s' (valid fields: %s)", field, strings.Join(searchFields, ", "))
+		}
+		parsed = append(parsed, field)
+	}
+	if len(parsed) == 0 {
+		return searchFields, nil
+	}
+	return parsed, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
 
-	searchFields := []string{"name", "namespace", "image", "labels"}
-	if fields != "" {
-		searchFields = strings.Split(fields, ",")
+// parseNonNegativeParam reads an integer query parameter, returning def when it is absent
+func parseNonNegativeParam(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
 	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return parsed, nil
+}
+
+// SearchResult is a cache search match; Score is only filled in with debug=true
+type SearchResult struct {
+	DeploymentSummary
+	Score int `json:"score,omitempty"`
+}
+
+// Relevance of a search match: how well the query matches a field, scaled by
+// how much that field says about the deployment
+const (
+	matchSubstring = 1
+	matchPrefix    = 2
+	matchExact     = 3
+)
+
+var searchFieldWeights = map[string]int{
+	"name":      4,
+	"image":     3,
+	"namespace": 2,
+	"labels":    1,
+}
 
+// searchDeployments returns the page of matches selected by offset and limit,
+// best matches first, together with the total number of matches
+func (e *EventProcessor) searchDeployments(ctx context.Context, query, namespace string, fields []string, offset, limit int, includeScore bool) ([]SearchResult, int, error) {
 	query = strings.ToLower(query)
-	count := 0
 
-	for _, deployment := range deployments {
-		if count >= limit {
-			break
+	type scoredDeployment struct {
+		deployment *appsv1.Deployment
+		score      int
+	}
+
+	var matches []scoredDeployment
+	for _, deployment := range e.searchCandidates(query, namespace, fields) {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
 		}
 
 		if namespace != "" && deployment.Namespace != namespace {
 			continue
 		}
 
-		match := false
-		for _, field := range searchFields {
-			switch field {
-			case "name":
-				if strings.Contains(strings.ToLower(deployment.Name), query) {
-					match = true
-				}
-			case "namespace":
-				if strings.Contains(strings.ToLower(deployment.Namespace), query) {
-					match = true
-				}
-			case "image":
-				if len(deployment.Spec.Template.Spec.Containers) > 0 {
-					image := strings.ToLower(deployment.Spec.Template.Spec.Containers[0].Image)
-					if strings.Contains(image, query) {
-						match = true
-					}
-				}
-			case "labels":
-				for key, value := range deployment.Labels {
-					if strings.Contains(strings.ToLower(key), query) ||
-						strings.Contains(strings.ToLower(value), query) {
-						match = true
-						break
-					}
-				}
+		if score := searchScore(deployment, query, fields); score > 0 {
+			matches = append(matches, scoredDeployment{deployment: deployment, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].deployment.Namespace != matches[j].deployment.Namespace {
+			return matches[i].deployment.Namespace < matches[j].deployment.Namespace
+		}
+		return matches[i].deployment.Name < matches[j].deployment.Name
+	})
+
+	total := len(matches)
+	if offset >= total {
+		return []SearchResult{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	results := make([]SearchResult, 0, end-offset)
+	for _, match := range matches[offset:end] {
+		result := SearchResult{DeploymentSummary: e.createDeploymentSummary(match.deployment)}
+		if includeScore {
+			result.Score = match.score
+		}
+		results = append(results, result)
+	}
+	return results, total, nil
+}
+
+// searchCandidates narrows the cache for searchDeployments. A namespace is
+// read from the namespace index. Name is the only search field without an
+// index, so when it isn't searched only the deployments whose namespace,
+// image or a label contains query are scored.
+func (e *EventProcessor) searchCandidates(query, namespace string, fields []string) []*appsv1.Deployment {
+	if !e.indexed {
+		return e.getAllDeploymentsFromCache()
+	}
+	if namespace != "" {
+		return e.deploymentsByIndex(informer.NamespaceIndex, namespace)
+	}
+	if containsString(fields, "name") {
+		return e.getAllDeploymentsFromCache()
+	}
+
+	contains := func(value string) bool { return strings.Contains(strings.ToLower(value), query) }
+	var candidates []*appsv1.Deployment
+	seen := make(map[*appsv1.Deployment]bool)
+	add := func(deployments []*appsv1.Deployment) {
+		for _, deployment := range deployments {
+			if !seen[deployment] {
+				seen[deployment] = true
+				candidates = append(candidates, deployment)
 			}
-			if match {
-				break
+		}
+	}
+	for _, field := range fields {
+		switch field {
+		case "namespace":
+			add(e.deploymentsByIndexMatch(informer.NamespaceIndex, contains))
+		case "image":
+			add(e.deploymentsByIndexMatch(informer.ImageIndex, contains))
+		case "labels":
+			add(e.deploymentsByIndexMatch(informer.LabelIndex, func(label string) bool {
+				key, value, _ := strings.Cut(label, "=")
+				return contains(key) || contains(value)
+			}))
+		}
+	}
+	return candidates
+}
+
+// searchScore is the best weighted match of query across fields, 0 if nothing
+// matches. An exact name beats a name prefix, which beats a name substring,
+// and any name match outranks the same kind of match on an image, namespace
+// or label. query is lowercase.
+func searchScore(deployment *appsv1.Deployment, query string, fields []string) int {
+	best := 0
+	for _, field := range fields {
+		var values []string
+		switch field {
+		case "name":
+			values = []string{deployment.Name}
+		case "namespace":
+			values = []string{deployment.Namespace}
+		case "image":
+			if len(deployment.Spec.Template.Spec.Containers) > 0 {
+				values = []string{deployment.Spec.Template.Spec.Containers[0].Image}
+			}
+		case "labels":
+			for key, value := range deployment.Labels {
+				values = append(values, key, value)
 			}
 		}
 
-		if match {
-			summary := e.createDeploymentSummary(deployment)
-			results = append(results, summary)
-			count++
+		for _, value := range values {
+			if score := matchQuality(strings.ToLower(value), query) * searchFieldWeights[field]; score > best {
+				best = score
+			}
 		}
 	}
+	return best
+}
 
-	return results
+func matchQuality(value, query string) int {
+	switch {
+	case value == query:
+		return matchExact
+	case strings.HasPrefix(value, query):
+		return matchPrefix
+	case strings.Contains(value, query):
+		return matchSubstring
+	default:
+		return 0
+	}
 }
 
 func (e *EventProcessor) getCacheKeys() []string {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+
 	var keys []string
 	for key := range e.deploymentCache {
 		keys = append(keys, key)
@@ -769,6 +1033,9 @@ func (e *EventProcessor) getCacheKeys() []string {
 }
 
 func (e *EventProcessor) getCacheSample(limit int) []string {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+
 	var sample []string
 	count := 0
 	for key := range e.deploymentCache {
@@ -781,23 +1048,28 @@ func (e *EventProcessor) getCacheSample(limit int) []string {
 	return sample
 }
 
-func (e *EventProcessor) writeStep8JSONResponse(w http.ResponseWriter, response Step8APIResponse) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+// logCancelledRequest records a request abandoned because its client went away; nothing is written back
+func logCancelledRequest(r *http.Request, err error) {
+	logRequestf(r.Context(), "⚠️ API Request cancelled: %s %s: %v", r.Method, r.URL.Path, err)
+}
+
+func (e *EventProcessor) writeStep8JSONResponse(w http.ResponseWriter, r *http.Request, response Step8APIResponse) {
+	response.RequestID = w.Header().Get(requestIDHeader)
+	if err := writeJSON(w, r, http.StatusOK, response); err != nil {
 		log.Printf("❌ Error encoding Step 8 JSON response: %v", err)
 	}
 }
 
-func (e *EventProcessor) writeStep8ErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// writeStep8ErrorResponse uses the same error codes and request ID as writeErrorResponse
+func (e *EventProcessor) writeStep8ErrorResponse(w http.ResponseWriter, r *http.Request, code, message string, statusCode int) {
 	response := Step8APIResponse{
 		Status:    "error",
 		Error:     message,
+		Code:      code,
+		RequestID: requestIDFromContext(r.Context()),
 		Timestamp: time.Now(),
 	}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	if err := writeJSON(w, r, statusCode, response); err != nil {
 		log.Printf("❌ Error encoding Step 8 error response: %v", err)
 	}
 }
@@ -818,6 +1090,7 @@ Step 8 Features:
 • Prometheus metrics support
 • Enhanced error handling and logging`,
 	Run: func(cmd *cobra.Command, args []string) {
+		informerResyncPeriodSet = cmd.Flags().Changed("resync-period")
 		runStep8APIServer()
 	},
 }
@@ -849,7 +1122,7 @@ func runStep8APIServer() {
 		log.Fatalf("❌ Failed to create Kubernetes client: %v", err)
 	}
 
-	serverVersion, err := clientset.Discovery().ServerVersion()
+	serverVersion, err := k8s.WaitForServer(context.Background(), clientset.Discovery(), connectAttempts, connectBackoff)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to Kubernetes cluster: %v", err)
 	}
@@ -867,6 +1140,7 @@ func runStep8APIServer() {
 
 	// Start Step 8 API server
 	go processor.StartStep8APIServer()
+	go startPprofServer(pprofBindAddress(enablePprof, pprofPort))
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
@@ -919,10 +1193,16 @@ func init() {
 	// Add flags for Step 8
 	step8APICmd.Flags().IntVar(&step8Port, "port", 8090, "Step 8 API server port")
 	step8APICmd.Flags().StringVar(&configFile, "config", "", "Path to configuration file")
-	step8APICmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period")
+	step8APICmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period (default 30s or the config file value; 0 disables resync)")
 	step8APICmd.Flags().IntVar(&informerWorkers, "workers", 0, "Number of worker goroutines")
+	step8APICmd.Flags().IntVar(&connectAttempts, "connect-attempts", 5, "Number of attempts to reach the cluster on startup")
+	step8APICmd.Flags().DurationVar(&connectBackoff, "connect-backoff", time.Second, "Initial wait between connection attempts, doubled after each failure")
+	step8APICmd.Flags().DurationVar(&informerStaleThreshold, "stale-threshold", 0, "Report the watch as degraded after this long without events or resyncs (default 5m or the config file value)")
+	step8APICmd.Flags().StringSliceVar(&wsAllowedOrigins, "ws-allowed-origins", nil, "Browser origins besides the server's own allowed to open /api/v2/ws/deployments (\"*\" allows any)")
 	step8APICmd.Flags().BoolVar(&enableMetrics, "enable-metrics", false, "Enable Prometheus metrics endpoint")
 	step8APICmd.Flags().BoolVar(&enableDebug, "enable-debug", false, "Enable debug endpoints")
+	step8APICmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve net/http/pprof on localhost at --pprof-port")
+	step8APICmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "Port for pprof debug endpoints")
 
 	// Register command
 	RootCmd.AddCommand(step8APICmd)
@@ -0,0 +1,581 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	testEnv   *envtest.Environment
+	k8sClient kubernetes.Interface
+	testCtx   context.Context
+	cancel    context.CancelFunc
+)
+
+func TestStep8API(t *testing.T) {
+	// envtest needs kube-apiserver and etcd binaries; see `make test-envtest`
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set, skipping envtest suite")
+	}
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "k8s-cli Step 8 API Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	testCtx, cancel = context.WithCancel(context.TODO())
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{}
+
+	cfg, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	k8sClient, err = kubernetes.NewForConfig(cfg)
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	cancel()
+	By("tearing down the test environment")
+	Expect(testEnv.Stop()).To(Succeed())
+})
+
+// Step 8: filtering, sorting and pagination over deployments seeded in envtest
+var _ = Describe("Step 8: Advanced cache API handlers", func() {
+	var (
+		processor   *EventProcessor
+		namespaceA  string
+		namespaceB  string
+		deployments []*appsv1.Deployment
+	)
+
+	BeforeEach(func() {
+		processor = NewEventProcessor(k8sClient, &InformerConfig{
+			ResyncPeriod: 30 * time.Second,
+			Workers:      1,
+		})
+
+		namespaceA = createTestNamespace()
+		namespaceB = createTestNamespace()
+
+		// ready/desired replicas drive the derived status: Healthy, Unhealthy or Progressing
+		seedDeployment(namespaceA, "alpha", "nginx:1.25", 2, 2)
+		seedDeployment(namespaceA, "bravo", "nginx:1.25", 3, 3)
+		seedDeployment(namespaceA, "charlie", "redis:7", 1, 0)
+		seedDeployment(namespaceA, "echo", "redis:7", 3, 1)
+		seedDeployment(namespaceB, "delta", "nginx:1.25", 1, 1)
+
+		deployments = nil
+		for _, ns := range []string{namespaceA, namespaceB} {
+			list, err := k8sClient.AppsV1().Deployments(ns).List(testCtx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			for i := range list.Items {
+				deployments = append(deployments, &list.Items[i])
+			}
+		}
+		Expect(deployments).To(HaveLen(5))
+	})
+
+	AfterEach(func() {
+		for _, ns := range []string{namespaceA, namespaceB} {
+			Expect(k8sClient.CoreV1().Namespaces().Delete(testCtx, ns, metav1.DeleteOptions{})).To(Succeed())
+		}
+	})
+
+	Context("When filtering deployments", func() {
+		It("Should combine namespace and status filters", func() {
+			filtered, err := processor.filterDeployments(testCtx, deployments, map[string]string{
+				"namespace": namespaceA,
+				"status":    "Healthy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(filtered)).To(ConsistOf("alpha", "bravo"))
+		})
+
+		It("Should distinguish Unhealthy from Progressing", func() {
+			unhealthy, err := processor.filterDeployments(testCtx, deployments, map[string]string{"status": "Unhealthy"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(unhealthy)).To(ConsistOf("charlie"))
+
+			progressing, err := processor.filterDeployments(testCtx, deployments, map[string]string{"status": "Progressing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(progressing)).To(ConsistOf("echo"))
+		})
+
+		It("Should combine image and namespace filters", func() {
+			filtered, err := processor.filterDeployments(testCtx, deployments, map[string]string{
+				"namespace": namespaceA,
+				"image":     "redis",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(filtered)).To(ConsistOf("charlie", "echo"))
+		})
+
+		It("Should stop when the request context is cancelled", func() {
+			cancelledCtx, cancelRequest := context.WithCancel(testCtx)
+			cancelRequest()
+
+			_, err := processor.filterDeployments(cancelledCtx, deployments, map[string]string{})
+			Expect(err).To(MatchError(context.Canceled))
+		})
+	})
+
+	Context("When sorting deployments", func() {
+		It("Should sort filtered results by name descending", func() {
+			filtered, err := processor.filterDeployments(testCtx, deployments, map[string]string{"namespace": namespaceA})
+			Expect(err).NotTo(HaveOccurred())
+
+			sorted, err := processor.sortDeployments(testCtx, filtered, map[string]string{
+				"sortBy": "name",
+				"order":  "desc",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(sorted)).To(Equal([]string{"echo", "charlie", "bravo", "alpha"}))
+		})
+
+		It("Should default to ascending name order", func() {
+			sorted, err := processor.sortDeployments(testCtx, deployments, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentNames(sorted)).To(Equal([]string{"alpha", "bravo", "charlie", "delta", "echo"}))
+		})
+	})
+
+	Context("When paginating deployments", func() {
+		var sorted []*appsv1.Deployment
+
+		BeforeEach(func() {
+			var err error
+			sorted, err = processor.sortDeployments(testCtx, deployments, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should return a partial last page", func() {
+			page, metadata := processor.paginateDeployments(sorted, map[string]string{
+				"page":     "3",
+				"pageSize": "2",
+			})
+			Expect(deploymentNames(page)).To(Equal([]string{"echo"}))
+			Expect(metadata.Page).To(Equal(3))
+			Expect(metadata.PageSize).To(Equal(2))
+			Expect(metadata.TotalCount).To(Equal(5))
+		})
+
+		It("Should return an empty page past the end", func() {
+			page, metadata := processor.paginateDeployments(sorted, map[string]string{
+				"page":     "10",
+				"pageSize": "2",
+			})
+			Expect(page).To(BeEmpty())
+			Expect(metadata.TotalCount).To(Equal(5))
+		})
+
+		It("Should fall back to defaults for invalid parameters", func() {
+			page, metadata := processor.paginateDeployments(sorted, map[string]string{
+				"page":     "-1",
+				"pageSize": "1000",
+			})
+			Expect(page).To(HaveLen(5))
+			Expect(metadata.Page).To(Equal(1))
+			Expect(metadata.PageSize).To(Equal(20))
+		})
+	})
+})
+
+// Helper functions for Step 8 testing
+func createTestNamespace() string {
+	ns, err := k8sClient.CoreV1().Namespaces().Create(testCtx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "step8-test-"},
+	}, metav1.CreateOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	return ns.Name
+}
+
+// seedDeployment creates a deployment and sets its status, since envtest runs no deployment controller
+func seedDeployment(namespace, name, image string, replicas, readyReplicas int32) {
+	labels := map[string]string{"app": name}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: image}},
+				},
+			},
+		},
+	}
+
+	created, err := k8sClient.AppsV1().Deployments(namespace).Create(testCtx, deployment, metav1.CreateOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	created.Status.Replicas = replicas
+	created.Status.ReadyReplicas = readyReplicas
+	created.Status.AvailableReplicas = readyReplicas
+	_, err = k8sClient.AppsV1().Deployments(namespace).UpdateStatus(testCtx, created, metav1.UpdateOptions{})
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func deploymentNames(deployments []*appsv1.Deployment) []string {
+	names := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return names
+}
+
+func newSearchTestProcessor(t *testing.T, count int) *EventProcessor {
+	t.Helper()
+
+	deployments := make([]*appsv1.Deployment, 0, count)
+	for i := 0; i < count; i++ {
+		deployments = append(deployments, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("web-%02d", i), Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "web", Image: "nginx:1.25"}},
+			}}},
+		})
+	}
+	return newCachedTestProcessor(t, deployments...)
+}
+
+// newCachedTestProcessor returns an EventProcessor whose informer cache holds
+// deployments, synced from a fake clientset
+func newCachedTestProcessor(t *testing.T, deployments ...*appsv1.Deployment) *EventProcessor {
+	t.Helper()
+
+	objects := make([]runtime.Object, 0, len(deployments))
+	for _, deployment := range deployments {
+		objects = append(objects, deployment)
+	}
+	e := NewEventProcessor(fake.NewSimpleClientset(objects...), &InformerConfig{})
+	t.Cleanup(e.informer.Stop)
+	if err := e.informer.Start(context.Background()); err != nil {
+		t.Fatalf("starting informer: %v", err)
+	}
+	return e
+}
+
+func TestCacheSearchPaging(t *testing.T) {
+	e := newSearchTestProcessor(t, 12)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+		wantTotal int
+	}{
+		{name: "first page", query: "q=web&limit=5", wantNames: []string{"web-00", "web-01", "web-02", "web-03", "web-04"}, wantTotal: 12},
+		{name: "last partial page", query: "q=web&offset=10&limit=5", wantNames: []string{"web-10", "web-11"}, wantTotal: 12},
+		{name: "offset past the end", query: "q=web&offset=20", wantNames: []string{}, wantTotal: 12},
+		{name: "narrowed by image", query: "q=redis&fields=image", wantNames: []string{}, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.handleStep8CacheSearchAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v2/cache/search?"+tt.query, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+			}
+
+			var resp struct {
+				Data     []DeploymentSummary `json:"data"`
+				Metadata APIMetadata         `json:"metadata"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+
+			names := []string{}
+			for _, summary := range resp.Data {
+				names = append(names, summary.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+			if resp.Metadata.TotalCount != tt.wantTotal {
+				t.Errorf("total_count = %d, want %d", resp.Metadata.TotalCount, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestCacheSearchRejectsInvalidParameters(t *testing.T) {
+	e := newSearchTestProcessor(t, 1)
+
+	for _, query := range []string{"q=web&fields=name,imgae", "offset=-1", "limit=0", "limit=abc"} {
+		t.Run(query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.handleStep8CacheSearchAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v2/cache/search?"+query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), ErrCodeInvalidParameter) {
+				t.Errorf("body %s does not contain %s", rec.Body.String(), ErrCodeInvalidParameter)
+			}
+		})
+	}
+}
+
+func TestCacheSearchRanking(t *testing.T) {
+	e := newCachedTestProcessor(t,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "my-api-gateway", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default", Labels: map[string]string{"app": "api"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api-server", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default"}},
+	)
+
+	results, total, err := e.searchDeployments(context.TODO(), "api", "", searchFields, 0, 50, true)
+	if err != nil {
+		t.Fatalf("searchDeployments() error = %v", err)
+	}
+
+	var got []string
+	for _, result := range results {
+		got = append(got, fmt.Sprintf("%s:%d", result.Name, result.Score))
+	}
+	want := []string{"api:12", "api-server:8", "my-api-gateway:4", "worker:3"}
+	if !reflect.DeepEqual(got, want) || total != 4 {
+		t.Errorf("results = %v (total %d), want %v (total 4)", got, total, want)
+	}
+
+	results, _, _ = e.searchDeployments(context.TODO(), "api", "", searchFields, 0, 50, false)
+	if results[0].Score != 0 {
+		t.Errorf("Score = %d without debug, want it omitted", results[0].Score)
+	}
+}
+
+func TestCacheMetricsNamespace(t *testing.T) {
+	e := newCachedTestProcessor(t,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-b"}},
+	)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantTotal int
+		wantNS    map[string]int
+	}{
+		{name: "cluster-wide", query: "", wantCode: http.StatusOK, wantTotal: 3, wantNS: map[string]int{"team-a": 2, "team-b": 1}},
+		{name: "one namespace", query: "?namespace=team-a", wantCode: http.StatusOK, wantTotal: 2, wantNS: map[string]int{"team-a": 2}},
+		{name: "empty namespace", query: "?namespace=team-c", wantCode: http.StatusOK, wantTotal: 0, wantNS: map[string]int{}},
+		{name: "invalid namespace", query: "?namespace=Team_A", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.handleStep8CacheMetricsAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v2/cache/metrics"+tt.query, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Data CacheMetrics `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.Data.TotalDeployments != tt.wantTotal {
+				t.Errorf("total_deployments = %d, want %d", resp.Data.TotalDeployments, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(resp.Data.NamespaceDistribution, tt.wantNS) {
+				t.Errorf("namespace_distribution = %v, want %v", resp.Data.NamespaceDistribution, tt.wantNS)
+			}
+		})
+	}
+}
+
+func TestIndexedLookupsMatchFullScan(t *testing.T) {
+	newDeployment := func(namespace, name, image string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main", Image: image}},
+			}}},
+		}
+	}
+	e := newCachedTestProcessor(t,
+		newDeployment("default", "web", "nginx:1.25", map[string]string{"app": "web", "tier": "frontend"}),
+		newDeployment("default", "api", "registry.example.com/api:1.0", map[string]string{"app": "api"}),
+		newDeployment("team-a", "proxy", "nginx:1.24", map[string]string{"app": "proxy", "tier": "edge"}),
+		newDeployment("team-b", "worker", "busybox:latest", nil),
+	)
+	all := e.getAllDeploymentsFromCache()
+
+	filters := []struct {
+		params         map[string]string
+		wantCandidates int
+	}{
+		{params: map[string]string{"labelSelector": "app=web"}, wantCandidates: 1},
+		{params: map[string]string{"labelSelector": "tier=edge", "namespace": "default"}, wantCandidates: 1},
+		{params: map[string]string{"namespace": "default"}, wantCandidates: 2},
+		{params: map[string]string{"image": "nginx"}, wantCandidates: 2},
+		{params: map[string]string{"labelSelector": "app!=web"}, wantCandidates: 4},
+		{params: map[string]string{"labelSelector": "app==web"}, wantCandidates: 1},
+		{params: map[string]string{"labelSelector": "app=web,tier=frontend"}, wantCandidates: 4},
+		{params: map[string]string{"labelSelector": "app in (web,api)"}, wantCandidates: 4},
+		{params: map[string]string{"status": "ready"}, wantCandidates: 4},
+	}
+	for _, tt := range filters {
+		candidates := e.filterCandidates(tt.params)
+		if len(candidates) != tt.wantCandidates {
+			t.Errorf("filterCandidates(%v) returned %d deployments, want %d", tt.params, len(candidates), tt.wantCandidates)
+		}
+		indexed, _ := e.filterDeployments(context.TODO(), candidates, tt.params)
+		scanned, _ := e.filterDeployments(context.TODO(), all, tt.params)
+		if got, want := sortedNames(indexed), sortedNames(scanned); !reflect.DeepEqual(got, want) {
+			t.Errorf("filter %v with index = %v, full scan = %v", tt.params, got, want)
+		}
+	}
+
+	searches := []struct {
+		query     string
+		namespace string
+		fields    []string
+	}{
+		{query: "nginx", fields: []string{"image"}},
+		{query: "front", fields: []string{"labels"}},
+		{query: "team", fields: []string{"namespace", "labels"}},
+		{query: "a", namespace: "default", fields: searchFields},
+		{query: "web", fields: searchFields},
+	}
+	for _, tt := range searches {
+		got, total, err := e.searchDeployments(context.TODO(), tt.query, tt.namespace, tt.fields, 0, 50, true)
+		if err != nil {
+			t.Fatalf("searchDeployments(%q) error = %v", tt.query, err)
+		}
+		var want []string
+		for _, deployment := range all {
+			if tt.namespace != "" && deployment.Namespace != tt.namespace {
+				continue
+			}
+			if searchScore(deployment, tt.query, tt.fields) > 0 {
+				want = append(want, deployment.Name)
+			}
+		}
+		var names []string
+		for _, result := range got {
+			names = append(names, result.Name)
+		}
+		sort.Strings(names)
+		sort.Strings(want)
+		if !reflect.DeepEqual(names, want) || total != len(want) {
+			t.Errorf("search %q in %v = %v (total %d), want %v", tt.query, tt.fields, names, total, want)
+		}
+	}
+}
+
+func TestFilterDeploymentsLabelSelector(t *testing.T) {
+	newDeployment := func(name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+	deployments := []*appsv1.Deployment{
+		newDeployment("web", map[string]string{"app": "web", "tier": "frontend"}),
+		newDeployment("api", map[string]string{"app": "api", "tier": "backend"}),
+		newDeployment("proxy", map[string]string{"app": "proxy"}),
+		newDeployment("worker", nil),
+	}
+	e := NewEventProcessor(nil, &InformerConfig{})
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: "", want: []string{"api", "proxy", "web", "worker"}},
+		{selector: "app=web", want: []string{"web"}},
+		{selector: "app!=web", want: []string{"api", "proxy", "worker"}},
+		{selector: "app=api,tier=backend", want: []string{"api"}},
+		{selector: "app=api,tier=frontend", want: []string{}},
+		{selector: "app in (web,proxy)", want: []string{"proxy", "web"}},
+		{selector: "app notin (web,proxy)", want: []string{"api", "worker"}},
+		{selector: "tier", want: []string{"api", "web"}},
+		{selector: "!tier", want: []string{"proxy", "worker"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			filtered, err := e.filterDeployments(context.TODO(), deployments, map[string]string{"labelSelector": tt.selector})
+			if err != nil {
+				t.Fatalf("filterDeployments() error = %v", err)
+			}
+			if got := sortedNames(filtered); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labelSelector %q selected %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := e.filterDeployments(context.TODO(), deployments, map[string]string{"labelSelector": "app in web"}); err == nil {
+		t.Error("filterDeployments() accepted an invalid selector")
+	}
+}
+
+func TestLabelIndexValue(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+		wantOK   bool
+	}{
+		{selector: "app=web", want: "app=web", wantOK: true},
+		{selector: "app == web", want: "app=web", wantOK: true},
+		{selector: ""},
+		{selector: "app="},
+		{selector: "app!=web"},
+		{selector: "app=web,tier=frontend"},
+		{selector: "app in (web)"},
+		{selector: "app"},
+		{selector: "app in web"},
+	}
+	for _, tt := range tests {
+		got, ok := labelIndexValue(tt.selector)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("labelIndexValue(%q) = (%q, %v), want (%q, %v)", tt.selector, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func sortedNames(deployments []*appsv1.Deployment) []string {
+	names := deploymentNames(deployments)
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"k8s-cli/internal/k8s"
+)
+
+var (
+	// Client shared by the imperative commands, built on first use
+	sharedClientOnce sync.Once
+	sharedClient     *k8s.Client
+	sharedClientErr  error
+)
+
+// getClient returns the process-wide k8s.Client for --kubeconfig. Commands run
+// by the long-running servers or in a batch reuse its REST config and
+// connection pool instead of rebuilding them on every call. The context
+// commands and tests that need isolation keep using k8s.NewClient.
+func getClient() (*k8s.Client, error) {
+	sharedClientOnce.Do(func() {
+		sharedClient, sharedClientErr = k8s.NewClientAs(viper.GetString("kubeconfig"), impersonation())
+	})
+	return sharedClient, sharedClientErr
+}
+
+// impersonation is the user and groups from --as and --as-group
+func impersonation() k8s.Impersonation {
+	return k8s.Impersonation{UserName: impersonateUser, Groups: impersonateGroups}
+}
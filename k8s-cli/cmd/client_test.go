@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGetClientIsShared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(multiClusterTestKubeconfig), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	origKubeconfig := viper.GetString("kubeconfig")
+	viper.Set("kubeconfig", path)
+	sharedClientOnce, sharedClient, sharedClientErr = sync.Once{}, nil, nil
+	defer func() {
+		viper.Set("kubeconfig", origKubeconfig)
+		sharedClientOnce, sharedClient, sharedClientErr = sync.Once{}, nil, nil
+	}()
+
+	first, err := getClient()
+	if err != nil {
+		t.Fatalf("getClient() error = %v", err)
+	}
+	second, err := getClient()
+	if err != nil {
+		t.Fatalf("getClient() error = %v", err)
+	}
+	if first != second {
+		t.Error("getClient() built a second client, want the shared one")
+	}
+}
+
+func TestGetRESTConfigImpersonation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(multiClusterTestKubeconfig), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	origKubeconfig, origUser, origGroups := kubeconfig, impersonateUser, impersonateGroups
+	defer func() {
+		kubeconfig, impersonateUser, impersonateGroups = origKubeconfig, origUser, origGroups
+	}()
+	kubeconfig = path
+	impersonateUser = "system:serviceaccount:team-a:deployer"
+	impersonateGroups = []string{"system:serviceaccounts"}
+
+	config, err := getRESTConfig(false)
+	if err != nil {
+		t.Fatalf("getRESTConfig() error = %v", err)
+	}
+	if config.Impersonate.UserName != impersonateUser {
+		t.Errorf("Impersonate.UserName = %q, want %q", config.Impersonate.UserName, impersonateUser)
+	}
+	if len(config.Impersonate.Groups) != 1 || config.Impersonate.Groups[0] != "system:serviceaccounts" {
+		t.Errorf("Impersonate.Groups = %v, want [system:serviceaccounts]", config.Impersonate.Groups)
+	}
+
+	impersonateUser = ""
+	if _, err := getRESTConfig(false); err == nil {
+		t.Error("getRESTConfig() expected error for --as-group without --as")
+	}
+}
+
+func TestResolveNamespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	withNamespace := strings.Replace(multiClusterTestKubeconfig, "    cluster: staging\n", "    cluster: staging\n    namespace: team-a\n", 1)
+	if err := os.WriteFile(path, []byte(withNamespace), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+	plainPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(plainPath, []byte(multiClusterTestKubeconfig), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	origKubeconfig, origNamespace := viper.GetString("kubeconfig"), viper.GetString("namespace")
+	defer func() {
+		viper.Set("kubeconfig", origKubeconfig)
+		viper.Set("namespace", origNamespace)
+	}()
+
+	tests := []struct {
+		name       string
+		flag       string
+		kubeconfig string
+		want       string
+	}{
+		{name: "flag wins over context", flag: "web", kubeconfig: path, want: "web"},
+		{name: "context namespace without flag", kubeconfig: path, want: "team-a"},
+		{name: "default without either", kubeconfig: plainPath, want: "default"},
+		{name: "default when kubeconfig is missing", kubeconfig: filepath.Join(t.TempDir(), "missing"), want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("namespace", tt.flag)
+			viper.Set("kubeconfig", tt.kubeconfig)
+			if got := resolveNamespace(); got != tt.want {
+				t.Errorf("resolveNamespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-cli/internal/utils"
+)
+
+var clusterInfoCmd = &cobra.Command{
+	Use:   "cluster-info",
+	Short: "Summarize cluster state",
+	Long: `Print a quick health snapshot of the cluster: server version, node count
+and readiness, namespace count and total deployment/pod counts across all namespaces.`,
+	Example: `  # Table output
+  k8s-cli cluster-info
+
+  # JSON output
+  k8s-cli cluster-info -o json`,
+	RunE: runClusterInfo,
+}
+
+func runClusterInfo(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	info, err := collectClusterInfo(context.TODO(), client.GetClientset())
+	if err != nil {
+		return err
+	}
+
+	return utils.PrintClusterInfo(info, viper.GetString("output"))
+}
+
+// collectClusterInfo gathers the summary using only discovery and list calls
+func collectClusterInfo(ctx context.Context, clientset kubernetes.Interface) (utils.ClusterInfo, error) {
+	var info utils.ClusterInfo
+
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return info, fmt.Errorf("unable to connect to cluster: %w", err)
+	}
+	info.ServerVersion = serverVersion.GitVersion
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return info, fmt.Errorf("error listing nodes: %w", err)
+	}
+	info.Nodes = len(nodes.Items)
+	for _, node := range nodes.Items {
+		if isNodeReady(node) {
+			info.ReadyNodes++
+		}
+	}
+
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return info, fmt.Errorf("error listing namespaces: %w", err)
+	}
+	info.Namespaces = len(namespaces.Items)
+
+	deployments, err := clientset.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return info, fmt.Errorf("error listing deployments: %w", err)
+	}
+	info.Deployments = len(deployments.Items)
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return info, fmt.Errorf("error listing pods: %w", err)
+	}
+	info.Pods = len(pods.Items)
+
+	return info, nil
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func init() {
+	RootCmd.AddCommand(clusterInfoCmd)
+}
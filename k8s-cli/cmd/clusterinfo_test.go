@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	versionapi "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func TestCollectClusterInfo(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		testNode("node-1", corev1.ConditionTrue),
+		testNode("node-2", corev1.ConditionFalse),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "apps"}},
+	)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &versionapi.Info{GitVersion: "v1.29.0"}
+
+	info, err := collectClusterInfo(context.Background(), clientset)
+	if err != nil {
+		t.Fatalf("collectClusterInfo() error = %v", err)
+	}
+
+	if info.ServerVersion != "v1.29.0" {
+		t.Errorf("ServerVersion = %q, want v1.29.0", info.ServerVersion)
+	}
+	if info.Nodes != 3 || info.ReadyNodes != 1 {
+		t.Errorf("nodes = %d/%d ready, want 1/3", info.ReadyNodes, info.Nodes)
+	}
+	if info.Namespaces != 2 {
+		t.Errorf("Namespaces = %d, want 2", info.Namespaces)
+	}
+	if info.Deployments != 2 {
+		t.Errorf("Deployments = %d, want 2", info.Deployments)
+	}
+	if info.Pods != 3 {
+		t.Errorf("Pods = %d, want 3", info.Pods)
+	}
+}
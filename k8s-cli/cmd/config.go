@@ -225,9 +225,12 @@ func validateConfig() {
 	fmt.Println("══════════════════════════════════════")
 
 	// Validate resync period
-	if config.ResyncPeriod < 1 {
-		fmt.Println("❌ resync_period must be positive")
-	} else {
+	switch {
+	case config.ResyncPeriod == 0:
+		fmt.Println("✅ resync_period: 0 (periodic resync disabled)")
+	case config.ResyncPeriod < minResyncPeriod:
+		fmt.Printf("⚠️ resync_period %v is below %v and may overload the API server\n", config.ResyncPeriod, minResyncPeriod)
+	default:
 		fmt.Printf("✅ resync_period: %v\n", config.ResyncPeriod)
 	}
 
@@ -323,7 +326,7 @@ func loadInformerConfigEnhanced() (*InformerConfig, error) {
 	}
 
 	// Override with command line flags
-	if informerResyncPeriod > 0 {
+	if informerResyncPeriodSet {
 		config.ResyncPeriod = informerResyncPeriod
 	}
 	if informerWorkers > 0 {
@@ -105,7 +105,7 @@ func runContextSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
-	err = client.SetContext(contextName, kubeconfigPath)
+	err = client.SetContext(contextName)
 	if err != nil {
 		return fmt.Errorf("error switching context: %w", err)
 	}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,13 +12,19 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"k8s-cli/internal/k8s"
 )
 
 var (
@@ -25,6 +33,13 @@ var (
 	controllerWorkers    int
 	controllerSyncPeriod time.Duration
 	enableControllerLogs bool
+
+	// Optional Discord alerting for deployments that stay unhealthy
+	controllerDiscordWebhook     string
+	controllerUnhealthyThreshold time.Duration
+
+	// Label selector limiting which deployments are reconciled
+	controllerWatchLabels string
 )
 
 // Step 9: DeploymentController using sigs.k8s.io/controller-runtime
@@ -32,21 +47,28 @@ type DeploymentController struct {
 	client.Client
 	Scheme    *runtime.Scheme
 	clientset kubernetes.Interface
+	// notifier is nil unless a Discord webhook is configured
+	notifier *DeploymentHealthNotifier
 }
 
 // Step 9: Reconcile implements the reconcile.Reconciler interface
 func (r *DeploymentController) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
-	log.Printf("🔄 Step 9: Reconciling deployment %s/%s", req.Namespace, req.Name)
+	// The logger from ctx already carries the request's namespace, name and reconcileID
+	logger := logf.FromContext(ctx)
+	logger.Info("Reconciling deployment")
 
 	// Fetch the Deployment instance
 	var deployment appsv1.Deployment
 	if err := r.Get(ctx, req.NamespacedName, &deployment); err != nil {
 		if client.IgnoreNotFound(err) != nil {
-			log.Printf("❌ Error fetching deployment: %v", err)
+			logger.Error(err, "Failed to fetch deployment")
 			return reconcile.Result{}, err
 		}
 		// Deployment was deleted
-		log.Printf("🗑️ Step 9: Deployment %s/%s was deleted", req.Namespace, req.Name)
+		logger.Info("Deployment was deleted")
+		if r.notifier != nil {
+			r.notifier.Forget(req.NamespacedName)
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -56,49 +78,87 @@ func (r *DeploymentController) Reconcile(ctx context.Context, req reconcile.Requ
 		replicas = *deployment.Spec.Replicas
 	}
 
-	log.Printf("📊 Step 9: Deployment Details:")
-	log.Printf("   Name: %s", deployment.Name)
-	log.Printf("   Namespace: %s", deployment.Namespace)
-	log.Printf("   Desired Replicas: %d", replicas)
-	log.Printf("   Ready Replicas: %d", deployment.Status.ReadyReplicas)
-	log.Printf("   Available Replicas: %d", deployment.Status.AvailableReplicas)
-	log.Printf("   Updated Replicas: %d", deployment.Status.UpdatedReplicas)
-
+	logger = logger.WithValues("phase", deploymentStatus(&deployment))
+	details := []interface{}{
+		"desiredReplicas", replicas,
+		"readyReplicas", deployment.Status.ReadyReplicas,
+		"availableReplicas", deployment.Status.AvailableReplicas,
+		"updatedReplicas", deployment.Status.UpdatedReplicas,
+	}
 	// Log container information
 	if len(deployment.Spec.Template.Spec.Containers) > 0 {
 		container := deployment.Spec.Template.Spec.Containers[0]
-		log.Printf("   Main Container: %s", container.Name)
-		log.Printf("   Image: %s", container.Image)
+		details = append(details, "container", container.Name, "image", container.Image)
 	}
+	logger.V(1).Info("Deployment details", details...)
 
 	// Check deployment health
-	if deployment.Status.ReadyReplicas != replicas {
-		log.Printf("⚠️ Step 9: Deployment %s/%s is not fully ready (%d/%d replicas)",
-			deployment.Namespace, deployment.Name, deployment.Status.ReadyReplicas, replicas)
+	healthy := deployment.Status.ReadyReplicas == replicas
+	var untilAlert time.Duration
+	if r.notifier != nil {
+		untilAlert = r.notifier.Observe(req.NamespacedName, healthy, deployment.Status.ReadyReplicas, replicas)
+	}
+
+	if !healthy {
+		logger.Info("Deployment is not fully ready", "readyReplicas", deployment.Status.ReadyReplicas, "desiredReplicas", replicas)
 
-		// Requeue for retry
-		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		// Requeue for retry, early enough to alert right when the threshold passes
+		requeueAfter := 30 * time.Second
+		if untilAlert > 0 && untilAlert < requeueAfter {
+			requeueAfter = untilAlert
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	} else if replicas > 0 {
-		log.Printf("✅ Step 9: Deployment %s/%s is healthy (%d/%d replicas)",
-			deployment.Namespace, deployment.Name, deployment.Status.ReadyReplicas, replicas)
+		logger.Info("Deployment is healthy", "readyReplicas", deployment.Status.ReadyReplicas, "desiredReplicas", replicas)
 	}
 
 	// Log events for Step 9 requirement
-	log.Printf("🎯 Step 9: Event processed successfully for deployment %s/%s", req.Namespace, req.Name)
+	logger.Info("Event processed successfully")
 
 	return reconcile.Result{}, nil
 }
 
 // Step 9: SetupWithManager sets up the controller with the Manager
 func (r *DeploymentController) SetupWithManager(mgr ctrl.Manager) error {
+	var opts []builder.ForOption
+	if controllerWatchLabels != "" {
+		selector, err := labels.Parse(controllerWatchLabels)
+		if err != nil {
+			return usageErrorf("invalid --watch-labels selector: %w", err)
+		}
+		opts = append(opts, builder.WithPredicates(labelSelectorPredicate(selector)))
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&appsv1.Deployment{}).
+		For(&appsv1.Deployment{}, opts...).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: controllerWorkers,
 		}).
 		Complete(r)
 }
 
+// labelSelectorPredicate drops events for objects whose labels don't match selector
+func labelSelectorPredicate(selector labels.Selector) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return selector.Matches(labels.Set(obj.GetLabels()))
+	})
+}
+
+// newControllerScheme registers the client-go types, like the Step 11 scheme in crd.go,
+// and fails if the Deployment kind the controller watches is missing
+func newControllerScheme() (*runtime.Scheme, error) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		return nil, err
+	}
+
+	deploymentKind := appsv1.SchemeGroupVersion.WithKind("Deployment")
+	if !s.Recognizes(deploymentKind) {
+		return nil, fmt.Errorf("scheme does not recognize %s", deploymentKind)
+	}
+	return s, nil
+}
+
 // Step 9: Controller command
 var controllerCmd = &cobra.Command{
 	Use:   "controller",
@@ -121,27 +181,34 @@ func runController() {
 	log.Println("🎯 Starting Step 9: sigs.k8s.io/controller-runtime deployment controller...")
 
 	// Setup logging
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	setupLogger()
+
+	// Core types are needed besides apps/v1 for owner references and events
+	controllerScheme, err := newControllerScheme()
+	if err != nil {
+		log.Fatalf("❌ Failed to build scheme: %v", err)
+	}
 
 	// Create manager
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: runtime.NewScheme(),
+		Scheme: controllerScheme,
 	})
 	if err != nil {
 		log.Fatalf("❌ Failed to create manager: %v", err)
 	}
 
-	// Add schemes
-	if err := appsv1.AddToScheme(mgr.GetScheme()); err != nil {
-		log.Fatalf("❌ Failed to add appsv1 scheme: %v", err)
-	}
-
 	// Create clientset for additional operations
 	clientset, err := GetKubernetesClient()
 	if err != nil {
 		log.Fatalf("❌ Failed to create clientset: %v", err)
 	}
 
+	serverVersion, err := k8s.WaitForServer(context.Background(), clientset.Discovery(), connectAttempts, connectBackoff)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to Kubernetes cluster: %v", err)
+	}
+	log.Printf("✅ Successfully connected to Kubernetes cluster (version: %s)", serverVersion.String())
+
 	// Setup controller
 	controller := &DeploymentController{
 		Client:    mgr.GetClient(),
@@ -149,6 +216,13 @@ func runController() {
 		clientset: clientset,
 	}
 
+	if controllerDiscordWebhook != "" {
+		controller.notifier = NewDeploymentHealthNotifier(&DiscordClient{
+			WebhookURL: controllerDiscordWebhook,
+			HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		}, controllerUnhealthyThreshold)
+	}
+
 	if err := controller.SetupWithManager(mgr); err != nil {
 		log.Fatalf("❌ Failed to setup controller: %v", err)
 	}
@@ -158,6 +232,8 @@ func runController() {
 	log.Printf("   Workers: %d", controllerWorkers)
 	log.Printf("   Sync Period: %v", controllerSyncPeriod)
 	log.Printf("   Enable Logs: %t", enableControllerLogs)
+	log.Printf("   Watch Labels: %s", controllerWatchLabels)
+	log.Printf("   Discord Alerts: %t (threshold %v)", controller.notifier != nil, controllerUnhealthyThreshold)
 
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -203,6 +279,13 @@ func init() {
 	controllerCmd.Flags().DurationVar(&controllerSyncPeriod, "sync-period", 10*time.Minute, "Controller sync period")
 	controllerCmd.Flags().BoolVar(&enableControllerLogs, "enable-logs", true, "Enable detailed controller logs")
 	controllerCmd.Flags().StringVar(&configFile, "config", "", "Path to configuration file")
+	controllerCmd.Flags().IntVar(&connectAttempts, "connect-attempts", 5, "Number of attempts to reach the cluster on startup")
+	controllerCmd.Flags().StringVar(&controllerWatchLabels, "watch-labels", "", "Only reconcile deployments matching this label selector (e.g. managed-by=k8s-cli)")
+	controllerCmd.Flags().StringVar(&controllerDiscordWebhook, "discord-webhook", "", "Discord webhook URL for unhealthy deployment alerts")
+	controllerCmd.Flags().DurationVar(&controllerUnhealthyThreshold, "unhealthy-threshold", 5*time.Minute, "How long a deployment must stay unhealthy before alerting")
+	controllerCmd.Flags().DurationVar(&connectBackoff, "connect-backoff", time.Second, "Initial wait between connection attempts, doubled after each failure")
+	controllerCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, error or a verbosity number")
+	controllerCmd.Flags().StringVar(&logFormat, "log-format", "console", "Log format: console or json")
 
 	// Register command
 	RootCmd.AddCommand(controllerCmd)
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestLabelSelectorPredicate(t *testing.T) {
+	selector, err := labels.Parse("managed-by=k8s-cli")
+	if err != nil {
+		t.Fatalf("parsing selector: %v", err)
+	}
+	p := labelSelectorPredicate(selector)
+
+	managed := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:   "web",
+		Labels: map[string]string{"managed-by": "k8s-cli", "app": "web"},
+	}}
+	other := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:   "db",
+		Labels: map[string]string{"managed-by": "helm"},
+	}}
+	unlabeled := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}}
+
+	if !p.Create(event.CreateEvent{Object: managed}) {
+		t.Error("expected create of managed deployment to pass")
+	}
+	if !p.Update(event.UpdateEvent{ObjectOld: managed, ObjectNew: managed}) {
+		t.Error("expected update of managed deployment to pass")
+	}
+	if p.Create(event.CreateEvent{Object: other}) {
+		t.Error("expected create of deployment with other label value to be filtered")
+	}
+	if p.Delete(event.DeleteEvent{Object: unlabeled}) {
+		t.Error("expected delete of unlabeled deployment to be filtered")
+	}
+}
+
+func TestNewControllerScheme(t *testing.T) {
+	s, err := newControllerScheme()
+	if err != nil {
+		t.Fatalf("newControllerScheme() error = %v", err)
+	}
+
+	for _, gvk := range []schema.GroupVersionKind{
+		appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+		corev1.SchemeGroupVersion.WithKind("Pod"),
+		corev1.SchemeGroupVersion.WithKind("Event"),
+	} {
+		if !s.Recognizes(gvk) {
+			t.Errorf("scheme does not recognize %s", gvk)
+		}
+	}
+}
@@ -12,112 +12,49 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	k8scliv1 "k8s-cli/api/v1"
+	k8scliv2 "k8s-cli/api/v2"
 	"k8s-cli/controllers"
 )
 
 var (
 	scheme = runtime.NewScheme()
 	// Step 11 flags
-	crdMetricsPort          int
-	crdHealthPort           int
-	enableCRDLeaderElection bool
-	crdLeaderElectionID     string
+	crdMetricsPort             int
+	crdHealthPort              int
+	enableCRDLeaderElection    bool
+	crdLeaderElectionID        string
+	crdLeaderElectionNamespace string
+	enableCRDWebhooks          bool
+	crdWebhookPort             int
+	crdWebhookCertDir          string
+	crdDefaultRegistry         string
+	crdMetricsSecure           bool
+	crdMetricsCertDir          string
+
+	// FrontendPage workers, and the overall pace of their requeues after errors
+	crdMaxConcurrentReconciles int
+	crdReconcileQPS            float64
+	crdReconcileBurst          int
+
+	// Install the bundled CRD when missing, and how often to look for it meanwhile
+	crdInstallCRD    bool
+	crdCheckInterval time.Duration
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(k8scliv1.AddToScheme(scheme))
-}
-
-// Step 11++: Multi-cluster client configuration
-type MultiClusterConfig struct {
-	Clusters map[string]ClusterConfig `yaml:"clusters"`
-}
-
-type ClusterConfig struct {
-	Name       string `yaml:"name"`
-	Kubeconfig string `yaml:"kubeconfig"`
-	Context    string `yaml:"context"`
-	Namespace  string `yaml:"namespace"`
-	Enabled    bool   `yaml:"enabled"`
-}
-
-type MultiClusterManager struct {
-	configs  map[string]ClusterConfig
-	managers map[string]ctrl.Manager
-}
-
-func NewMultiClusterManager() *MultiClusterManager {
-	return &MultiClusterManager{
-		configs:  make(map[string]ClusterConfig),
-		managers: make(map[string]ctrl.Manager),
-	}
-}
-
-func (mcm *MultiClusterManager) AddCluster(name string, config ClusterConfig) error {
-	log.Printf("🌐 Step 11++: Adding cluster '%s' to multi-cluster manager", name)
-
-	// Create manager for this cluster
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
-
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: server.Options{
-			BindAddress: "0", // Disable metrics for individual clusters
-		},
-		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				config.Namespace: {},
-			},
-		},
-		HealthProbeBindAddress: "0",   // Disable health for individual clusters
-		LeaderElection:         false, // No leader election per cluster
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create manager for cluster %s: %v", name, err)
-	}
-
-	// Setup FrontendPage controller for this cluster
-	if err = (&controllers.FrontendPageReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		return fmt.Errorf("failed to setup FrontendPageReconciler for cluster %s: %v", name, err)
-	}
-
-	mcm.configs[name] = config
-	mcm.managers[name] = mgr
-
-	log.Printf("✅ Step 11++: Successfully configured cluster '%s'", name)
-	return nil
-}
-
-func (mcm *MultiClusterManager) StartAll(ctx context.Context) error {
-	log.Printf("🚀 Step 11++: Starting multi-cluster managers for %d clusters", len(mcm.managers))
-
-	for name, mgr := range mcm.managers {
-		if !mcm.configs[name].Enabled {
-			log.Printf("⏭️ Step 11++: Skipping disabled cluster '%s'", name)
-			continue
-		}
-
-		go func(clusterName string, manager ctrl.Manager) {
-			log.Printf("🏃 Step 11++: Starting manager for cluster '%s'", clusterName)
-			if err := manager.Start(ctx); err != nil {
-				log.Printf("❌ Step 11++: Manager for cluster '%s' failed: %v", clusterName, err)
-			}
-		}(name, mgr)
-	}
-
-	return nil
+	utilruntime.Must(k8scliv2.AddToScheme(scheme))
 }
 
 // Step 11: CRD command
@@ -133,6 +70,8 @@ Step 11 Features:
 • Creates Deployment and Service for each FrontendPage
 • Status updates and condition management
 • Owner references and garbage collection
+• Conversion webhook between FrontendPage v1 (hub) and v2 (--enable-webhooks);
+  the installed CRD serves v2 only after config/crd/patches/webhook_in_frontendpages.yaml
 
 Step 11++ Features:
 • Multi-cluster client configuration for management clusters
@@ -144,40 +83,127 @@ FrontendPage Resource:
 • Manages frontend applications as code
 • Automatically creates Deployment and Service
 • Configurable replicas, image, and environment
-• Status tracking and health monitoring`,
+• Status tracking and health monitoring
+
+Leader Election (--enable-leader-election):
+• The lock is a coordination.k8s.io Lease named after --leader-election-id
+• The Lease lives in --leader-election-namespace; when empty it defaults to the
+  pod's namespace in-cluster and must be set when running out of cluster
+• The controller's service account needs get, list, watch, create, update,
+  patch and delete on leases (apiGroup coordination.k8s.io) plus create and
+  patch on events in that namespace, via a Role and RoleBinding there
+
+Missing CRD:
+• At startup the controller checks that the API server serves FrontendPages
+• If not, it logs how to install the CRD and checks again every
+  --crd-check-interval instead of exiting, so it doesn't crash-loop;
+  /healthz answers ok and /readyz 503 meanwhile
+• --install-crd installs the bundled CRD instead; the service account then
+  needs create on customresourcedefinitions (apiGroup apiextensions.k8s.io)
+
+Concurrency (--max-concurrent-reconciles):
+• Workers reconcile different FrontendPages in parallel; one page is never
+  reconciled by two workers at once, so workers don't conflict with each other
+• Status updates can still conflict with other writers such as the platform
+  API; they are retried, which costs extra API calls
+• Every worker shares the client's QPS budget, so raise it gradually
+• --reconcile-qps and --reconcile-burst cap requeues after errors across all
+  pages, so a mass failure is retried at a steady rate instead of all at once`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runCRDController()
 	},
 }
 
+// waitForCRD blocks until the FrontendPage CRD is served, keeping the health
+// port live meanwhile. It returns false when interrupted before that.
+func waitForCRD(config *rest.Config) bool {
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		log.Fatalf("❌ Failed to create discovery client: %v", err)
+	}
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("❌ Failed to create dynamic client: %v", err)
+	}
+
+	// Up before the first check, which can itself hang on an unreachable API server
+	stopProbes := serveWaitingProbes(crdHealthPort)
+	defer stopProbes()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := waitForFrontendPageCRD(ctx, dc, dyn, crdInstallCRD, crdCheckInterval); err != nil {
+		if ctx.Err() != nil {
+			log.Println("🛑 Stopped while waiting for the FrontendPage CRD")
+			return false
+		}
+		log.Fatalf("❌ %v", err)
+	}
+	return true
+}
+
 func runCRDController() {
 	log.Println("🎯 Starting Step 11: Custom FrontendPage CRD Controller...")
 
 	// Setup logging
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	setupLogger()
+
+	tracer, shutdownTracing, err := setupTracing(context.Background(), otelEndpoint, "k8s-cli-crd-controller")
+	if err != nil {
+		log.Fatalf("❌ Failed to setup tracing: %v", err)
+	}
+
+	config := ctrl.GetConfigOrDie()
+	if !waitForCRD(config) {
+		return
+	}
 
 	// Create manager
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: server.Options{
-			BindAddress: fmt.Sprintf(":%d", crdMetricsPort),
-		},
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsServerOptions(crdMetricsPort, crdMetricsSecure, crdMetricsCertDir),
 		HealthProbeBindAddress: fmt.Sprintf(":%d", crdHealthPort),
+		PprofBindAddress:       pprofBindAddress(enablePprof, pprofPort),
 		LeaderElection:         enableCRDLeaderElection,
 		LeaderElectionID:       crdLeaderElectionID,
+		// The Lease is named exactly after LeaderElectionID
+		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
+		LeaderElectionNamespace:    crdLeaderElectionNamespace,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    crdWebhookPort,
+			CertDir: crdWebhookCertDir,
+		}),
 	})
 	if err != nil {
 		log.Fatalf("❌ Failed to create manager: %v", err)
 	}
 
+	if crdMaxConcurrentReconciles < 1 || crdReconcileQPS <= 0 || crdReconcileBurst < 1 {
+		log.Fatalf("❌ --max-concurrent-reconciles, --reconcile-qps and --reconcile-burst must be positive")
+	}
+
 	// Setup FrontendPage controller
 	if err = (&controllers.FrontendPageReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		APIReader:               mgr.GetAPIReader(),
+		Scheme:                  mgr.GetScheme(),
+		MaxConcurrentReconciles: crdMaxConcurrentReconciles,
+		RateLimiter:             controllers.NewFrontendPageRateLimiter(crdReconcileQPS, crdReconcileBurst),
+		Tracer:                  tracer,
 	}).SetupWithManager(mgr); err != nil {
 		log.Fatalf("❌ Failed to setup FrontendPageReconciler: %v", err)
 	}
 
+	// Setup FrontendPage conversion (v1 hub <-> v2) and defaulting webhooks
+	if crdDefaultRegistry != "" && !enableCRDWebhooks {
+		log.Fatalf("❌ --default-registry requires --enable-webhooks")
+	}
+	if enableCRDWebhooks {
+		if err = (&k8scliv1.FrontendPage{}).SetupWebhookWithManager(mgr, crdDefaultRegistry); err != nil {
+			log.Fatalf("❌ Failed to setup FrontendPage webhook: %v", err)
+		}
+	}
+
 	// Setup Deployment controller for additional monitoring
 	if err = (&DeploymentController{
 		Client: mgr.GetClient(),
@@ -218,16 +244,39 @@ func runCRDController() {
 	log.Println("   ✅ Automatic Deployment and Service creation")
 	log.Println("   ✅ Status updates and condition management")
 	log.Println("   ✅ Owner references and garbage collection")
+	log.Printf("   ✅ %d FrontendPage workers, requeues limited to %g/s (burst %d)", crdMaxConcurrentReconciles, crdReconcileQPS, crdReconcileBurst)
+	if otelEndpoint != "" {
+		log.Printf("   ✅ Reconcile traces exported to %s", otelEndpoint)
+	}
 	if enableCRDLeaderElection {
 		log.Printf("   ✅ Leader election enabled with ID: %s", crdLeaderElectionID)
+		if crdLeaderElectionNamespace != "" {
+			log.Printf("   ✅ Leader election lease: %s/%s", crdLeaderElectionNamespace, crdLeaderElectionID)
+		}
 	} else {
 		log.Println("   ⚠️ Leader election disabled")
 	}
+	if enableCRDWebhooks {
+		log.Println("   ✅ Conversion webhook for FrontendPage v1 <-> v2")
+		if crdDefaultRegistry != "" {
+			log.Printf("   ✅ Defaulting webhook prefixing images with %s", crdDefaultRegistry)
+		}
+		log.Println("   ⚠️ The API server calls the webhooks only once config/certmanager and config/webhook are applied")
+	} else {
+		log.Println("   ⚠️ Webhooks disabled")
+	}
 	log.Println("")
 	log.Println("🔗 Endpoints:")
-	log.Printf("   📊 Metrics: http://localhost:%d/metrics", crdMetricsPort)
+	log.Printf("   📊 Metrics: %s://localhost:%d/metrics", metricsScheme(crdMetricsSecure), crdMetricsPort)
 	log.Printf("   ❤️ Health: http://localhost:%d/healthz", crdHealthPort)
 	log.Printf("   ✅ Ready: http://localhost:%d/readyz", crdHealthPort)
+	if enablePprof {
+		log.Printf("   🔬 pprof: http://%s/debug/pprof/", pprofBindAddress(enablePprof, pprofPort))
+	}
+	if enableCRDWebhooks {
+		log.Printf("   🔁 Conversion: https://localhost:%d/convert", crdWebhookPort)
+		log.Printf("   ✏️ Defaulting: https://localhost:%d/mutate-k8scli-dev-v1-frontendpage", crdWebhookPort)
+	}
 	log.Println("")
 	log.Println("🧪 Test the CRD controller:")
 	log.Println("   # First, apply the CRD:")
@@ -260,6 +309,13 @@ func runCRDController() {
 
 	cancel()
 	time.Sleep(2 * time.Second)
+
+	// Flush spans still batched for export
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer flushCancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("⚠️ Failed to flush traces: %v", err)
+	}
 	log.Println("👋 Step 11: FrontendPage CRD Controller stopped gracefully")
 }
 
@@ -283,8 +339,16 @@ Step 11++ Features:
 func runMultiClusterManager() {
 	log.Println("🎯 Starting Step 11++: Multi-Cluster Management...")
 
-	// Create multi-cluster manager
-	mcm := NewMultiClusterManager()
+	setupLogger()
+
+	// Create multi-cluster manager running the FrontendPage controller in every cluster
+	mcm := NewMultiClusterManager(scheme, func(mgr ctrl.Manager) error {
+		return (&controllers.FrontendPageReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Scheme:    mgr.GetScheme(),
+		}).SetupWithManager(mgr)
+	})
 
 	// Example cluster configurations (in real implementation, load from config file)
 	clusters := []ClusterConfig{
@@ -360,9 +424,29 @@ func runMultiClusterManager() {
 func init() {
 	// Add flags for Step 11
 	crdCmd.Flags().IntVar(&crdMetricsPort, "metrics-port", 8082, "Port for CRD controller metrics")
+	crdCmd.Flags().BoolVar(&crdMetricsSecure, "metrics-secure", false, "Serve metrics over HTTPS to authenticated and authorized clients only")
+	crdCmd.Flags().StringVar(&crdMetricsCertDir, "metrics-cert-dir", "", "Directory containing tls.crt and tls.key for secure metrics (default: self-signed)")
 	crdCmd.Flags().IntVar(&crdHealthPort, "health-port", 8083, "Port for CRD controller health checks")
+	crdCmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve net/http/pprof on localhost at --pprof-port")
+	crdCmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "Port for pprof debug endpoints")
 	crdCmd.Flags().BoolVar(&enableCRDLeaderElection, "enable-leader-election", false, "Enable leader election for CRD controller")
-	crdCmd.Flags().StringVar(&crdLeaderElectionID, "leader-election-id", "k8s-cli-crd-controller", "Leader election ID for CRD controller")
+	crdCmd.Flags().StringVar(&crdLeaderElectionID, "leader-election-id", "k8s-cli-crd-controller", "Leader election ID for CRD controller, also the Lease name")
+	crdCmd.Flags().StringVar(&crdLeaderElectionNamespace, "leader-election-namespace", "", "Namespace for the leader election Lease (default: the pod's namespace in-cluster)")
+	crdCmd.Flags().BoolVar(&enableCRDWebhooks, "enable-webhooks", false, "Enable FrontendPage webhooks (requires serving certificates)")
+	crdCmd.Flags().IntVar(&crdWebhookPort, "webhook-port", 9443, "Port for the webhook server")
+	crdCmd.Flags().StringVar(&crdDefaultRegistry, "default-registry", "", "Registry prefixed to FrontendPage images that don't name one (e.g. registry.internal)")
+	crdCmd.Flags().StringVar(&crdWebhookCertDir, "webhook-cert-dir", "", "Directory containing tls.crt and tls.key for the webhook server")
+	crdCmd.Flags().BoolVar(&crdInstallCRD, "install-crd", false, "Install the bundled FrontendPage CRD if it is missing (needs create on customresourcedefinitions)")
+	crdCmd.Flags().DurationVar(&crdCheckInterval, "crd-check-interval", 10*time.Second, "How often to check for the FrontendPage CRD while it is missing")
+	crdCmd.Flags().IntVar(&crdMaxConcurrentReconciles, "max-concurrent-reconciles", 1, "FrontendPages reconciled at once; more workers also use more of the API client's QPS")
+	crdCmd.Flags().Float64Var(&crdReconcileQPS, "reconcile-qps", 10, "Requeues per second across all FrontendPages after reconcile errors")
+	crdCmd.Flags().IntVar(&crdReconcileBurst, "reconcile-burst", 100, "Requeues allowed in a burst above --reconcile-qps")
+	crdCmd.Flags().StringVar(&otelEndpoint, "otel-endpoint", "", "OTLP/HTTP collector URL for reconcile traces, e.g. http://otel-collector:4318 (default: tracing off)")
+	crdCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, error or a verbosity number")
+	crdCmd.Flags().StringVar(&logFormat, "log-format", "console", "Log format: console or json")
+
+	multiClusterCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, error or a verbosity number")
+	multiClusterCmd.Flags().StringVar(&logFormat, "log-format", "console", "Log format: console or json")
 
 	// Register commands
 	RootCmd.AddCommand(crdCmd)
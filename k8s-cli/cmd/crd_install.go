@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+
+	k8scliv1 "k8s-cli/api/v1"
+	"k8s-cli/config/crd"
+)
+
+var customResourceDefinitions = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// frontendPageCRDServed asks discovery whether the API server serves
+// frontendpages in k8scli.dev/v1, the version the controller watches
+func frontendPageCRDServed(dc discovery.DiscoveryInterface) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(k8scliv1.GroupVersion.String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error discovering %s: %w", k8scliv1.GroupVersion, err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "frontendpages" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// installFrontendPageCRD creates the embedded CRD; one created in the
+// meantime, say by another replica, counts as installed
+func installFrontendPageCRD(ctx context.Context, dyn dynamic.Interface) error {
+	definition, err := crd.FrontendPageCRD()
+	if err != nil {
+		return err
+	}
+	_, err = dyn.Resource(customResourceDefinitions).Create(ctx, definition, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("error installing FrontendPage CRD: %w", err)
+	}
+	return nil
+}
+
+// applyFrontendPageCRD creates the embedded CRD or, if it exists, updates it
+// to the embedded definition, and returns what the API server stored
+func applyFrontendPageCRD(ctx context.Context, dyn dynamic.Interface) (*unstructured.Unstructured, error) {
+	definition, err := crd.FrontendPageCRD()
+	if err != nil {
+		return nil, err
+	}
+
+	crds := dyn.Resource(customResourceDefinitions)
+	applied, err := crds.Create(ctx, definition, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return applied, err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := crds.Get(ctx, definition.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		definition.SetResourceVersion(existing.GetResourceVersion())
+		applied, err = crds.Update(ctx, definition, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
+// crdVersions lists the CRD's versions, marking the storage version
+func crdVersions(definition *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(definition.Object, "spec", "versions")
+	names := make([]string, 0, len(versions))
+	for _, version := range versions {
+		version, ok := version.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := version["name"].(string)
+		if storage, _ := version["storage"].(bool); storage {
+			name += " (storage)"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// waitForFrontendPageCRD returns once discovery lists FrontendPages. A missing
+// CRD is installed with install, otherwise it is reported once with what to
+// do; either way the check repeats every interval until ctx ends, so a
+// controller deployed before its CRD waits instead of crash-looping.
+func waitForFrontendPageCRD(ctx context.Context, dc discovery.DiscoveryInterface, dyn dynamic.Interface, install bool, interval time.Duration) error {
+	installed, reported := false, false
+	return wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		served, err := frontendPageCRDServed(dc)
+		if err != nil {
+			log.Printf("⚠️ Could not check for the FrontendPage CRD, retrying: %v", err)
+			return false, nil
+		}
+		if served {
+			return true, nil
+		}
+
+		switch {
+		case install && !installed:
+			if err := installFrontendPageCRD(ctx, dyn); err != nil {
+				return false, err
+			}
+			installed = true
+			log.Println("📦 Installed the FrontendPage CRD, waiting for it to be served")
+		case !install && !reported:
+			reported = true
+			log.Println("❌ The FrontendPage CRD (frontendpages.k8scli.dev) is not installed.")
+			log.Println("   Install it with:  k8s-cli install crd")
+			log.Println("   or:               kubectl apply -f config/crd/bases/")
+			log.Println("   or restart with --install-crd to install the bundled CRD")
+			log.Printf("   ⏳ Checking again every %s...", interval)
+		}
+		return false, nil
+	})
+}
+
+// serveWaitingProbes answers the health port while the controller waits for
+// its CRD: live, so the kubelet doesn't restart it, but not ready. The returned
+// function frees the port for the manager's own probes.
+func serveWaitingProbes(port int) (stop func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "waiting for the FrontendPage CRD", http.StatusServiceUnavailable)
+	})
+
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("⚠️ Health probes unavailable while waiting for the CRD: %v", err)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var servedFrontendPages = &metav1.APIResourceList{
+	GroupVersion: "k8scli.dev/v1",
+	APIResources: []metav1.APIResource{{Name: "frontendpages", Kind: "FrontendPage", Namespaced: true}},
+}
+
+func TestWaitForFrontendPageCRD(t *testing.T) {
+	newClients := func(resources ...*metav1.APIResourceList) (*fakediscovery.FakeDiscovery, *fakedynamic.FakeDynamicClient) {
+		dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}}
+		dyn := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			customResourceDefinitions: "CustomResourceDefinitionList",
+		})
+		// The API server starts serving a CRD once it is created
+		dyn.PrependReactor("create", "customresourcedefinitions", func(clienttesting.Action) (bool, runtime.Object, error) {
+			dc.Resources = append(dc.Resources, servedFrontendPages)
+			return false, nil, nil
+		})
+		return dc, dyn
+	}
+
+	t.Run("already installed", func(t *testing.T) {
+		dc, dyn := newClients(servedFrontendPages)
+		if err := waitForFrontendPageCRD(context.Background(), dc, dyn, false, time.Millisecond); err != nil {
+			t.Fatalf("wait error = %v", err)
+		}
+	})
+
+	t.Run("missing waits instead of failing", func(t *testing.T) {
+		dc, dyn := newClients(&metav1.APIResourceList{GroupVersion: "k8scli.dev/v1"})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := waitForFrontendPageCRD(ctx, dc, dyn, false, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("wait error = %v, want to keep waiting until the deadline", err)
+		}
+		if len(dyn.Actions()) != 0 {
+			t.Errorf("actions = %v, want nothing installed without --install-crd", dyn.Actions())
+		}
+	})
+
+	t.Run("missing with install", func(t *testing.T) {
+		dc, dyn := newClients()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := waitForFrontendPageCRD(ctx, dc, dyn, true, time.Millisecond); err != nil {
+			t.Fatalf("wait error = %v", err)
+		}
+		installed, err := dyn.Resource(customResourceDefinitions).Get(ctx, "frontendpages.k8scli.dev", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("CRD not installed: %v", err)
+		}
+		if group, _, _ := unstructured.NestedString(installed.Object, "spec", "group"); group != "k8scli.dev" {
+			t.Errorf("installed CRD group = %q", group)
+		}
+	})
+}
+
+func TestApplyFrontendPageCRD(t *testing.T) {
+	dyn := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		customResourceDefinitions: "CustomResourceDefinitionList",
+	})
+	ctx := context.Background()
+
+	created, err := applyFrontendPageCRD(ctx, dyn)
+	if err != nil {
+		t.Fatalf("first apply error = %v", err)
+	}
+	if created.GetName() != "frontendpages.k8scli.dev" {
+		t.Errorf("name = %q", created.GetName())
+	}
+	if got := crdVersions(created); got != "v1 (storage), v2" {
+		t.Errorf("versions = %q, want v1 (storage), v2", got)
+	}
+
+	// An outdated CRD already in the cluster is brought up to date
+	unstructured.SetNestedField(created.Object, "Outdated", "spec", "names", "kind")
+	if _, err := dyn.Resource(customResourceDefinitions).Update(ctx, created, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := applyFrontendPageCRD(ctx, dyn)
+	if err != nil {
+		t.Fatalf("second apply error = %v", err)
+	}
+	if kind, _, _ := unstructured.NestedString(updated.Object, "spec", "names", "kind"); kind != "FrontendPage" {
+		t.Errorf("kind after re-apply = %q, want FrontendPage", kind)
+	}
+}
@@ -3,14 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"k8s-cli/internal/k8s"
+	"k8s-cli/internal/utils"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // createCmd represents the create command
@@ -33,7 +34,19 @@ var createDeploymentCmd = &cobra.Command{
   k8s-cli create deployment app --image=gcr.io/kuber-351315/week-3:v1.0.0 --replicas=3
 
   # Create deployment in specific namespace
-  k8s-cli create deployment demo2 --image=gcr.io/kuber-351315/week-3:v1.0.0 -n my-namespace`,
+  k8s-cli create deployment demo2 --image=gcr.io/kuber-351315/week-3:v1.0.0 -n my-namespace
+
+  # Create deployment from a private registry
+  k8s-cli create deployment api --image=registry.example.com/api:1.0 --image-pull-secret=regcred
+
+  # Create deployment with environment variables
+  k8s-cli create deployment api --image=my-api:v1.0.0 --env LOG_LEVEL=debug --env-from configmap/api-config
+
+  # Create deployment with team labels and an owner annotation
+  k8s-cli create deployment api --image=my-api:v1.0.0 --label team=shop --annotation owner=ops@example.com
+
+  # Print only deployment.apps/<name> for use in scripts
+  k8s-cli create deployment nginx --image=nginx:1.20 -o name`,
 	RunE: runCreateDeployment,
 }
 
@@ -47,7 +60,13 @@ var createPodCmd = &cobra.Command{
   k8s-cli create pod nginx --image=nginx:1.20
 
   # Create pod in specific namespace
-  k8s-cli create pod test-pod --image=gcr.io/kuber-351315/week-3:v1.0.0 -n my-namespace`,
+  k8s-cli create pod test-pod --image=gcr.io/kuber-351315/week-3:v1.0.0 -n my-namespace
+
+  # Create pod from a private registry
+  k8s-cli create pod api --image=registry.example.com/api:1.0 --image-pull-secret=regcred --image-pull-policy=Always
+
+  # Create pod with environment variables from flags, a ConfigMap and a Secret
+  k8s-cli create pod api --image=my-api:v1.0.0 --env LOG_LEVEL=debug --env-from configmap/api-config --env-from secret/api-credentials`,
 	RunE: runCreatePod,
 }
 
@@ -64,7 +83,10 @@ var createServiceCmd = &cobra.Command{
   k8s-cli create service my-service --port=80 --target-port=8080 --type=NodePort
 
   # Create service with selector
-  k8s-cli create service my-service --port=80 --selector=app=nginx`,
+  k8s-cli create service my-service --port=80 --selector=app=nginx
+
+  # Create service with a team label
+  k8s-cli create service my-service --port=80 --label team=shop`,
 	RunE: runCreateService,
 }
 
@@ -78,11 +100,23 @@ func init() {
 	createDeploymentCmd.Flags().String("image", "", "Container image to use (required)")
 	createDeploymentCmd.Flags().Int32("replicas", 1, "Number of replicas")
 	createDeploymentCmd.Flags().Int32("port", 0, "Container port to expose")
+	createDeploymentCmd.Flags().String("image-pull-policy", "", "Image pull policy (Always, IfNotPresent, Never)")
+	createDeploymentCmd.Flags().StringArray("image-pull-secret", nil, "Secret with registry credentials, can be repeated")
+	createDeploymentCmd.Flags().StringArray("env", nil, "Environment variable KEY=VALUE, can be repeated")
+	createDeploymentCmd.Flags().StringArray("env-from", nil, "Load environment variables from configmap/<name> or secret/<name>, can be repeated")
+	createDeploymentCmd.Flags().StringArray("label", nil, "Label key=value to add, can be repeated (overrides the default app label)")
+	createDeploymentCmd.Flags().StringArray("annotation", nil, "Annotation key=value to add, can be repeated")
 	createDeploymentCmd.MarkFlagRequired("image")
 
 	// Flags for pod
 	createPodCmd.Flags().String("image", "", "Container image to use (required)")
 	createPodCmd.Flags().Int32("port", 0, "Container port to expose")
+	createPodCmd.Flags().String("image-pull-policy", "", "Image pull policy (Always, IfNotPresent, Never)")
+	createPodCmd.Flags().StringArray("image-pull-secret", nil, "Secret with registry credentials, can be repeated")
+	createPodCmd.Flags().StringArray("env", nil, "Environment variable KEY=VALUE, can be repeated")
+	createPodCmd.Flags().StringArray("env-from", nil, "Load environment variables from configmap/<name> or secret/<name>, can be repeated")
+	createPodCmd.Flags().StringArray("label", nil, "Label key=value to add, can be repeated (overrides the default app label)")
+	createPodCmd.Flags().StringArray("annotation", nil, "Annotation key=value to add, can be repeated")
 	createPodCmd.MarkFlagRequired("image")
 
 	// Flags for service
@@ -90,6 +124,8 @@ func init() {
 	createServiceCmd.Flags().Int32("target-port", 0, "Target port (defaults to port)")
 	createServiceCmd.Flags().String("type", "ClusterIP", "Service type (ClusterIP, NodePort, LoadBalancer)")
 	createServiceCmd.Flags().String("selector", "", "Selector for service (e.g., app=nginx)")
+	createServiceCmd.Flags().StringArray("label", nil, "Label key=value to add, can be repeated (overrides the default app label)")
+	createServiceCmd.Flags().StringArray("annotation", nil, "Annotation key=value to add, can be repeated")
 }
 
 func runCreateDeployment(cmd *cobra.Command, args []string) error {
@@ -97,21 +133,37 @@ func runCreateDeployment(cmd *cobra.Command, args []string) error {
 	image, _ := cmd.Flags().GetString("image")
 	replicas, _ := cmd.Flags().GetInt32("replicas")
 	port, _ := cmd.Flags().GetInt32("port")
-	namespace := viper.GetString("namespace")
+	namespace := resolveNamespace()
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	pullPolicy, pullSecrets, err := imagePullOptions(cmd)
+	if err != nil {
+		return err
+	}
+	env, envFrom, err := envOptions(cmd)
+	if err != nil {
+		return err
+	}
+	labels, annotations, err := metadataOptions(cmd, deploymentName)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Create deployment object
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      deploymentName,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"app": deploymentName,
-			},
+			Name:        deploymentName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -129,10 +181,14 @@ func runCreateDeployment(cmd *cobra.Command, args []string) error {
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  deploymentName,
-							Image: image,
+							Name:            deploymentName,
+							Image:           image,
+							ImagePullPolicy: pullPolicy,
+							Env:             env,
+							EnvFrom:         envFrom,
 						},
 					},
+					ImagePullSecrets: pullSecrets,
 				},
 			},
 		},
@@ -153,16 +209,19 @@ func runCreateDeployment(cmd *cobra.Command, args []string) error {
 		deployment,
 		metav1.CreateOptions{},
 	)
+	reportResult(utils.ActionResult{Action: "create", Kind: "Deployment", Name: deploymentName, Namespace: namespace, Result: "created"}, err)
 	if err != nil {
 		return fmt.Errorf("error creating deployment: %w", err)
 	}
 
-	fmt.Printf("✅ Deployment '%s' created successfully in namespace '%s'\n", deploymentName, namespace)
-	fmt.Printf("   Image: %s\n", image)
-	fmt.Printf("   Replicas: %d\n", replicas)
+	printCreatedName("deployment", "apps", deploymentName)
+	infof("✅ Deployment '%s' created successfully in namespace '%s'\n", deploymentName, namespace)
+	infof("   Image: %s\n", image)
+	infof("   Replicas: %d\n", replicas)
 	if port > 0 {
-		fmt.Printf("   Port: %d\n", port)
+		infof("   Port: %d\n", port)
 	}
+	printImagePullOptions(pullPolicy, pullSecrets)
 
 	return nil
 }
@@ -171,29 +230,49 @@ func runCreatePod(cmd *cobra.Command, args []string) error {
 	podName := args[0]
 	image, _ := cmd.Flags().GetString("image")
 	port, _ := cmd.Flags().GetInt32("port")
-	namespace := viper.GetString("namespace")
+	namespace := resolveNamespace()
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	pullPolicy, pullSecrets, err := imagePullOptions(cmd)
+	if err != nil {
+		return err
+	}
+	env, envFrom, err := envOptions(cmd)
+	if err != nil {
+		return err
+	}
+	labels, annotations, err := metadataOptions(cmd, podName)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Create pod object
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"app": podName,
-			},
+			Name:        podName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
-					Name:  podName,
-					Image: image,
+					Name:            podName,
+					Image:           image,
+					ImagePullPolicy: pullPolicy,
+					Env:             env,
+					EnvFrom:         envFrom,
 				},
 			},
+			ImagePullSecrets: pullSecrets,
 		},
 	}
 
@@ -212,37 +291,168 @@ func runCreatePod(cmd *cobra.Command, args []string) error {
 		pod,
 		metav1.CreateOptions{},
 	)
+	reportResult(utils.ActionResult{Action: "create", Kind: "Pod", Name: podName, Namespace: namespace, Result: "created"}, err)
 	if err != nil {
 		return fmt.Errorf("error creating pod: %w", err)
 	}
 
-	fmt.Printf("✅ Pod '%s' created successfully in namespace '%s'\n", podName, namespace)
-	fmt.Printf("   Image: %s\n", image)
+	printCreatedName("pod", "", podName)
+	infof("✅ Pod '%s' created successfully in namespace '%s'\n", podName, namespace)
+	infof("   Image: %s\n", image)
 	if port > 0 {
-		fmt.Printf("   Port: %d\n", port)
+		infof("   Port: %d\n", port)
 	}
+	printImagePullOptions(pullPolicy, pullSecrets)
 
 	return nil
 }
 
+// imagePullOptions reads --image-pull-policy and --image-pull-secret. An empty
+// policy is left for the API server to default from the image tag.
+func imagePullOptions(cmd *cobra.Command) (corev1.PullPolicy, []corev1.LocalObjectReference, error) {
+	policy, _ := cmd.Flags().GetString("image-pull-policy")
+	secrets, _ := cmd.Flags().GetStringArray("image-pull-secret")
+
+	pullPolicy, err := parseImagePullPolicy(policy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	for _, secret := range secrets {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	return pullPolicy, pullSecrets, nil
+}
+
+// parseImagePullPolicy accepts the pull policies the API server does
+func parseImagePullPolicy(policy string) (corev1.PullPolicy, error) {
+	switch pullPolicy := corev1.PullPolicy(policy); pullPolicy {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+		return pullPolicy, nil
+	default:
+		return "", usageErrorf("invalid --image-pull-policy %q: must be Always, IfNotPresent or Never", policy)
+	}
+}
+
+// envOptions reads --env KEY=VALUE into container env vars and
+// --env-from configmap/<name> or secret/<name> into env sources
+func envOptions(cmd *cobra.Command) ([]corev1.EnvVar, []corev1.EnvFromSource, error) {
+	envFlags, _ := cmd.Flags().GetStringArray("env")
+	envFromFlags, _ := cmd.Flags().GetStringArray("env-from")
+
+	var env []corev1.EnvVar
+	for _, value := range envFlags {
+		parts := splitKeyValue(value)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, nil, usageErrorf("invalid --env %q: must be KEY=VALUE", value)
+		}
+		env = append(env, corev1.EnvVar{Name: parts[0], Value: parts[1]})
+	}
+
+	var envFrom []corev1.EnvFromSource
+	for _, value := range envFromFlags {
+		kind, name, found := strings.Cut(value, "/")
+		if !found || name == "" {
+			return nil, nil, usageErrorf("invalid --env-from %q: must be configmap/<name> or secret/<name>", value)
+		}
+		switch strings.ToLower(kind) {
+		case "configmap", "cm":
+			envFrom = append(envFrom, corev1.EnvFromSource{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			})
+		case "secret":
+			envFrom = append(envFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			})
+		default:
+			return nil, nil, usageErrorf("invalid --env-from %q: must be configmap/<name> or secret/<name>", value)
+		}
+	}
+	return env, envFrom, nil
+}
+
+// metadataOptions builds the object's labels, app=<name> unless --label sets
+// app, and annotations from --label and --annotation key=value flags
+func metadataOptions(cmd *cobra.Command, name string) (map[string]string, map[string]string, error) {
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	annotationFlags, _ := cmd.Flags().GetStringArray("annotation")
+
+	labels := map[string]string{"app": name}
+	for _, value := range labelFlags {
+		key, val, err := parseMetadataPair("label", value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+			return nil, nil, usageErrorf("invalid --label %q: %s", value, strings.Join(errs, "; "))
+		}
+		labels[key] = val
+	}
+
+	var annotations map[string]string
+	for _, value := range annotationFlags {
+		key, val, err := parseMetadataPair("annotation", value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[key] = val
+	}
+	return labels, annotations, nil
+}
+
+// parseMetadataPair splits a --label or --annotation key=value and checks the
+// key is a valid qualified name, an optional DNS prefix and a name
+func parseMetadataPair(flag, value string) (string, string, error) {
+	parts := splitKeyValue(value)
+	if len(parts) != 2 {
+		return "", "", usageErrorf("invalid --%s %q: must be key=value", flag, value)
+	}
+	if errs := validation.IsQualifiedName(parts[0]); len(errs) > 0 {
+		return "", "", usageErrorf("invalid --%s %q: %s", flag, value, strings.Join(errs, "; "))
+	}
+	return parts[0], parts[1], nil
+}
+
+func printImagePullOptions(pullPolicy corev1.PullPolicy, pullSecrets []corev1.LocalObjectReference) {
+	if pullPolicy != "" {
+		infof("   Image pull policy: %s\n", pullPolicy)
+	}
+	for _, secret := range pullSecrets {
+		infof("   Image pull secret: %s\n", secret.Name)
+	}
+}
+
 func runCreateService(cmd *cobra.Command, args []string) error {
 	serviceName := args[0]
 	port, _ := cmd.Flags().GetInt32("port")
 	targetPort, _ := cmd.Flags().GetInt32("target-port")
 	serviceType, _ := cmd.Flags().GetString("type")
 	selector, _ := cmd.Flags().GetString("selector")
-	namespace := viper.GetString("namespace")
+	namespace := resolveNamespace()
+
+	labels, annotations, err := metadataOptions(cmd, serviceName)
+	if err != nil {
+		return err
+	}
 
 	// Default target port to port if not specified
 	if targetPort == 0 {
 		targetPort = port
 	}
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Parse selector
 	selectorMap := make(map[string]string)
 	if selector != "" {
@@ -261,11 +471,10 @@ func runCreateService(cmd *cobra.Command, args []string) error {
 	// Create service object
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      serviceName,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"app": serviceName,
-			},
+			Name:        serviceName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
 			Type:     corev1.ServiceType(serviceType),
@@ -286,14 +495,16 @@ func runCreateService(cmd *cobra.Command, args []string) error {
 		service,
 		metav1.CreateOptions{},
 	)
+	reportResult(utils.ActionResult{Action: "create", Kind: "Service", Name: serviceName, Namespace: namespace, Result: "created"}, err)
 	if err != nil {
 		return fmt.Errorf("error creating service: %w", err)
 	}
 
-	fmt.Printf("✅ Service '%s' created successfully in namespace '%s'\n", serviceName, namespace)
-	fmt.Printf("   Type: %s\n", serviceType)
-	fmt.Printf("   Port: %d -> %d\n", port, targetPort)
-	fmt.Printf("   Selector: %v\n", selectorMap)
+	printCreatedName("service", "", serviceName)
+	infof("✅ Service '%s' created successfully in namespace '%s'\n", serviceName, namespace)
+	infof("   Type: %s\n", serviceType)
+	infof("   Port: %d -> %d\n", port, targetPort)
+	infof("   Selector: %v\n", selectorMap)
 
 	return nil
 }
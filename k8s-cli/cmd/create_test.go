@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestImagePullOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantPolicy  corev1.PullPolicy
+		wantSecrets []corev1.LocalObjectReference
+		wantErr     bool
+	}{
+		{name: "unset"},
+		{name: "policy", args: []string{"--image-pull-policy=IfNotPresent"}, wantPolicy: corev1.PullIfNotPresent},
+		{
+			name:        "repeated secrets",
+			args:        []string{"--image-pull-secret=regcred", "--image-pull-secret=mirror"},
+			wantSecrets: []corev1.LocalObjectReference{{Name: "regcred"}, {Name: "mirror"}},
+		},
+		{name: "invalid policy", args: []string{"--image-pull-policy=always"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("image-pull-policy", "", "")
+			cmd.Flags().StringArray("image-pull-secret", nil, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			policy, secrets, err := imagePullOptions(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("imagePullOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if policy != tt.wantPolicy {
+				t.Errorf("policy = %q, want %q", policy, tt.wantPolicy)
+			}
+			if !reflect.DeepEqual(secrets, tt.wantSecrets) {
+				t.Errorf("secrets = %v, want %v", secrets, tt.wantSecrets)
+			}
+		})
+	}
+}
+
+func TestEnvOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantEnv     []corev1.EnvVar
+		wantEnvFrom []corev1.EnvFromSource
+		wantErr     string
+	}{
+		{name: "unset"},
+		{
+			name:    "env values keep later equals signs",
+			args:    []string{"--env=MODE=debug", "--env=QUERY=a=b,c", "--env=EMPTY="},
+			wantEnv: []corev1.EnvVar{{Name: "MODE", Value: "debug"}, {Name: "QUERY", Value: "a=b,c"}, {Name: "EMPTY"}},
+		},
+		{
+			name: "env from configmap and secret",
+			args: []string{"--env-from=configmap/settings", "--env-from=secret/credentials"},
+			wantEnvFrom: []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}},
+			},
+		},
+		{name: "env without equals", args: []string{"--env=MODE"}, wantErr: `invalid --env "MODE"`},
+		{name: "env without key", args: []string{"--env==debug"}, wantErr: `invalid --env "=debug"`},
+		{name: "env from unknown kind", args: []string{"--env-from=volume/data"}, wantErr: `invalid --env-from "volume/data"`},
+		{name: "env from without name", args: []string{"--env-from=secret/"}, wantErr: `invalid --env-from "secret/"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringArray("env", nil, "")
+			cmd.Flags().StringArray("env-from", nil, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			env, envFrom, err := envOptions(cmd)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("envOptions() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("envOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(env, tt.wantEnv) {
+				t.Errorf("env = %v, want %v", env, tt.wantEnv)
+			}
+			if !reflect.DeepEqual(envFrom, tt.wantEnvFrom) {
+				t.Errorf("envFrom = %v, want %v", envFrom, tt.wantEnvFrom)
+			}
+		})
+	}
+}
+
+func TestMetadataOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+		wantErr         string
+	}{
+		{name: "default app label", wantLabels: map[string]string{"app": "web"}},
+		{
+			name:            "labels and annotations",
+			args:            []string{"--label=team=shop", "--label=example.com/cost-center=42", "--annotation=owner=ops@example.com"},
+			wantLabels:      map[string]string{"app": "web", "team": "shop", "example.com/cost-center": "42"},
+			wantAnnotations: map[string]string{"owner": "ops@example.com"},
+		},
+		{name: "app label overridden", args: []string{"--label=app=storefront"}, wantLabels: map[string]string{"app": "storefront"}},
+		{name: "label without value", args: []string{"--label=team"}, wantErr: `invalid --label "team"`},
+		{name: "invalid label key", args: []string{"--label=-team=shop"}, wantErr: `invalid --label "-team=shop"`},
+		{name: "invalid label value", args: []string{"--label=owner=ops@example.com"}, wantErr: `invalid --label "owner=ops@example.com"`},
+		{name: "invalid annotation key", args: []string{"--annotation=cost center=42"}, wantErr: `invalid --annotation "cost center=42"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringArray("label", nil, "")
+			cmd.Flags().StringArray("annotation", nil, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			labels, annotations, err := metadataOptions(cmd, "web")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("metadataOptions() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("metadataOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", labels, tt.wantLabels)
+			}
+			if !reflect.DeepEqual(annotations, tt.wantAnnotations) {
+				t.Errorf("annotations = %v, want %v", annotations, tt.wantAnnotations)
+			}
+		})
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
-	"k8s-cli/internal/k8s"
+	"k8s-cli/internal/utils"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
 )
 
 // deleteCmd represents the delete command
@@ -51,7 +54,13 @@ var deletePodCmd = &cobra.Command{
   k8s-cli delete pod nginx-pod -n my-app
 
   # Force delete without confirmation
-  k8s-cli delete pod nginx-pod --force`,
+  k8s-cli delete pod nginx-pod --force
+
+  # Give the pod 5 seconds to shut down instead of its own grace period
+  k8s-cli delete pod nginx-pod --grace-period=5
+
+  # Remove a stuck pod immediately (unsafe, see --now)
+  k8s-cli delete pod nginx-pod --now --force`,
 	RunE: runDeletePod,
 }
 
@@ -89,18 +98,62 @@ var deleteServiceCmd = &cobra.Command{
 	RunE: runDeleteService,
 }
 
+// deletePodsCmd deletes every pod matching a label selector
+var deletePodsCmd = &cobra.Command{
+	Use:   "pods",
+	Short: "Delete pods by label selector",
+	Long:  "Delete every pod matching a label selector, or all pods in the namespace with --all",
+	Args:  cobra.NoArgs,
+	Example: `  # Delete the pods labelled app=test
+  k8s-cli delete pods --selector app=test -n my-app
+
+  # Delete every pod in the namespace without confirmation
+  k8s-cli delete pods --all --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeleteBySelector(cmd, podDeleter)
+	},
+}
+
+// deleteDeploymentsCmd deletes every deployment matching a label selector
+var deleteDeploymentsCmd = &cobra.Command{
+	Use:   "deployments",
+	Short: "Delete deployments by label selector",
+	Long:  "Delete every deployment matching a label selector, or all deployments in the namespace with --all",
+	Args:  cobra.NoArgs,
+	Example: `  # Delete the deployments labelled app=test
+  k8s-cli delete deployments --selector app=test -n my-app
+
+  # Delete every deployment in the namespace without confirmation
+  k8s-cli delete deployments --all --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeleteBySelector(cmd, deploymentDeleter)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.AddCommand(deleteFileCmd)
 	deleteCmd.AddCommand(deletePodCmd)
 	deleteCmd.AddCommand(deleteDeploymentCmd)
 	deleteCmd.AddCommand(deleteServiceCmd)
+	deleteCmd.AddCommand(deletePodsCmd)
+	deleteCmd.AddCommand(deleteDeploymentsCmd)
 
 	// Add flags
 	deleteFileCmd.Flags().Bool("force", false, "Force delete without confirmation")
 	deletePodCmd.Flags().Bool("force", false, "Force delete without confirmation")
+	deletePodCmd.Flags().Int("grace-period", -1, "Seconds the pod gets to terminate gracefully; -1 uses the pod's own terminationGracePeriodSeconds")
+	deletePodCmd.Flags().Bool("now", false, "Delete immediately with --grace-period=0 and foreground propagation. Unsafe: the pod may keep running on a node that can't be reached")
+	deletePodCmd.MarkFlagsMutuallyExclusive("grace-period", "now")
 	deleteDeploymentCmd.Flags().Bool("force", false, "Force delete without confirmation")
 	deleteServiceCmd.Flags().Bool("force", false, "Force delete without confirmation")
+
+	for _, cmd := range []*cobra.Command{deletePodsCmd, deleteDeploymentsCmd} {
+		cmd.Flags().Bool("force", false, "Force delete without confirmation")
+		cmd.Flags().StringP("selector", "l", "", "Label selector of the resources to delete")
+		cmd.Flags().Bool("all", false, "Delete every resource in the namespace")
+		cmd.MarkFlagsMutuallyExclusive("selector", "all")
+	}
 }
 
 func runDeleteFile(cmd *cobra.Command, args []string) error {
@@ -114,11 +167,15 @@ func runDeleteFile(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create Kubernetes client
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	namespace := viper.GetString("namespace")
 
 	// Parse YAML to get resource info
@@ -128,6 +185,10 @@ func runDeleteFile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error decoding YAML: %w", err)
 	}
 
+	if err := client.ValidateResourceType(obj.GetKind()); err != nil {
+		return err
+	}
+
 	// Confirm deletion unless force flag is used
 	if !force {
 		fmt.Printf("Are you sure you want to delete %s/%s? (y/N): ", obj.GetKind(), obj.GetName())
@@ -135,17 +196,19 @@ func runDeleteFile(cmd *cobra.Command, args []string) error {
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
 			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "delete", Kind: obj.GetKind(), Name: obj.GetName(), Namespace: namespace, Result: "cancelled"}, nil)
 			return nil
 		}
 	}
 
 	// Delete the resource
 	err = client.DeleteFromYAML(yamlData, namespace)
+	reportResult(utils.ActionResult{Action: "delete", Kind: obj.GetKind(), Name: obj.GetName(), Namespace: namespace, Result: "deleted"}, err)
 	if err != nil {
 		return fmt.Errorf("error deleting resource: %w", err)
 	}
 
-	fmt.Printf("✅ Resource successfully deleted from file: %s\n", filename)
+	infof("✅ Resource successfully deleted from file: %s\n", filename)
 	return nil
 }
 
@@ -153,12 +216,20 @@ func runDeletePod(cmd *cobra.Command, args []string) error {
 	podName := args[0]
 	force, _ := cmd.Flags().GetBool("force")
 	namespace := viper.GetString("namespace")
+	deleteOptions, err := podDeleteOptions(cmd)
+	if err != nil {
+		return err
+	}
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Confirm deletion unless force flag is used
 	if !force {
 		fmt.Printf("Are you sure you want to delete pod/%s in namespace %s? (y/N): ", podName, namespace)
@@ -166,34 +237,69 @@ func runDeletePod(cmd *cobra.Command, args []string) error {
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
 			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "delete", Kind: "Pod", Name: podName, Namespace: namespace, Result: "cancelled"}, nil)
 			return nil
 		}
 	}
 
+	if deleteOptions.GracePeriodSeconds != nil && *deleteOptions.GracePeriodSeconds == 0 {
+		fmt.Fprintln(os.Stderr, "⚠️ Immediate deletion does not wait for the kubelet to confirm the pod stopped. "+
+			"If its node is partitioned, the containers may keep running after the pod is gone from the API.")
+	}
+
 	// Delete the pod
 	err = client.GetClientset().CoreV1().Pods(namespace).Delete(
 		context.TODO(),
 		podName,
-		metav1.DeleteOptions{},
+		deleteOptions,
 	)
+	reportResult(utils.ActionResult{Action: "delete", Kind: "Pod", Name: podName, Namespace: namespace, Result: "deleted"}, err)
 	if err != nil {
 		return fmt.Errorf("error deleting pod: %w", err)
 	}
 
-	fmt.Printf("✅ Pod '%s' successfully deleted from namespace '%s'\n", podName, namespace)
+	infof("✅ Pod '%s' successfully deleted from namespace '%s'\n", podName, namespace)
 	return nil
 }
 
+// podDeleteOptions builds the DeleteOptions for delete pod from --grace-period
+// and --now; with neither the pod's own grace period applies
+func podDeleteOptions(cmd *cobra.Command) (metav1.DeleteOptions, error) {
+	gracePeriod, _ := cmd.Flags().GetInt("grace-period")
+	now, _ := cmd.Flags().GetBool("now")
+
+	var options metav1.DeleteOptions
+	if now {
+		immediate := int64(0)
+		propagation := metav1.DeletePropagationForeground
+		options.GracePeriodSeconds = &immediate
+		options.PropagationPolicy = &propagation
+		return options, nil
+	}
+	if gracePeriod < -1 {
+		return options, usageErrorf("--grace-period must be -1 or at least 0, got %d", gracePeriod)
+	}
+	if gracePeriod >= 0 {
+		seconds := int64(gracePeriod)
+		options.GracePeriodSeconds = &seconds
+	}
+	return options, nil
+}
+
 func runDeleteDeployment(cmd *cobra.Command, args []string) error {
 	deploymentName := args[0]
 	force, _ := cmd.Flags().GetBool("force")
 	namespace := viper.GetString("namespace")
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Confirm deletion unless force flag is used
 	if !force {
 		fmt.Printf("Are you sure you want to delete deployment/%s in namespace %s? (y/N): ", deploymentName, namespace)
@@ -201,6 +307,7 @@ func runDeleteDeployment(cmd *cobra.Command, args []string) error {
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
 			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "delete", Kind: "Deployment", Name: deploymentName, Namespace: namespace, Result: "cancelled"}, nil)
 			return nil
 		}
 	}
@@ -211,11 +318,12 @@ func runDeleteDeployment(cmd *cobra.Command, args []string) error {
 		deploymentName,
 		metav1.DeleteOptions{},
 	)
+	reportResult(utils.ActionResult{Action: "delete", Kind: "Deployment", Name: deploymentName, Namespace: namespace, Result: "deleted"}, err)
 	if err != nil {
 		return fmt.Errorf("error deleting deployment: %w", err)
 	}
 
-	fmt.Printf("✅ Deployment '%s' successfully deleted from namespace '%s'\n", deploymentName, namespace)
+	infof("✅ Deployment '%s' successfully deleted from namespace '%s'\n", deploymentName, namespace)
 	return nil
 }
 
@@ -224,11 +332,15 @@ func runDeleteService(cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 	namespace := viper.GetString("namespace")
 
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	// Confirm deletion unless force flag is used
 	if !force {
 		fmt.Printf("Are you sure you want to delete service/%s in namespace %s? (y/N): ", serviceName, namespace)
@@ -236,6 +348,7 @@ func runDeleteService(cmd *cobra.Command, args []string) error {
 		fmt.Scanln(&response)
 		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
 			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "delete", Kind: "Service", Name: serviceName, Namespace: namespace, Result: "cancelled"}, nil)
 			return nil
 		}
 	}
@@ -246,10 +359,149 @@ func runDeleteService(cmd *cobra.Command, args []string) error {
 		serviceName,
 		metav1.DeleteOptions{},
 	)
+	reportResult(utils.ActionResult{Action: "delete", Kind: "Service", Name: serviceName, Namespace: namespace, Result: "deleted"}, err)
 	if err != nil {
 		return fmt.Errorf("error deleting service: %w", err)
 	}
 
-	fmt.Printf("✅ Service '%s' successfully deleted from namespace '%s'\n", serviceName, namespace)
+	infof("✅ Service '%s' successfully deleted from namespace '%s'\n", serviceName, namespace)
+	return nil
+}
+
+// bulkDeleter lists and deletes one kind of resource for the plural delete commands
+type bulkDeleter struct {
+	kind   string
+	plural string
+	list   func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]string, error)
+	delete func(ctx context.Context, namespace, name string) error
+}
+
+func podDeleter(clientset kubernetes.Interface) bulkDeleter {
+	return bulkDeleter{
+		kind:   "Pod",
+		plural: "pods",
+		list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]string, error) {
+			pods, err := clientset.CoreV1().Pods(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(pods.Items))
+			for _, pod := range pods.Items {
+				names = append(names, pod.Name)
+			}
+			return names, nil
+		},
+		delete: func(ctx context.Context, namespace, name string) error {
+			return clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+}
+
+func deploymentDeleter(clientset kubernetes.Interface) bulkDeleter {
+	return bulkDeleter{
+		kind:   "Deployment",
+		plural: "deployments",
+		list: func(ctx context.Context, namespace string, opts metav1.ListOptions) ([]string, error) {
+			deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(deployments.Items))
+			for _, deployment := range deployments.Items {
+				names = append(names, deployment.Name)
+			}
+			return names, nil
+		},
+		delete: func(ctx context.Context, namespace, name string) error {
+			return clientset.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+}
+
+// deleteSelector returns the label selector of a plural delete command. An
+// empty selector would match everything, so it needs an explicit --all.
+func deleteSelector(cmd *cobra.Command) (string, error) {
+	selector, _ := cmd.Flags().GetString("selector")
+	all, _ := cmd.Flags().GetBool("all")
+
+	selector = strings.TrimSpace(selector)
+	if selector == "" && !all {
+		return "", usageErrorf("a label selector (-l) or --all is required, refusing to delete everything implicitly")
+	}
+	if selector != "" {
+		if _, err := labels.Parse(selector); err != nil {
+			return "", usageErrorf("invalid selector %q: %w", selector, err)
+		}
+	}
+	return selector, nil
+}
+
+func runDeleteBySelector(cmd *cobra.Command, newDeleter func(kubernetes.Interface) bulkDeleter) error {
+	force, _ := cmd.Flags().GetBool("force")
+	selector, err := deleteSelector(cmd)
+	if err != nil {
+		return err
+	}
+	namespace := viper.GetString("namespace")
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
+	deleter := newDeleter(client.GetClientset())
+	names, err := deleter.list(context.TODO(), namespace, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("error listing %s: %w", deleter.plural, err)
+	}
+	if len(names) == 0 {
+		infof("ℹ️ No %s matched in namespace '%s'\n", deleter.plural, namespace)
+		return nil
+	}
+
+	// Confirm deletion unless force flag is used
+	if !force {
+		fmt.Printf("The following %d %s in namespace %s will be deleted:\n", len(names), deleter.plural, namespace)
+		for _, name := range names {
+			fmt.Printf("  - %s\n", name)
+		}
+		fmt.Printf("Are you sure you want to delete %d %s? (y/N): ", len(names), deleter.plural)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Deletion cancelled")
+			for _, name := range names {
+				reportResult(utils.ActionResult{Action: "delete", Kind: deleter.kind, Name: name, Namespace: namespace, Result: "cancelled"}, nil)
+			}
+			return nil
+		}
+	}
+
+	return deleteAll(context.TODO(), deleter, namespace, names)
+}
+
+// deleteAll deletes every named resource, carrying on past failures, and
+// prints how many were deleted
+func deleteAll(ctx context.Context, deleter bulkDeleter, namespace string, names []string) error {
+	deleted := 0
+	for _, name := range names {
+		err := deleter.delete(ctx, namespace, name)
+		reportResult(utils.ActionResult{Action: "delete", Kind: deleter.kind, Name: name, Namespace: namespace, Result: "deleted"}, err)
+		if err != nil {
+			infof("❌ %s '%s': %v\n", deleter.kind, name, err)
+			continue
+		}
+		deleted++
+		infof("🗑️ %s '%s' deleted\n", deleter.kind, name)
+	}
+
+	if failed := len(names) - deleted; failed > 0 {
+		return fmt.Errorf("deleted %d of %d %s, %d failed", deleted, len(names), deleter.plural, failed)
+	}
+	infof("✅ Deleted %d %s from namespace '%s'\n", deleted, deleter.plural, namespace)
 	return nil
 }
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDeleteSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "selector", args: []string{"--selector", "app=test"}, want: "app=test"},
+		{name: "all", args: []string{"--all"}, want: ""},
+		{name: "neither", wantErr: true},
+		{name: "blank selector", args: []string{"-l", " "}, wantErr: true},
+		{name: "invalid selector", args: []string{"-l", "app=(test"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringP("selector", "l", "", "")
+			cmd.Flags().Bool("all", false, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := deleteSelector(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deleteSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("deleteSelector() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteBySelector(t *testing.T) {
+	deployment := func(name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels}}
+	}
+	clientset := fake.NewSimpleClientset(
+		deployment("test-a", map[string]string{"app": "test"}),
+		deployment("test-b", map[string]string{"app": "test"}),
+		deployment("web", map[string]string{"app": "web"}),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", Labels: map[string]string{"app": "test"}}},
+	)
+	ctx := context.Background()
+
+	deleter := deploymentDeleter(clientset)
+	names, err := deleter.list(ctx, "default", metav1.ListOptions{LabelSelector: "app=test"})
+	if err != nil {
+		t.Fatalf("list() error = %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("list() = %v, want the two app=test deployments", names)
+	}
+	if err := deleteAll(ctx, deleter, "default", names); err != nil {
+		t.Fatalf("deleteAll() error = %v", err)
+	}
+
+	remaining, _ := clientset.AppsV1().Deployments("default").List(ctx, metav1.ListOptions{})
+	if len(remaining.Items) != 1 || remaining.Items[0].Name != "web" {
+		t.Errorf("remaining deployments = %v, want only web", remaining.Items)
+	}
+	if _, err := clientset.CoreV1().Pods("default").Get(ctx, "test-pod", metav1.GetOptions{}); err != nil {
+		t.Errorf("pod deleted by delete deployments: %v", err)
+	}
+}
+
+func TestDeleteAllContinuesPastFailures(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}},
+	)
+	clientset.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.DeleteAction).GetName() == "a" {
+			return true, nil, fmt.Errorf("forbidden")
+		}
+		return false, nil, nil
+	})
+
+	err := deleteAll(context.Background(), podDeleter(clientset), "default", []string{"a", "b"})
+	if err == nil || err.Error() != "deleted 1 of 2 pods, 1 failed" {
+		t.Fatalf("deleteAll() error = %v, want 1 of 2 deleted", err)
+	}
+	if _, err := clientset.CoreV1().Pods("default").Get(context.Background(), "b", metav1.GetOptions{}); err == nil {
+		t.Error("pod b still exists after a failure deleting pod a")
+	}
+}
+
+func TestPodDeleteOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		wantGracePeriod *int64
+		wantForeground  bool
+		wantErr         bool
+	}{
+		{name: "default"},
+		{name: "grace period", args: []string{"--grace-period=5"}, wantGracePeriod: int64Ptr(5)},
+		{name: "zero grace period", args: []string{"--grace-period=0"}, wantGracePeriod: int64Ptr(0)},
+		{name: "now", args: []string{"--now"}, wantGracePeriod: int64Ptr(0), wantForeground: true},
+		{name: "negative grace period", args: []string{"--grace-period=-2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Int("grace-period", -1, "")
+			cmd.Flags().Bool("now", false, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			options, err := podDeleteOptions(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("podDeleteOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(options.GracePeriodSeconds, tt.wantGracePeriod) {
+				t.Errorf("GracePeriodSeconds = %v, want %v", options.GracePeriodSeconds, tt.wantGracePeriod)
+			}
+			foreground := options.PropagationPolicy != nil && *options.PropagationPolicy == metav1.DeletePropagationForeground
+			if foreground != tt.wantForeground {
+				t.Errorf("PropagationPolicy = %v, want foreground %v", options.PropagationPolicy, tt.wantForeground)
+			}
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s-cli/internal/utils"
+)
+
+// eventsCmd lists and optionally streams cluster events
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "List cluster events",
+	Long: `List events in a namespace, oldest first by last occurrence.
+
+With --watch the existing events are printed and new or updated events are
+streamed as they arrive, using a shared informer like the watch command.`,
+	Example: `  # Events in the current namespace
+  k8s-cli events
+
+  # Stream events from kube-system
+  k8s-cli events --watch -n kube-system`,
+	RunE: runEvents,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	// Add flags
+	eventsCmd.Flags().BoolP("watch", "w", false, "Stream new events after listing existing ones")
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	namespace := viper.GetString("namespace")
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
+	if watch {
+		return watchEvents(client.GetClientset(), namespace)
+	}
+
+	events, err := client.GetClientset().CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing events: %w", err)
+	}
+
+	sortEvents(events.Items)
+	return utils.PrintEvents(events.Items, viper.GetString("output"))
+}
+
+// watchEvents prints the informer's initial snapshot, then every event
+// added or updated after the cache has synced, until interrupted
+func watchEvents(clientset kubernetes.Interface, namespace string) error {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithNamespace(namespace))
+	eventInformer := informerFactory.Core().V1().Events().Informer()
+
+	// The handler is already receiving the informer's initial list while the
+	// snapshot below is printed; printed remembers what the snapshot covered
+	// so nothing is dropped or shown twice.
+	var mu sync.Mutex
+	var printed map[types.UID]string
+	printEvent := func(obj interface{}) {
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if printed == nil {
+			return
+		}
+		if printed[event.UID] == event.ResourceVersion {
+			return
+		}
+		printed[event.UID] = event.ResourceVersion
+		utils.PrintEventLine(*event)
+	}
+
+	eventInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: printEvent,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			printEvent(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if event, ok := obj.(*corev1.Event); ok {
+				mu.Lock()
+				delete(printed, event.UID)
+				mu.Unlock()
+			}
+		},
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, eventInformer.HasSynced) {
+		return fmt.Errorf("failed to sync event informer cache")
+	}
+
+	mu.Lock()
+	var existing []corev1.Event
+	for _, obj := range eventInformer.GetIndexer().List() {
+		if event, ok := obj.(*corev1.Event); ok {
+			existing = append(existing, *event)
+		}
+	}
+	sortEvents(existing)
+
+	printed = make(map[types.UID]string, len(existing))
+	utils.PrintEventHeader()
+	for _, event := range existing {
+		printed[event.UID] = event.ResourceVersion
+		utils.PrintEventLine(event)
+	}
+	mu.Unlock()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	<-signalChan
+
+	return nil
+}
+
+// sortEvents orders events by last occurrence, oldest first like kubectl
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return utils.EventTimestamp(events[i]).Before(utils.EventTimestamp(events[j]))
+	})
+}
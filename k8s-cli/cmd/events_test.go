@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortEvents(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "newest"}, LastTimestamp: metav1.NewTime(base.Add(3 * time.Minute))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "event-time-only"}, EventTime: metav1.NewMicroTime(base.Add(2 * time.Minute))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "oldest"}, LastTimestamp: metav1.NewTime(base)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "first-timestamp-only"}, FirstTimestamp: metav1.NewTime(base.Add(time.Minute))},
+	}
+
+	sortEvents(events)
+
+	want := []string{"oldest", "first-timestamp-only", "event-time-only", "newest"}
+	for i, name := range want {
+		if events[i].Name != name {
+			t.Errorf("events[%d] = %s, want %s", i, events[i].Name, name)
+		}
+	}
+}
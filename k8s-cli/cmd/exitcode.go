@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s-cli/internal/k8s"
+)
+
+// Exit codes by failure class, so scripts and CI can tell an unreachable
+// cluster from a missing resource. Anything unclassified exits with 1.
+const (
+	ExitError            = 1
+	ExitUsage            = 2
+	ExitNotFound         = 3
+	ExitConnection       = 4
+	ExitPermissionDenied = 5
+)
+
+// exitCodeHelp is the code table shown in the root command's help
+const exitCodeHelp = `Коды выхода:
+  0  успех
+  1  прочие ошибки, в том числе ответ "no" от can-i
+  2  неверное использование: флаги, аргументы, kubeconfig или YAML
+  3  ресурс или namespace не найден
+  4  нет связи с кластером
+  5  доступ запрещён (401/403)`
+
+// usageError is a failure caused by how the command was called: an unknown
+// flag or a missing argument found by cobra, or a flag value or argument
+// rejected by the command itself
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// usageErrorf is fmt.Errorf for invalid flag values and arguments, so that
+// they exit with ExitUsage like the errors cobra reports
+func usageErrorf(format string, args ...interface{}) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	var urlErr *url.Error
+	var usage usageError
+	// can-i answering "no" is not a failure to talk to the cluster; it keeps
+	// exiting with 1 like kubectl auth can-i
+	if errors.Is(err, errAccessDenied) {
+		return ExitError
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, k8s.ErrConnection), errors.As(err, &urlErr):
+		return ExitConnection
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ExitPermissionDenied
+	case apierrors.IsNotFound(err), errors.Is(err, k8s.ErrNotFound):
+		return ExitNotFound
+	case errors.As(err, &usage), errors.Is(err, k8s.ErrClientConfig), errors.Is(err, k8s.ErrDecodeYAML):
+		return ExitUsage
+	}
+	return ExitError
+}
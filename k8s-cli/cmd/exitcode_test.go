@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s-cli/internal/k8s"
+)
+
+func TestExitCode(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", want: 0},
+		{name: "other", err: errors.New("boom"), want: ExitError},
+		{name: "usage", err: usageError{err: errors.New(`unknown flag: --bogus`)}, want: ExitUsage},
+		{name: "invalid flag value", err: fmt.Errorf("error creating deployment: %w", usageErrorf("invalid --env %q: must be KEY=VALUE", "FOO")), want: ExitUsage},
+		{name: "client config", err: fmt.Errorf("error creating client: %w", &k8s.Error{Class: k8s.ErrClientConfig, Op: "error loading kubeconfig"}), want: ExitUsage},
+		{name: "bad manifest", err: fmt.Errorf("error reading file x.yaml: %w", &k8s.Error{Class: k8s.ErrDecodeYAML, Op: "error decoding YAML"}), want: ExitUsage},
+		{name: "api not found", err: fmt.Errorf("error deleting pod: %w", apierrors.NewNotFound(pods, "web")), want: ExitNotFound},
+		{name: "namespace not found", err: &k8s.Error{Class: k8s.ErrNotFound, Op: "get namespace", Err: errors.New("namespace 'x' not found")}, want: ExitNotFound},
+		{name: "not found on create", err: &k8s.Error{Class: k8s.ErrResourceCreate, Op: "error creating resource", Err: apierrors.NewNotFound(pods, "web")}, want: ExitNotFound},
+		{name: "connection", err: &k8s.Error{Class: k8s.ErrConnection, Op: "unable to connect to cluster"}, want: ExitConnection},
+		{name: "transport", err: fmt.Errorf("error listing pods: %w", &url.Error{Op: "Get", URL: "https://10.0.0.1:6443", Err: errors.New("connection refused")}), want: ExitConnection},
+		{name: "forbidden", err: fmt.Errorf("error listing pods: %w", apierrors.NewForbidden(pods, "", errors.New("rbac"))), want: ExitPermissionDenied},
+		{name: "unauthorized", err: apierrors.NewUnauthorized("token expired"), want: ExitPermissionDenied},
+		{name: "can-i denied", err: errAccessDenied, want: ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteReportsUsageErrors(t *testing.T) {
+	defer func() {
+		rootCmd.SetArgs(nil)
+		commandStarted = false
+		// flag values and their Changed marks outlive Execute
+		for _, flag := range []*pflag.Flag{
+			deletePodCmd.Flags().Lookup("now"),
+			deletePodCmd.Flags().Lookup("grace-period"),
+		} {
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		}
+	}()
+
+	for _, args := range [][]string{
+		{"delete", "pod"},                                      // missing the pod name
+		{"delete", "pods"},                                     // neither -l nor --all, rejected by the command itself
+		{"frontendpage", "create", "foo"},                      // missing the required --title and --path
+		{"delete", "pod", "x", "--now", "--grace-period", "3"}, // mutually exclusive flags
+	} {
+		commandStarted = false
+		rootCmd.SetArgs(args)
+		if got := ExitCode(Execute()); got != ExitUsage {
+			t.Errorf("ExitCode() for %v = %d, want %d", args, got, ExitUsage)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8scliv1 "k8s-cli/api/v1"
+	"k8s-cli/internal/utils"
+)
+
+// newFrontendPageClient builds the typed client the frontendpage commands use;
+// tests swap it for a fake client
+var newFrontendPageClient = func() (client.Client, error) {
+	config, err := getRESTConfig(false)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create frontendpage client: %v", err)
+	}
+	return c, nil
+}
+
+// frontendPageWaitInterval is how often waitForFrontendPageReady polls; tests shorten it
+var frontendPageWaitInterval = 2 * time.Second
+
+// waitForFrontendPageReady polls the FrontendPage until the controller reports
+// it Ready and returns it, so callers can hand back Status.URL. A Failed phase
+// doesn't end the wait because the controller keeps retrying; only ctx does,
+// and the error then carries the last phase and message seen.
+func waitForFrontendPageReady(ctx context.Context, reader client.Reader, key client.ObjectKey) (*k8scliv1.FrontendPage, error) {
+	page := &k8scliv1.FrontendPage{}
+	err := wait.PollUntilContextCancel(ctx, frontendPageWaitInterval, true, func(ctx context.Context) (bool, error) {
+		if err := reader.Get(ctx, key, page); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("FrontendPage %s was deleted while waiting for it", key)
+			}
+			// Transient API errors are retried until ctx expires
+			return false, nil
+		}
+		return page.Status.Ready, nil
+	})
+	if err == nil {
+		return page, nil
+	}
+	if ctx.Err() == nil {
+		return nil, err
+	}
+
+	phase := page.Status.Phase
+	if phase == "" {
+		phase = "not reconciled yet"
+	}
+	if page.Status.Message != "" {
+		return page, fmt.Errorf("FrontendPage %s is not ready (%s: %s): %w", key, phase, page.Status.Message, ctx.Err())
+	}
+	return page, fmt.Errorf("FrontendPage %s is not ready (%s): %w", key, phase, ctx.Err())
+}
+
+// frontendPageCmd groups the FrontendPage CRUD commands
+var frontendPageCmd = &cobra.Command{
+	Use:     "frontendpage",
+	Aliases: []string{"frontendpages", "fp"},
+	Short:   "Manage FrontendPage resources",
+	Long: `Create, list and delete FrontendPage custom resources with the typed client,
+without writing YAML or going through the platform API. The FrontendPage CRD
+must be installed; the crd controller turns each page into a Deployment and Service.`,
+}
+
+// frontendPageCreateCmd creates a FrontendPage
+var frontendPageCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a FrontendPage",
+	Long:  "Create a FrontendPage in the namespace given with -n",
+	Args:  cobra.ExactArgs(1),
+	Example: `  # Static page
+  k8s-cli frontendpage create docs --title "Docs" --path /docs
+
+  # Single-page app with three replicas
+  k8s-cli fp create shop --title "Shop" --path /shop --template spa --replicas 3
+
+  # Block until the controller reports the page Ready and print its URL
+  k8s-cli fp create docs --title "Docs" --path /docs --wait --timeout 5m
+
+  # Reverse proxy to a backend service
+  k8s-cli fp create api --title "API" --path /api --template proxy \
+    --config upstream=http://backend.default.svc:8080`,
+	RunE: runFrontendPageCreate,
+}
+
+// frontendPageListCmd lists FrontendPages
+var frontendPageListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List FrontendPages",
+	Long:    "List FrontendPages with their title, path, phase, readiness and URL",
+	Example: `  # FrontendPages in the current namespace
+  k8s-cli frontendpage list
+
+  # In every namespace, selected by label
+  k8s-cli fp list -A -l tier=frontend
+
+  # Names only, for xargs
+  k8s-cli fp list -o name`,
+	RunE: runFrontendPageList,
+}
+
+// frontendPageDeleteCmd deletes a FrontendPage
+var frontendPageDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a FrontendPage",
+	Long:  "Delete a FrontendPage; its Deployment and Service are garbage collected through owner references",
+	Args:  cobra.ExactArgs(1),
+	Example: `  # Delete a FrontendPage
+  k8s-cli frontendpage delete docs
+
+  # Without confirmation
+  k8s-cli fp delete docs -n web --force`,
+	RunE: runFrontendPageDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(frontendPageCmd)
+	frontendPageCmd.AddCommand(frontendPageCreateCmd)
+	frontendPageCmd.AddCommand(frontendPageListCmd)
+	frontendPageCmd.AddCommand(frontendPageDeleteCmd)
+
+	frontendPageCreateCmd.Flags().String("title", "", "page title (required)")
+	frontendPageCreateCmd.Flags().String("description", "", "page description")
+	frontendPageCreateCmd.Flags().String("path", "", "URL path, e.g. /docs (required)")
+	frontendPageCreateCmd.Flags().String("template", "", "template: static, spa or proxy (default static)")
+	frontendPageCreateCmd.Flags().String("image", "", "container image (default: the template's image)")
+	frontendPageCreateCmd.Flags().Int32("replicas", 1, "number of replicas")
+	frontendPageCreateCmd.Flags().StringToString("config", nil, "config entries as key=value, e.g. upstream=http://backend:8080")
+	frontendPageCreateCmd.Flags().Bool("wait", false, "wait until the FrontendPage is Ready and print its URL")
+	frontendPageCreateCmd.Flags().Duration("timeout", 2*time.Minute, "how long --wait waits before giving up")
+	frontendPageCreateCmd.MarkFlagRequired("title")
+	frontendPageCreateCmd.MarkFlagRequired("path")
+
+	frontendPageListCmd.Flags().StringP("selector", "l", "", "селектор меток")
+	frontendPageListCmd.Flags().BoolP("all-namespaces", "A", false, "list FrontendPages in every namespace")
+
+	frontendPageDeleteCmd.Flags().Bool("force", false, "Force delete without confirmation")
+}
+
+func runFrontendPageCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := viper.GetString("namespace")
+
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	path, _ := cmd.Flags().GetString("path")
+	template, _ := cmd.Flags().GetString("template")
+	image, _ := cmd.Flags().GetString("image")
+	replicas, _ := cmd.Flags().GetInt32("replicas")
+	config, _ := cmd.Flags().GetStringToString("config")
+	waitReady, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	if replicas < 0 {
+		return usageErrorf("--replicas must not be negative")
+	}
+	if waitReady && timeout <= 0 {
+		return usageErrorf("--timeout must be positive")
+	}
+
+	c, err := newFrontendPageClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: k8scliv1.FrontendPageSpec{
+			Title:       title,
+			Description: description,
+			Path:        path,
+			Template:    template,
+			Image:       image,
+			Replicas:    replicas,
+			Config:      config,
+		},
+	}
+
+	err = c.Create(context.TODO(), page)
+	reportResult(utils.ActionResult{Action: "create", Kind: "FrontendPage", Name: name, Namespace: namespace, Result: "created"}, err)
+	if err != nil {
+		return fmt.Errorf("error creating frontendpage: %w", err)
+	}
+
+	infof("✅ FrontendPage '%s' successfully created in namespace '%s'\n", name, namespace)
+	printCreatedName("frontendpage", k8scliv1.GroupVersion.Group, name)
+	if !waitReady {
+		return nil
+	}
+
+	infof("⏳ Waiting up to %s for FrontendPage '%s' to become ready...\n", timeout, name)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ready, err := waitForFrontendPageReady(ctx, c, client.ObjectKeyFromObject(page))
+	result := utils.ActionResult{Action: "wait", Kind: "FrontendPage", Name: name, Namespace: namespace, Result: "ready"}
+	if err == nil {
+		result.URL = ready.Status.URL
+	}
+	reportResult(result, err)
+	if err != nil {
+		return err
+	}
+
+	infof("🌐 FrontendPage '%s' is ready at %s\n", name, ready.Status.URL)
+	return nil
+}
+
+func runFrontendPageList(cmd *cobra.Command, args []string) error {
+	selector, _ := cmd.Flags().GetString("selector")
+	allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+
+	c, err := newFrontendPageClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	var opts []client.ListOption
+	namespace := viper.GetString("namespace")
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return usageErrorf("invalid selector %q: %w", selector, err)
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: parsed})
+	}
+
+	var pages k8scliv1.FrontendPageList
+	if err := c.List(context.TODO(), &pages, opts...); err != nil {
+		return fmt.Errorf("error listing frontendpages: %w", err)
+	}
+
+	if allNamespaces {
+		infof("FrontendPages in all namespaces:\n")
+	} else {
+		infof("FrontendPages in namespace '%s':\n", namespace)
+	}
+	hideManagedFields(pages.Items)
+	return utils.PrintFrontendPages(os.Stdout, pages.Items, listOutputFormat())
+}
+
+func runFrontendPageDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+	namespace := viper.GetString("namespace")
+
+	c, err := newFrontendPageClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	// Confirm deletion unless force flag is used
+	if !force {
+		fmt.Printf("Are you sure you want to delete frontendpage/%s in namespace %s? (y/N): ", name, namespace)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "delete", Kind: "FrontendPage", Name: name, Namespace: namespace, Result: "cancelled"}, nil)
+			return nil
+		}
+	}
+
+	page := &k8scliv1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	err = c.Delete(context.TODO(), page)
+	reportResult(utils.ActionResult{Action: "delete", Kind: "FrontendPage", Name: name, Namespace: namespace, Result: "deleted"}, err)
+	if err != nil {
+		return fmt.Errorf("error deleting frontendpage: %w", err)
+	}
+
+	infof("✅ FrontendPage '%s' successfully deleted from namespace '%s'\n", name, namespace)
+	return nil
+}
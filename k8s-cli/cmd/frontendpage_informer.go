@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+var (
+	// Watch FrontendPage custom resources next to deployments
+	watchFrontendPages bool
+)
+
+// FrontendPage is a CRD without a typed clientset, so it is watched through the dynamic client
+var frontendPageGVR = k8scliv1.GroupVersion.WithResource("frontendpages")
+
+type FrontendPageSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Title     string `json:"title,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Image     string `json:"image,omitempty"`
+	Replicas  int64  `json:"replicas"`
+	Phase     string `json:"phase,omitempty"`
+	Ready     bool   `json:"ready"`
+}
+
+// SetDynamicClient provides the client used for the FrontendPage informer
+func (e *EventProcessor) SetDynamicClient(client dynamic.Interface) {
+	e.dynamicClient = client
+}
+
+// frontendPageCRDInstalled reports whether the API server serves the frontendpages resource
+func frontendPageCRDInstalled(client discovery.DiscoveryInterface) (bool, error) {
+	resources, err := client.ServerResourcesForGroupVersion(k8scliv1.GroupVersion.String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == frontendPageGVR.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// startFrontendPageInformer watches FrontendPages with a dynamic informer. A
+// missing CRD is not an error: the deployment informer keeps running without it.
+func (e *EventProcessor) startFrontendPageInformer(ctx context.Context) error {
+	installed, err := frontendPageCRDInstalled(e.clientset.Discovery())
+	if err != nil {
+		log.Printf("⚠️ Could not discover %s, skipping FrontendPage informer: %v", k8scliv1.GroupVersion, err)
+		return nil
+	}
+	if !installed {
+		log.Printf("⚠️ FrontendPage CRD (%s) is not installed, skipping FrontendPage informer", frontendPageGVR.GroupResource())
+		return nil
+	}
+
+	log.Println("🚀 Starting FrontendPage informer...")
+
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(e.dynamicClient, e.config.ResyncPeriod)
+	frontendPageInformer := informerFactory.ForResource(frontendPageGVR).Informer()
+
+	frontendPageInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if page, ok := obj.(*unstructured.Unstructured); ok && e.config.LogEvents {
+				log.Printf("✅ ADD: FrontendPage %s/%s created", page.GetNamespace(), page.GetName())
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPage, ok := oldObj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			newPage, ok := newObj.(*unstructured.Unstructured)
+			if !ok || oldPage.GetResourceVersion() == newPage.GetResourceVersion() {
+				return
+			}
+			if e.config.LogEvents {
+				phase, _, _ := unstructured.NestedString(newPage.Object, "status", "phase")
+				log.Printf("🔄 UPDATE: FrontendPage %s/%s modified (phase: %s)", newPage.GetNamespace(), newPage.GetName(), phase)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if page, ok := obj.(*unstructured.Unstructured); ok && e.config.LogEvents {
+				log.Printf("🗑️ DELETE: FrontendPage %s/%s removed", page.GetNamespace(), page.GetName())
+			}
+		},
+	})
+
+	informerFactory.Start(e.informerStop)
+
+	log.Println("⏳ Waiting for FrontendPage cache to sync...")
+	if !cache.WaitForCacheSync(ctx.Done(), frontendPageInformer.HasSynced) {
+		return fmt.Errorf("failed to sync FrontendPage informer cache")
+	}
+	e.frontendPageIndexer = frontendPageInformer.GetIndexer()
+	log.Printf("✅ FrontendPage cache synced (%d objects)", len(e.frontendPageIndexer.ListKeys()))
+
+	return nil
+}
+
+func createFrontendPageSummary(page *unstructured.Unstructured) FrontendPageSummary {
+	title, _, _ := unstructured.NestedString(page.Object, "spec", "title")
+	path, _, _ := unstructured.NestedString(page.Object, "spec", "path")
+	image, _, _ := unstructured.NestedString(page.Object, "spec", "image")
+	replicas, _, _ := unstructured.NestedInt64(page.Object, "spec", "replicas")
+	phase, _, _ := unstructured.NestedString(page.Object, "status", "phase")
+	ready, _, _ := unstructured.NestedBool(page.Object, "status", "ready")
+
+	return FrontendPageSummary{
+		Name:      page.GetName(),
+		Namespace: page.GetNamespace(),
+		Title:     title,
+		Path:      path,
+		Image:     image,
+		Replicas:  replicas,
+		Phase:     phase,
+		Ready:     ready,
+	}
+}
+
+func (e *EventProcessor) handleFrontendPagesAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, r, ErrCodeMethodNotAllowed, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if e.frontendPageIndexer == nil {
+		writeErrorResponse(w, r, ErrCodeUnavailable, "FrontendPage cache is not available; start with --watch-frontendpages and install the CRD", http.StatusServiceUnavailable)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+
+	summaries := []FrontendPageSummary{}
+	for _, obj := range e.frontendPageIndexer.List() {
+		page, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if namespace != "" && page.GetNamespace() != namespace {
+			continue
+		}
+		summaries = append(summaries, createFrontendPageSummary(page))
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	writeJSONResponse(w, r, APIResponse{
+		Status: "success",
+		Data:   summaries,
+		Count:  len(summaries),
+	})
+}
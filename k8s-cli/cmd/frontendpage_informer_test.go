@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newFrontendPage(namespace, name, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "k8scli.dev/v1",
+		"kind":       "FrontendPage",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name},
+		"spec": map[string]interface{}{
+			"title":    "Landing",
+			"path":     "/",
+			"image":    "nginx:1.25",
+			"replicas": int64(2),
+		},
+		"status": map[string]interface{}{"phase": phase, "ready": phase == "Ready"},
+	}}
+}
+
+func newFrontendPageProcessor(crdInstalled bool, objects ...runtime.Object) *EventProcessor {
+	clientset := fake.NewSimpleClientset()
+	if crdInstalled {
+		clientset.Resources = []*metav1.APIResourceList{{
+			GroupVersion: "k8scli.dev/v1",
+			APIResources: []metav1.APIResource{{Name: "frontendpages", Namespaced: true, Kind: "FrontendPage"}},
+		}}
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{frontendPageGVR: "FrontendPageList"}, objects...)
+
+	e := NewEventProcessor(clientset, &InformerConfig{WatchFrontendPages: true})
+	e.SetDynamicClient(dynamicClient)
+	return e
+}
+
+func TestStartFrontendPageInformer(t *testing.T) {
+	t.Run("CRD not installed", func(t *testing.T) {
+		e := newFrontendPageProcessor(false)
+		defer close(e.informerStop)
+
+		if err := e.startFrontendPageInformer(context.Background()); err != nil {
+			t.Fatalf("startFrontendPageInformer() error = %v, want nil", err)
+		}
+		if e.frontendPageIndexer != nil {
+			t.Error("FrontendPage indexer set although the CRD is not installed")
+		}
+	})
+
+	t.Run("CRD installed", func(t *testing.T) {
+		e := newFrontendPageProcessor(true,
+			newFrontendPage("team-b", "docs", "Pending"),
+			newFrontendPage("team-a", "home", "Ready"))
+		defer close(e.informerStop)
+
+		if err := e.startFrontendPageInformer(context.Background()); err != nil {
+			t.Fatalf("startFrontendPageInformer() error = %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		e.handleFrontendPagesAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v1/frontendpages", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+
+		var resp struct {
+			Data  []FrontendPageSummary `json:"data"`
+			Count int                   `json:"count"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if resp.Count != 2 || resp.Data[0].Name != "home" || resp.Data[1].Name != "docs" {
+			t.Fatalf("frontendpages = %+v, want home then docs", resp.Data)
+		}
+		if got := resp.Data[0]; got.Replicas != 2 || got.Phase != "Ready" || !got.Ready || got.Image != "nginx:1.25" {
+			t.Errorf("summary = %+v", got)
+		}
+	})
+}
+
+func TestFrontendPagesAPIDisabled(t *testing.T) {
+	e := NewEventProcessor(fake.NewSimpleClientset(), &InformerConfig{})
+
+	rec := httptest.NewRecorder()
+	e.handleFrontendPagesAPI(rec, httptest.NewRequest(http.MethodGet, "/api/v1/frontendpages", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+	var response APIResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if response.Code != ErrCodeUnavailable {
+		t.Errorf("code = %q, want %q", response.Code, ErrCodeUnavailable)
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	k8scliv1 "k8s-cli/api/v1"
+	"k8s-cli/controllers"
+)
+
+// frontendPageReconcileCmd previews what the controller would do with a FrontendPage
+var frontendPageReconcileCmd = &cobra.Command{
+	Use:   "reconcile <name>",
+	Short: "Preview the Deployment and Service a FrontendPage reconciles to",
+	Long: `Run the controller's reconcile logic for a FrontendPage without writing anything:
+print the Deployment and Service it would create or update, then the difference
+from what is live. The change is sent to the API server as a server-side dry run,
+so defaulting and admission apply and the diff shows only real changes.
+
+Only --dry-run is supported; the crd controller applies the changes.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # What would the controller change for shop?
+  k8s-cli frontendpage reconcile shop --dry-run
+
+  # After editing the page, before the controller picks it up
+  k8s-cli fp reconcile docs -n web --dry-run`,
+	RunE: runFrontendPageReconcile,
+}
+
+func init() {
+	frontendPageCmd.AddCommand(frontendPageReconcileCmd)
+
+	frontendPageReconcileCmd.Flags().Bool("dry-run", false, "print the computed objects and their diff against live without writing (required)")
+}
+
+func runFrontendPageReconcile(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		return fmt.Errorf("reconcile only runs with --dry-run; the crd controller applies changes")
+	}
+
+	c, err := newFrontendPageClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	page := &k8scliv1.FrontendPage{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: viper.GetString("namespace"), Name: args[0]}, page); err != nil {
+		return fmt.Errorf("error getting FrontendPage: %w", err)
+	}
+
+	return dryRunReconcileFrontendPage(ctx, c, page, os.Stdout)
+}
+
+// dryRunReconcileFrontendPage writes the Deployment and Service the controller
+// builds for page, each followed by the diff a server-side dry run of the
+// create or update reports against the live object
+func dryRunReconcileFrontendPage(ctx context.Context, c client.Client, page *k8scliv1.FrontendPage, w io.Writer) error {
+	deployment, err := controllers.DesiredDeployment(page, c.Scheme())
+	if err != nil {
+		return err
+	}
+	service, err := controllers.DesiredService(page, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	live := &appsv1.Deployment{}
+	if err := dryRunReconcileObject(ctx, c, deployment, live, func() error {
+		return controllers.MutateDeployment(live, deployment, page, c.Scheme())
+	}, w); err != nil {
+		return err
+	}
+
+	liveService := &corev1.Service{}
+	return dryRunReconcileObject(ctx, c, service, liveService, func() error {
+		return controllers.MutateService(liveService, service, page, c.Scheme())
+	}, w)
+}
+
+// dryRunReconcileObject prints desired as YAML, then dry-runs creating it or,
+// when it exists, applying mutate to the live copy read into live
+func dryRunReconcileObject(ctx context.Context, c client.Client, desired, live client.Object, mutate func() error, w io.Writer) error {
+	gvk, err := apiutil.GVKForObject(desired, c.Scheme())
+	if err != nil {
+		return err
+	}
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	manifest, err := yaml.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "---\n%s", manifest)
+
+	ref := fmt.Sprintf("%s %s/%s", gvk.Kind, desired.GetNamespace(), desired.GetName())
+	err = c.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired.DeepCopyObject().(client.Object), client.DryRunAll); err != nil {
+			return fmt.Errorf("dry-run create of %s failed: %w", ref, err)
+		}
+		fmt.Fprintf(w, "# %s would be created\n", ref)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting %s: %w", ref, err)
+	}
+
+	before := live.DeepCopyObject()
+	if err := mutate(); err != nil {
+		return err
+	}
+	if err := c.Update(ctx, live, client.DryRunAll); err != nil {
+		return fmt.Errorf("dry-run update of %s failed: %w", ref, err)
+	}
+
+	diff, err := reconcileDiff(before, live)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		fmt.Fprintf(w, "# %s is up to date\n", ref)
+		return nil
+	}
+	fmt.Fprintf(w, "# %s would be updated (-live +reconciled):\n%s", ref, diff)
+	return nil
+}
+
+// reconcileDiff compares two versions of an object, leaving out the status
+// and the metadata the API server bumps on every write
+func reconcileDiff(before, after runtime.Object) (string, error) {
+	var objects [2]map[string]interface{}
+	for i, obj := range []runtime.Object{before, after} {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return "", err
+		}
+		delete(content, "status")
+		for _, field := range []string{"resourceVersion", "generation", "managedFields"} {
+			unstructured.RemoveNestedField(content, "metadata", field)
+		}
+		objects[i] = content
+	}
+	return cmp.Diff(objects[0], objects[1]), nil
+}
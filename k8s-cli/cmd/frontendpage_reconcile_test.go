@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8scliv1 "k8s-cli/api/v1"
+	"k8s-cli/controllers"
+)
+
+func TestDryRunReconcileFrontendPage(t *testing.T) {
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web", UID: "page-uid"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Path: "/shop", Template: k8scliv1.TemplateSPA, Replicas: 3},
+	}
+	upToDate, err := controllers.DesiredDeployment(page, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := upToDate.DeepCopy()
+	stale.Spec.Template.Spec.Containers[0].Image = "nginx:1.19"
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       []string
+	}{
+		{
+			name: "nothing live",
+			want: []string{"# Deployment web/shop-deployment would be created", "# Service web/shop-service would be created"},
+		},
+		{
+			name:       "stale deployment",
+			deployment: stale,
+			want:       []string{"# Deployment web/shop-deployment would be updated", `-`, `"nginx:1.19"`, `+`, `"nginx:1.25-alpine"`},
+		},
+		{
+			name:       "deployment up to date",
+			deployment: upToDate,
+			want:       []string{"# Deployment web/shop-deployment is up to date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page.DeepCopy())
+			if tt.deployment != nil {
+				builder = builder.WithObjects(tt.deployment.DeepCopy())
+			}
+			c := builder.Build()
+
+			var out bytes.Buffer
+			if err := dryRunReconcileFrontendPage(context.Background(), c, page.DeepCopy(), &out); err != nil {
+				t.Fatalf("dryRunReconcileFrontendPage() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			if !strings.Contains(out.String(), "kind: Deployment") || !strings.Contains(out.String(), "kind: Service") {
+				t.Errorf("output should include both manifests:\n%s", out.String())
+			}
+
+			// Nothing is written
+			var deployment appsv1.Deployment
+			err := c.Get(context.Background(), client.ObjectKey{Namespace: "web", Name: "shop-deployment"}, &deployment)
+			switch {
+			case tt.deployment == nil && !apierrors.IsNotFound(err):
+				t.Errorf("Get(deployment) error = %v, want NotFound", err)
+			case tt.deployment != nil && deployment.Spec.Template.Spec.Containers[0].Image != tt.deployment.Spec.Template.Spec.Containers[0].Image:
+				t.Errorf("live image = %q, want it untouched", deployment.Spec.Template.Spec.Containers[0].Image)
+			}
+			if err := c.Get(context.Background(), client.ObjectKey{Namespace: "web", Name: "shop-service"}, &corev1.Service{}); !apierrors.IsNotFound(err) {
+				t.Errorf("Get(service) error = %v, want NotFound", err)
+			}
+		})
+	}
+}
+
+func TestFrontendPageReconcileRequiresDryRun(t *testing.T) {
+	if err := runFrontendPageReconcile(frontendPageReconcileCmd, []string{"shop"}); err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Errorf("runFrontendPageReconcile() error = %v, want one asking for --dry-run", err)
+	}
+}
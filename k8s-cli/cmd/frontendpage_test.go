@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+func TestFrontendPageCommands(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+	}).Build()
+	defer func(f func() (client.Client, error)) { newFrontendPageClient = f }(newFrontendPageClient)
+	newFrontendPageClient = func() (client.Client, error) { return c, nil }
+
+	defer func(ns string) { viper.Set("namespace", ns) }(viper.GetString("namespace"))
+	viper.Set("namespace", "web")
+	viper.Set("quiet", true)
+	defer viper.Set("quiet", false)
+
+	flags := frontendPageCreateCmd.Flags()
+	for name, value := range map[string]string{
+		"title":    "Shop",
+		"path":     "/shop",
+		"template": k8scliv1.TemplateProxy,
+		"replicas": "3",
+		"config":   "upstream=http://backend:8080",
+	} {
+		if err := flags.Set(name, value); err != nil {
+			t.Fatalf("Set(%s) error = %v", name, err)
+		}
+		defer flags.Lookup(name).Value.Set(flags.Lookup(name).DefValue)
+	}
+
+	if err := runFrontendPageCreate(frontendPageCreateCmd, []string{"shop"}); err != nil {
+		t.Fatalf("create error = %v", err)
+	}
+
+	var page k8scliv1.FrontendPage
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "web", Name: "shop"}, &page); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	spec := page.Spec
+	if spec.Title != "Shop" || spec.Path != "/shop" || spec.Template != k8scliv1.TemplateProxy || spec.Replicas != 3 || spec.Config["upstream"] != "http://backend:8080" {
+		t.Errorf("spec = %+v", spec)
+	}
+
+	if err := runFrontendPageCreate(frontendPageCreateCmd, []string{"shop"}); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("second create error = %v, want AlreadyExists", err)
+	}
+
+	if err := frontendPageDeleteCmd.Flags().Set("force", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer frontendPageDeleteCmd.Flags().Set("force", "false")
+	if err := runFrontendPageDelete(frontendPageDeleteCmd, []string{"shop"}); err != nil {
+		t.Fatalf("delete error = %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "web", Name: "shop"}, &page); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() after delete error = %v, want NotFound", err)
+	}
+	if err := runFrontendPageDelete(frontendPageDeleteCmd, []string{"shop"}); err == nil {
+		t.Error("deleting a missing FrontendPage should fail")
+	}
+}
+
+// readyAfterGets fakes the controller: the FrontendPage turns Ready on the nth Get
+func readyAfterGets(n int, objects ...client.Object) client.Client {
+	gets := 0
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := c.Get(ctx, key, obj, opts...); err != nil {
+				return err
+			}
+			page, ok := obj.(*k8scliv1.FrontendPage)
+			if !ok {
+				return nil
+			}
+			gets++
+			if n > 0 && gets >= n {
+				page.Status = k8scliv1.FrontendPageStatus{Phase: "Running", Ready: true, URL: "http://" + key.Name + ".svc/"}
+			} else {
+				page.Status = k8scliv1.FrontendPageStatus{Phase: "Pending", Message: "Deployment " + key.Name + " is not ready yet"}
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func TestWaitForFrontendPageReady(t *testing.T) {
+	defer func(d time.Duration) { frontendPageWaitInterval = d }(frontendPageWaitInterval)
+	frontendPageWaitInterval = time.Millisecond
+
+	key := client.ObjectKey{Namespace: "web", Name: "shop"}
+	page := &k8scliv1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"}}
+
+	ready, err := waitForFrontendPageReady(context.Background(), readyAfterGets(3, page.DeepCopy()), key)
+	if err != nil {
+		t.Fatalf("wait error = %v", err)
+	}
+	if ready.Status.URL != "http://shop.svc/" {
+		t.Errorf("URL = %q", ready.Status.URL)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = waitForFrontendPageReady(ctx, readyAfterGets(0, page.DeepCopy()), key)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Pending: Deployment shop is not ready yet") {
+		t.Errorf("timeout error = %v, want the last phase and message", err)
+	}
+
+	_, err = waitForFrontendPageReady(context.Background(), readyAfterGets(0), key)
+	if err == nil || !strings.Contains(err.Error(), "deleted") {
+		t.Errorf("missing page error = %v, want deleted", err)
+	}
+}
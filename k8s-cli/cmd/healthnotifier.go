@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deploymentHealthState is what the notifier remembers about an unhealthy deployment
+type deploymentHealthState struct {
+	since   time.Time
+	alerted bool
+}
+
+// DeploymentHealthNotifier sends a Discord alert once a deployment has been
+// unhealthy for longer than threshold across reconciles, and a recovery
+// message when an alerted deployment becomes healthy again.
+type DeploymentHealthNotifier struct {
+	discord   *DiscordClient
+	threshold time.Duration
+	now       func() time.Time
+
+	mu             sync.Mutex
+	unhealthySince map[types.NamespacedName]*deploymentHealthState
+}
+
+func NewDeploymentHealthNotifier(discord *DiscordClient, threshold time.Duration) *DeploymentHealthNotifier {
+	return &DeploymentHealthNotifier{
+		discord:        discord,
+		threshold:      threshold,
+		now:            time.Now,
+		unhealthySince: make(map[types.NamespacedName]*deploymentHealthState),
+	}
+}
+
+// Observe records the deployment's health and sends any alert or recovery
+// message that became due. It returns how long until an unhealthy deployment
+// crosses the threshold, or zero when nothing is pending.
+func (n *DeploymentHealthNotifier) Observe(key types.NamespacedName, healthy bool, ready, desired int32) time.Duration {
+	alert, recovered, pending := n.transition(key, healthy)
+
+	if alert {
+		n.send(DiscordMessage{
+			Content: "🚨 k8s-cli Deployment unhealthy",
+			Embeds: []DiscordEmbed{{
+				Title:       fmt.Sprintf("Deployment %s is unhealthy", key),
+				Description: fmt.Sprintf("Not fully ready for more than %v", n.threshold),
+				Color:       0xFF0000,
+				Timestamp:   n.now().Format(time.RFC3339),
+				Fields: []DiscordEmbedField{
+					{Name: "Ready Replicas", Value: fmt.Sprintf("%d/%d", ready, desired), Inline: true},
+				},
+			}},
+		})
+	}
+	if recovered {
+		n.send(DiscordMessage{
+			Content: "✅ k8s-cli Deployment recovered",
+			Embeds: []DiscordEmbed{{
+				Title:       fmt.Sprintf("Deployment %s is healthy again", key),
+				Description: fmt.Sprintf("All %d replicas are ready", desired),
+				Color:       0x00FF00,
+				Timestamp:   n.now().Format(time.RFC3339),
+			}},
+		})
+	}
+
+	return pending
+}
+
+// Forget drops the state of a deleted deployment
+func (n *DeploymentHealthNotifier) Forget(key types.NamespacedName) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.unhealthySince, key)
+}
+
+func (n *DeploymentHealthNotifier) transition(key types.NamespacedName, healthy bool) (alert, recovered bool, pending time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	state, tracked := n.unhealthySince[key]
+	if healthy {
+		if tracked {
+			delete(n.unhealthySince, key)
+			recovered = state.alerted
+		}
+		return false, recovered, 0
+	}
+
+	now := n.now()
+	if !tracked {
+		state = &deploymentHealthState{since: now}
+		n.unhealthySince[key] = state
+	}
+	if state.alerted {
+		return false, false, 0
+	}
+
+	elapsed := now.Sub(state.since)
+	if elapsed < n.threshold {
+		return false, false, n.threshold - elapsed
+	}
+	state.alerted = true
+	return true, false, 0
+}
+
+func (n *DeploymentHealthNotifier) send(message DiscordMessage) {
+	if err := n.discord.SendMessage(message); err != nil {
+		log.Printf("❌ Failed to send Discord health notification: %v", err)
+		return
+	}
+	log.Printf("📱 Discord health notification sent: %s", message.Content)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestHealthNotifier(t *testing.T, threshold time.Duration) (*DeploymentHealthNotifier, *time.Time, func() []DiscordMessage) {
+	var mu sync.Mutex
+	var received []DiscordMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message DiscordMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("decoding webhook payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, message)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	n := NewDeploymentHealthNotifier(&DiscordClient{WebhookURL: server.URL, HTTPClient: server.Client()}, threshold)
+	n.now = func() time.Time { return now }
+
+	return n, &now, func() []DiscordMessage {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]DiscordMessage(nil), received...)
+	}
+}
+
+func TestDeploymentHealthNotifierAlertsAfterThreshold(t *testing.T) {
+	n, now, received := newTestHealthNotifier(t, 5*time.Minute)
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+
+	if pending := n.Observe(key, false, 1, 3); pending != 5*time.Minute {
+		t.Errorf("first unhealthy observation pending = %v, want 5m", pending)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if pending := n.Observe(key, false, 1, 3); pending != 3*time.Minute {
+		t.Errorf("pending = %v, want 3m", pending)
+	}
+	if got := len(received()); got != 0 {
+		t.Fatalf("sent %d messages before threshold, want 0", got)
+	}
+
+	*now = now.Add(3 * time.Minute)
+	n.Observe(key, false, 1, 3)
+	*now = now.Add(time.Minute)
+	n.Observe(key, false, 2, 3)
+	if got := len(received()); got != 1 {
+		t.Fatalf("sent %d messages after threshold, want exactly 1 alert", got)
+	}
+
+	n.Observe(key, true, 3, 3)
+	messages := received()
+	if len(messages) != 2 {
+		t.Fatalf("sent %d messages after recovery, want alert and recovery", len(messages))
+	}
+	if messages[1].Embeds[0].Color != 0x00FF00 {
+		t.Errorf("recovery message color = %#x, want green", messages[1].Embeds[0].Color)
+	}
+}
+
+func TestDeploymentHealthNotifierSkipsShortBlips(t *testing.T) {
+	n, now, received := newTestHealthNotifier(t, 5*time.Minute)
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+
+	n.Observe(key, false, 0, 1)
+	*now = now.Add(time.Minute)
+	n.Observe(key, true, 1, 1)
+
+	// A new unhealthy period starts the clock again
+	*now = now.Add(time.Minute)
+	if pending := n.Observe(key, false, 0, 1); pending != 5*time.Minute {
+		t.Errorf("pending = %v, want 5m after recovery reset", pending)
+	}
+
+	if got := len(received()); got != 0 {
+		t.Errorf("sent %d messages for an unhealthy period below threshold, want 0", got)
+	}
+}
+
+func TestDeploymentHealthNotifierForget(t *testing.T) {
+	n, now, received := newTestHealthNotifier(t, time.Minute)
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+
+	n.Observe(key, false, 0, 1)
+	*now = now.Add(2 * time.Minute)
+	n.Observe(key, false, 0, 1)
+	n.Forget(key)
+
+	// Deleted deployments get no recovery message
+	n.Observe(key, true, 1, 1)
+	if got := len(received()); got != 1 {
+		t.Errorf("sent %d messages, want only the alert", got)
+	}
+}
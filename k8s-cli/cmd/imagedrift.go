@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/homedir"
+)
+
+var (
+	// Opt-in image drift tracking for watch-informer
+	trackImageDrift bool
+	cacheFile       string
+)
+
+// WatchCacheFile is what watch-informer persists between runs in --cache-file
+type WatchCacheFile struct {
+	SavedAt time.Time         `json:"saved_at"`
+	Images  map[string]string `json:"images"`
+}
+
+// ImageDrift is a deployment whose image changed while the watcher was down
+type ImageDrift struct {
+	Key      string
+	OldImage string
+	NewImage string
+}
+
+func defaultCacheFile() string {
+	return filepath.Join(homedir.HomeDir(), ".k8s-cli", "watch-cache.json")
+}
+
+// deploymentImages joins the container images of a deployment, e.g. "nginx:1.25,envoy:v1.28"
+func deploymentImages(deployment *appsv1.Deployment) string {
+	images := make([]string, 0, len(deployment.Spec.Template.Spec.Containers))
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return strings.Join(images, ",")
+}
+
+func loadWatchCache(path string) (*WatchCacheFile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &WatchCacheFile{Images: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file %s: %w", path, err)
+	}
+
+	var cacheData WatchCacheFile
+	if err := json.Unmarshal(data, &cacheData); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file %s: %w", path, err)
+	}
+	if cacheData.Images == nil {
+		cacheData.Images = map[string]string{}
+	}
+	return &cacheData, nil
+}
+
+// saveWatchCache writes through a temp file so a crash never leaves a truncated baseline
+func saveWatchCache(path string, cacheData *WatchCacheFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cacheData, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// detectImageDrift compares the persisted baseline with the images now in the cluster.
+// Deployments created or deleted in the meantime are not drift and are skipped.
+func detectImageDrift(baseline, current map[string]string) []ImageDrift {
+	var drift []ImageDrift
+	for key, newImage := range current {
+		oldImage, ok := baseline[key]
+		if ok && oldImage != newImage {
+			drift = append(drift, ImageDrift{Key: key, OldImage: oldImage, NewImage: newImage})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Key < drift[j].Key })
+	return drift
+}
+
+func printImageDriftReport(drift []ImageDrift, since time.Time) {
+	if since.IsZero() {
+		log.Println("📸 No image baseline found, recording current images")
+		return
+	}
+	if len(drift) == 0 {
+		log.Printf("📸 No image drift since %s", since.Format(time.RFC3339))
+		return
+	}
+
+	log.Printf("🚨 IMAGE DRIFT: %d deployment(s) changed image since %s:", len(drift), since.Format(time.RFC3339))
+	for _, d := range drift {
+		log.Printf("   %s: %s -> %s", d.Key, d.OldImage, d.NewImage)
+	}
+}
+
+// imageTracker keeps the image baseline in --cache-file current while watching
+type imageTracker struct {
+	mu     sync.Mutex
+	path   string
+	images map[string]string
+	ready  bool
+}
+
+func newImageTracker(path string) *imageTracker {
+	return &imageTracker{path: path, images: map[string]string{}}
+}
+
+// reset replaces the tracked images with the synced cache contents and saves them
+func (t *imageTracker) reset(images map[string]string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.images = images
+	t.ready = true
+	return t.saveLocked()
+}
+
+func (t *imageTracker) observe(deployment *appsv1.Deployment) {
+	key, err := cache.MetaNamespaceKeyFunc(deployment)
+	if err != nil {
+		return
+	}
+	image := deploymentImages(deployment)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.images[key] == image {
+		return
+	}
+	t.images[key] = image
+	t.persistLocked()
+}
+
+func (t *imageTracker) forget(deployment *appsv1.Deployment) {
+	key, err := cache.MetaNamespaceKeyFunc(deployment)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.images[key]; !ok {
+		return
+	}
+	delete(t.images, key)
+	t.persistLocked()
+}
+
+// persistLocked saves after each change once the startup report has been made;
+// before that the informer is still replaying the initial list.
+func (t *imageTracker) persistLocked() {
+	if !t.ready {
+		return
+	}
+	if err := t.saveLocked(); err != nil {
+		log.Printf("⚠️ Failed to save image baseline: %v", err)
+	}
+}
+
+func (t *imageTracker) saveLocked() error {
+	return saveWatchCache(t.path, &WatchCacheFile{SavedAt: time.Now(), Images: t.images})
+}
+
+// EnableImageDriftTracking records deployment images in path; call before Start
+func (e *EventProcessor) EnableImageDriftTracking(path string) {
+	e.imageTracker = newImageTracker(path)
+}
+
+// ReportImageDrift compares the synced deployment cache with the baseline from
+// the previous run, prints the report and starts keeping the baseline current.
+func (e *EventProcessor) ReportImageDrift() error {
+	tracker := e.imageTracker
+	if tracker == nil {
+		return nil
+	}
+
+	baseline, err := loadWatchCache(tracker.path)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]string)
+	for _, deployment := range e.informer.List() {
+		current[deployment.Namespace+"/"+deployment.Name] = deploymentImages(deployment)
+	}
+
+	printImageDriftReport(detectImageDrift(baseline.Images, current), baseline.SavedAt)
+	return tracker.reset(current)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newImageDeployment(name string, images ...string) *appsv1.Deployment {
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	for i, image := range images {
+		d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers,
+			corev1.Container{Name: string(rune('a' + i)), Image: image})
+	}
+	return d
+}
+
+func TestDetectImageDrift(t *testing.T) {
+	baseline := map[string]string{
+		"default/api":     "api:v1",
+		"default/web":     "nginx:1.24",
+		"default/removed": "old:v1",
+	}
+	current := map[string]string{
+		"default/api": "api:v2",
+		"default/web": "nginx:1.24",
+		"default/new": "new:v1",
+	}
+
+	want := []ImageDrift{{Key: "default/api", OldImage: "api:v1", NewImage: "api:v2"}}
+	if got := detectImageDrift(baseline, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("detectImageDrift() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWatchCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "watch-cache.json")
+
+	missing, err := loadWatchCache(path)
+	if err != nil {
+		t.Fatalf("loadWatchCache() on missing file error = %v", err)
+	}
+	if !missing.SavedAt.IsZero() || len(missing.Images) != 0 {
+		t.Errorf("missing file loaded as %+v, want empty baseline", missing)
+	}
+
+	saved := &WatchCacheFile{SavedAt: time.Now().UTC().Truncate(time.Second), Images: map[string]string{"default/web": "nginx:1.25"}}
+	if err := saveWatchCache(path, saved); err != nil {
+		t.Fatalf("saveWatchCache() error = %v", err)
+	}
+	loaded, err := loadWatchCache(path)
+	if err != nil {
+		t.Fatalf("loadWatchCache() error = %v", err)
+	}
+	if !loaded.SavedAt.Equal(saved.SavedAt) || !reflect.DeepEqual(loaded.Images, saved.Images) {
+		t.Errorf("loaded %+v, want %+v", loaded, saved)
+	}
+}
+
+func TestReportImageDriftUpdatesBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch-cache.json")
+	if err := saveWatchCache(path, &WatchCacheFile{SavedAt: time.Now(), Images: map[string]string{"default/web": "nginx:1.24"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	e := newCachedTestProcessor(t, newImageDeployment("web", "nginx:1.25", "envoy:v1.28"))
+	e.EnableImageDriftTracking(path)
+
+	if err := e.ReportImageDrift(); err != nil {
+		t.Fatalf("ReportImageDrift() error = %v", err)
+	}
+	baseline, _ := loadWatchCache(path)
+	if got := baseline.Images["default/web"]; got != "nginx:1.25,envoy:v1.28" {
+		t.Fatalf("baseline image = %q after report", got)
+	}
+
+	// Changes seen while watching are persisted right away
+	e.imageTracker.observe(newImageDeployment("web", "nginx:1.26"))
+	e.imageTracker.forget(newImageDeployment("gone"))
+	baseline, _ = loadWatchCache(path)
+	if got := baseline.Images["default/web"]; got != "nginx:1.26" {
+		t.Errorf("baseline image = %q after update, want nginx:1.26", got)
+	}
+}
@@ -6,16 +6,21 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/client-go/informers"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+
+	"k8s-cli/internal/informer"
+	"k8s-cli/internal/k8s"
 )
 
 var (
@@ -24,8 +29,21 @@ var (
 	informerWorkers      int
 	enableEventLogging   bool
 	configFile           string
+
+	// Set when --resync-period was passed, so that an explicit 0 disables resync
+	// instead of falling back to the config file or the 30s default
+	informerResyncPeriodSet bool
+
+	// Retries for the initial connection while the cluster is starting
+	connectAttempts int
+	connectBackoff  time.Duration
 )
 
+// minResyncPeriod is the shortest resync period used without a warning. Every
+// resync replays the whole cache through the event handlers, so very short
+// periods mostly add load and log noise.
+const minResyncPeriod = 10 * time.Second
+
 // Step 7: Informer configuration structure
 type InformerConfig struct {
 	ResyncPeriod time.Duration `mapstructure:"resync_period"`
@@ -33,6 +51,13 @@ type InformerConfig struct {
 	Namespaces   []string      `mapstructure:"namespaces"`
 	LogEvents    bool          `mapstructure:"log_events"`
 
+	// Report the watch as degraded when nothing, not even a resync, happened
+	// for this long; 0 only checks that the watch is connected
+	StaleThreshold time.Duration `mapstructure:"stale_threshold"`
+
+	// Also watch FrontendPage custom resources through a dynamic informer
+	WatchFrontendPages bool `mapstructure:"watch_frontendpages"`
+
 	APIServer struct {
 		Enabled bool `mapstructure:"enabled"`
 		Port    int  `mapstructure:"port"`
@@ -57,94 +82,251 @@ type InformerConfig struct {
 	} `mapstructure:"logging"`
 }
 
-// Step 7: Event processor for informers using k8s.io/client-go
+// workerDrainTimeout bounds how long Stop waits for in-flight work items
+const workerDrainTimeout = 30 * time.Second
+
+// Step 7: Event processor for informers using k8s.io/client-go. The watch and
+// its cache live in internal/informer; the processor adds the custom logic,
+// the work queue and the HTTP APIs on top.
 type EventProcessor struct {
-	clientset       kubernetes.Interface
-	workqueue       workqueue.RateLimitingInterface
-	config          *InformerConfig
-	informerStop    chan struct{}
+	clientset    kubernetes.Interface
+	workqueue    workqueue.RateLimitingInterface
+	config       *InformerConfig
+	informer     *informer.Informer
+	indexed      bool // informer has the image and label indexes
+	informerStop chan struct{}
+	startTime    time.Time
+	workers      sync.WaitGroup
+
+	// deploymentCache is written by the event loop and read by the HTTP handlers
+	cacheMu         sync.RWMutex
 	deploymentCache map[string]*appsv1.Deployment
-	cacheIndexer    cache.Indexer
-	startTime       time.Time
+
+	// synced flips to true once the informer caches have synced; /readyz reports it
+	synced atomic.Bool
+
+	// Lifetime counters, logged as a summary by Stop
+	addEvents      atomic.Int64
+	updateEvents   atomic.Int64
+	deleteEvents   atomic.Int64
+	processedItems atomic.Int64
+	peakCacheSize  atomic.Int64
+
+	// Image baseline in --cache-file, only set up with --track-image-drift
+	imageTracker *imageTracker
+
+	// FrontendPage informer, only set up with --watch-frontendpages
+	dynamicClient       dynamic.Interface
+	frontendPageIndexer cache.Indexer
+
+	// Live deployment changes for /api/v2/ws/deployments clients
+	wsHub *wsHub
 }
 
 func NewEventProcessor(clientset kubernetes.Interface, config *InformerConfig) *EventProcessor {
+	deployments := informer.New(clientset, informer.Options{ResyncPeriod: config.ResyncPeriod})
+	// The search and filter endpoints look deployments up by image and label
+	indexed := true
+	if err := deployments.AddIndexers(informer.Indexers()); err != nil {
+		log.Printf("⚠️ Failed to add deployment indexers, lookups will scan the cache: %v", err)
+		indexed = false
+	}
+
 	return &EventProcessor{
 		clientset:       clientset,
 		workqueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "deployments"),
 		config:          config,
+		informer:        deployments,
+		indexed:         indexed,
 		informerStop:    make(chan struct{}),
 		deploymentCache: make(map[string]*appsv1.Deployment),
 		startTime:       time.Now(),
+		wsHub:           newWSHub(),
 	}
 }
 
+// Subscribe delivers every later deployment event to ch with the overflow
+// policy of opts, straight from the informer; see informer.Subscribe. The
+// Deployments are shared with the cache and must not be modified.
+func (e *EventProcessor) Subscribe(ch chan<- informer.Event, opts informer.SubscribeOptions) {
+	e.informer.Subscribe(ch, opts)
+}
+
+// Unsubscribe stops delivery to ch; the caller may close ch once it returns
+func (e *EventProcessor) Unsubscribe(ch chan<- informer.Event) {
+	e.informer.Unsubscribe(ch)
+}
+
 // Step 7: Start informer using k8s.io/client-go informers
 func (e *EventProcessor) Start(ctx context.Context) error {
 	log.Println("🚀 Starting Kubernetes deployment informer with k8s.io/client-go...")
 
-	// Step 7: Create SharedInformerFactory for list/watch informer
-	informerFactory := informers.NewSharedInformerFactory(e.clientset, e.config.ResyncPeriod)
-	deploymentInformer := informerFactory.Apps().V1().Deployments().Informer()
-
-	// Store indexer for direct cache access
-	e.cacheIndexer = deploymentInformer.GetIndexer()
-
-	// Step 7: Add event handlers for informer
-	deploymentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			if deployment, ok := obj.(*appsv1.Deployment); ok {
-				e.handleAddEvent(deployment)
-				// Step 7: Report events in logs
-				if e.config.LogEvents {
-					log.Printf("✅ ADD: Deployment %s/%s created", deployment.Namespace, deployment.Name)
-				}
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			if oldDeployment, ok := oldObj.(*appsv1.Deployment); ok {
-				if newDeployment, ok := newObj.(*appsv1.Deployment); ok {
-					e.handleUpdateEvent(oldDeployment, newDeployment)
-					// Step 7: Report events in logs
-					if e.config.LogEvents {
-						log.Printf("🔄 UPDATE: Deployment %s/%s modified", newDeployment.Namespace, newDeployment.Name)
-					}
-				}
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			if deployment, ok := obj.(*appsv1.Deployment); ok {
-				e.handleDeleteEvent(deployment)
-				// Step 7: Report events in logs
-				if e.config.LogEvents {
-					log.Printf("🗑️ DELETE: Deployment %s/%s removed", deployment.Namespace, deployment.Name)
-				}
-			}
-		},
-	})
-
-	// Step 7: Start informer factory
-	informerFactory.Start(e.informerStop)
+	// Step 7: Subscribe before starting so the initial list arrives as ADD events
+	events := make(chan informer.Event)
+	e.informer.Subscribe(events, informer.SubscribeOptions{Block: true})
+	go e.handleEvents(events)
 
 	log.Println("⏳ Waiting for informer cache to sync...")
-	if !cache.WaitForCacheSync(ctx.Done(), deploymentInformer.HasSynced) {
-		return fmt.Errorf("failed to sync informer cache")
+	if err := e.informer.Start(ctx); err != nil {
+		return err
 	}
 	log.Println("✅ Informer cache synced successfully")
 
-	// Start worker goroutines
-	for i := 0; i < e.config.Workers; i++ {
-		go e.runWorker(ctx)
+	if e.config.WatchFrontendPages && e.dynamicClient != nil {
+		if err := e.startFrontendPageInformer(ctx); err != nil {
+			return err
+		}
 	}
 
+	e.synced.Store(true)
+	e.startWorkers(ctx, e.config.Workers)
+
 	log.Printf("🔄 Started %d workers, watching deployment events...", e.config.Workers)
 	return nil
 }
 
+// handleEvents runs the custom logic for each informer event until Stop
+func (e *EventProcessor) handleEvents(events <-chan informer.Event) {
+	for {
+		select {
+		case event := <-events:
+			e.handleEvent(event)
+		case <-e.informerStop:
+			return
+		}
+	}
+}
+
+func (e *EventProcessor) handleEvent(event informer.Event) {
+	deployment := event.Deployment
+	switch event.Type {
+	case informer.Added:
+		e.addEvents.Add(1)
+		if e.imageTracker != nil {
+			e.imageTracker.observe(deployment)
+		}
+		e.handleAddEvent(deployment)
+		// Step 7: Report events in logs
+		if e.config.LogEvents {
+			log.Printf("✅ ADD: Deployment %s/%s created", deployment.Namespace, deployment.Name)
+		}
+	case informer.Modified:
+		e.updateEvents.Add(1)
+		if e.imageTracker != nil {
+			e.imageTracker.observe(deployment)
+		}
+		e.handleUpdateEvent(event.Old, deployment)
+		if e.config.LogEvents {
+			log.Printf("🔄 UPDATE: Deployment %s/%s modified", deployment.Namespace, deployment.Name)
+		}
+	case informer.Deleted:
+		e.deleteEvents.Add(1)
+		if e.imageTracker != nil {
+			e.imageTracker.forget(deployment)
+		}
+		e.handleDeleteEvent(deployment)
+		if e.config.LogEvents {
+			log.Printf("🗑️ DELETE: Deployment %s/%s removed", deployment.Namespace, deployment.Name)
+		}
+	}
+	e.publishDeploymentEvent(string(event.Type), deployment)
+}
+
+// startWorkers runs n worker goroutines tracked by e.workers so Stop can wait for them
+func (e *EventProcessor) startWorkers(ctx context.Context, n int) {
+	for i := 0; i < n; i++ {
+		e.workers.Add(1)
+		go func() {
+			defer e.workers.Done()
+			e.runWorker(ctx)
+		}()
+	}
+}
+
+// Stop stops the informer, lets the workers finish every queued item and
+// waits for them to exit, giving up after workerDrainTimeout.
 func (e *EventProcessor) Stop() {
 	log.Println("🛑 Stopping deployment informer...")
+	e.informer.Stop()
 	close(e.informerStop)
-	e.workqueue.ShutDown()
+
+	done := make(chan struct{})
+	go func() {
+		e.workqueue.ShutDownWithDrain()
+		e.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("✅ Workers drained the queue and stopped")
+	case <-time.After(workerDrainTimeout):
+		log.Printf("⚠️ Workers did not finish within %v, %d items left in queue", workerDrainTimeout, e.workqueue.Len())
+	}
+
+	e.logSummary()
+}
+
+// EventSummary is a snapshot of the processor's lifetime counters
+type EventSummary struct {
+	AddEvents      int64
+	UpdateEvents   int64
+	DeleteEvents   int64
+	ProcessedItems int64
+	PeakCacheSize  int64
+	Uptime         time.Duration
+}
+
+func (e *EventProcessor) Summary() EventSummary {
+	return EventSummary{
+		AddEvents:      e.addEvents.Load(),
+		UpdateEvents:   e.updateEvents.Load(),
+		DeleteEvents:   e.deleteEvents.Load(),
+		ProcessedItems: e.processedItems.Load(),
+		PeakCacheSize:  e.peakCacheSize.Load(),
+		Uptime:         time.Since(e.startTime),
+	}
+}
+
+func (e *EventProcessor) logSummary() {
+	s := e.Summary()
+	log.Println("📊 Session summary:")
+	log.Printf("   Events: %d add, %d update, %d delete", s.AddEvents, s.UpdateEvents, s.DeleteEvents)
+	log.Printf("   Work items processed: %d", s.ProcessedItems)
+	log.Printf("   Peak cache size: %d", s.PeakCacheSize)
+	log.Printf("   Uptime: %v", s.Uptime.Round(time.Second))
+}
+
+// recordCacheSize raises peakCacheSize to size if it is a new maximum
+func (e *EventProcessor) recordCacheSize(size int) {
+	for {
+		peak := e.peakCacheSize.Load()
+		if int64(size) <= peak || e.peakCacheSize.CompareAndSwap(peak, int64(size)) {
+			return
+		}
+	}
+}
+
+// cacheDeployment stores a copy of deployment under key and returns the cache size
+func (e *EventProcessor) cacheDeployment(key string, deployment *appsv1.Deployment) int {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+	e.deploymentCache[key] = deployment.DeepCopy()
+	return len(e.deploymentCache)
+}
+
+// cachedDeployment returns the local copy of the deployment with key
+func (e *EventProcessor) cachedDeployment(key string) (*appsv1.Deployment, bool) {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+	deployment, exists := e.deploymentCache[key]
+	return deployment, exists
+}
+
+func (e *EventProcessor) cacheSize() int {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+	return len(e.deploymentCache)
 }
 
 // Step 7+: Custom logic for handling events
@@ -156,7 +338,7 @@ func (e *EventProcessor) handleAddEvent(deployment *appsv1.Deployment) {
 	}
 
 	// Update local cache
-	e.deploymentCache[key] = deployment.DeepCopy()
+	e.recordCacheSize(e.cacheDeployment(key, deployment))
 	e.workqueue.Add(fmt.Sprintf("add:%s", key))
 
 	replicas := int32(0)
@@ -179,7 +361,7 @@ func (e *EventProcessor) handleUpdateEvent(oldDeployment, newDeployment *appsv1.
 	}
 
 	// Update local cache
-	e.deploymentCache[key] = newDeployment.DeepCopy()
+	e.cacheDeployment(key, newDeployment)
 
 	if e.hasSignificantChanges(oldDeployment, newDeployment) {
 		e.workqueue.Add(fmt.Sprintf("update:%s", key))
@@ -199,7 +381,9 @@ func (e *EventProcessor) handleDeleteEvent(deployment *appsv1.Deployment) {
 	}
 
 	// Remove from local cache
+	e.cacheMu.Lock()
 	delete(e.deploymentCache, key)
+	e.cacheMu.Unlock()
 	e.workqueue.Add(fmt.Sprintf("delete:%s", key))
 	e.processDeploymentDeletion(deployment)
 }
@@ -295,6 +479,7 @@ func (e *EventProcessor) runWorker(ctx context.Context) {
 			}
 
 			e.workqueue.Done(obj)
+			e.processedItems.Add(1)
 		}
 	}
 }
@@ -306,6 +491,8 @@ func loadInformerConfig() (*InformerConfig, error) {
 		Workers:      2,
 		Namespaces:   []string{"default"},
 		LogEvents:    true,
+
+		StaleThreshold: defaultStaleThreshold,
 	}
 
 	// Set defaults for all nested structs
@@ -332,19 +519,44 @@ func loadInformerConfig() (*InformerConfig, error) {
 	}
 
 	// Override with command line flags
-	if informerResyncPeriod > 0 {
+	if informerResyncPeriodSet {
 		config.ResyncPeriod = informerResyncPeriod
 	}
 	if informerWorkers > 0 {
 		config.Workers = informerWorkers
 	}
+	if informerStaleThreshold > 0 {
+		config.StaleThreshold = informerStaleThreshold
+	}
 	if enableEventLogging {
 		config.LogEvents = enableEventLogging
 	}
+	if watchFrontendPages {
+		config.WatchFrontendPages = true
+	}
+
+	if err := checkResyncPeriod(config.ResyncPeriod); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
 
+// checkResyncPeriod rejects negative periods and explains the unusual ones. A
+// period of 0 is valid: client-go then never resyncs and handlers only see
+// real changes from the watch.
+func checkResyncPeriod(period time.Duration) error {
+	switch {
+	case period < 0:
+		return usageErrorf("resync period must not be negative, got %s", period)
+	case period == 0:
+		log.Println("ℹ️ Periodic resync disabled (resync period 0)")
+	case period < minResyncPeriod:
+		log.Printf("⚠️ Resync period %s is below %s; frequent resyncs replay the whole cache and can add significant load", period, minResyncPeriod)
+	}
+	return nil
+}
+
 // Step 7: Watch command with informers
 var watchInformerCmd = &cobra.Command{
 	Use:   "watch-informer",
@@ -356,13 +568,16 @@ Step 7 Features:
 • Supports both kubeconfig and in-cluster authentication  
 • Reports all deployment events (ADD/UPDATE/DELETE) in logs
 • Custom logic for processing significant deployment changes
-• Configurable resync period and worker count
+• Configurable resync period and worker count (--resync-period=0 disables resync)
 • Cache storage for deployment resources
+• Optional FrontendPage watching via a dynamic informer (--watch-frontendpages)
+• Optional image drift report at startup against the last run (--track-image-drift)
 
 Authentication:
 • Default: kubeconfig from ~/.kube/config
 • In-cluster: use --in-cluster flag when running in pod`,
 	Run: func(cmd *cobra.Command, args []string) {
+		informerResyncPeriodSet = cmd.Flags().Changed("resync-period")
 		runWatchInformer()
 	},
 }
@@ -385,21 +600,43 @@ func runWatchInformer() {
 	}
 
 	// Test connection to cluster
-	serverVersion, err := clientset.Discovery().ServerVersion()
+	serverVersion, err := k8s.WaitForServer(context.Background(), clientset.Discovery(), connectAttempts, connectBackoff)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to Kubernetes cluster: %v", err)
 	}
 	log.Printf("✅ Successfully connected to Kubernetes cluster (version: %s)", serverVersion.String())
 
 	processor := NewEventProcessor(clientset, config)
+	if config.WatchFrontendPages {
+		dynamicClient, err := GetDynamicClient()
+		if err != nil {
+			log.Fatalf("❌ Failed to create dynamic client: %v", err)
+		}
+		processor.SetDynamicClient(dynamicClient)
+	}
+	if trackImageDrift {
+		if cacheFile == "" {
+			cacheFile = defaultCacheFile()
+		}
+		log.Printf("📸 Tracking image drift in %s", cacheFile)
+		processor.EnableImageDriftTracking(cacheFile)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	go processor.startHealthServer(informerHealthPort)
+
 	if err := processor.Start(ctx); err != nil {
 		log.Fatalf("❌ Failed to start event processor: %v", err)
 	}
 
+	if err := processor.ReportImageDrift(); err != nil {
+		log.Printf("⚠️ Image drift check failed: %v", err)
+	}
+
+	go startPprofServer(pprofBindAddress(enablePprof, pprofPort))
+
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -423,10 +660,19 @@ func runWatchInformer() {
 
 func init() {
 	// Add flags for Step 7
-	watchInformerCmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period")
+	watchInformerCmd.Flags().DurationVar(&informerResyncPeriod, "resync-period", 0, "Informer resync period (default 30s or the config file value; 0 disables resync)")
 	watchInformerCmd.Flags().IntVar(&informerWorkers, "workers", 0, "Number of worker goroutines")
 	watchInformerCmd.Flags().BoolVar(&enableEventLogging, "log-events", true, "Enable event logging")
 	watchInformerCmd.Flags().StringVar(&configFile, "config", "", "Path to configuration file")
+	watchInformerCmd.Flags().IntVar(&connectAttempts, "connect-attempts", 5, "Number of attempts to reach the cluster on startup")
+	watchInformerCmd.Flags().DurationVar(&connectBackoff, "connect-backoff", time.Second, "Initial wait between connection attempts, doubled after each failure")
+	watchInformerCmd.Flags().BoolVar(&watchFrontendPages, "watch-frontendpages", false, "Also watch FrontendPage custom resources (skipped if the CRD is not installed)")
+	watchInformerCmd.Flags().IntVar(&informerHealthPort, "health-port", 8081, "Port for /healthz and /readyz probes (0 disables)")
+	watchInformerCmd.Flags().DurationVar(&informerStaleThreshold, "stale-threshold", 0, "Report the watch as degraded after this long without events or resyncs (default 5m or the config file value)")
+	watchInformerCmd.Flags().BoolVar(&trackImageDrift, "track-image-drift", false, "Report deployments whose image changed since the last run")
+	watchInformerCmd.Flags().StringVar(&cacheFile, "cache-file", "", "File for state kept between runs (default ~/.k8s-cli/watch-cache.json)")
+	watchInformerCmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve net/http/pprof on localhost at --pprof-port")
+	watchInformerCmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "Port for pprof debug endpoints")
 
 	// Register command
 	RootCmd.AddCommand(watchInformerCmd)
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"k8s-cli/internal/informer"
+)
+
+var (
+	// Liveness/readiness probes for watch-informer; 0 disables the server
+	informerHealthPort int
+
+	// --stale-threshold; 0 keeps the config file value or defaultStaleThreshold
+	informerStaleThreshold time.Duration
+)
+
+// defaultStaleThreshold is well above the default resync period, so a healthy
+// informer always has a resync or an event within it
+const defaultStaleThreshold = 5 * time.Minute
+
+// HealthHandler serves /healthz, which always answers 200 while the process is
+// up, and /readyz, which answers 503 until the informer cache has synced and
+// again whenever the watch is degraded.
+func (e *EventProcessor) HealthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !e.synced.Load() {
+			http.Error(w, "informer cache not synced", http.StatusServiceUnavailable)
+			return
+		}
+		if _, reason := e.watchHealth(); reason != "" {
+			http.Error(w, reason, http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+// watchHealth returns the deployment watch status and, when the watch is
+// degraded, why: it is disconnected, or nothing happened for longer than
+// the configured stale threshold, so the cache may be out of date
+func (e *EventProcessor) watchHealth() (informer.WatchStatus, string) {
+	status := e.informer.Status()
+	switch {
+	case status.LastError != "":
+		return status, "watch disconnected: " + status.LastError
+	case !status.Connected:
+		return status, "informer cache not synced"
+	case e.config.StaleThreshold > 0 && time.Since(status.LastEventTime) > e.config.StaleThreshold:
+		return status, fmt.Sprintf("no events or resyncs for more than %s", e.config.StaleThreshold)
+	}
+	return status, ""
+}
+
+// addWatchHealth sets status, watch_connected and last_event_time in a health
+// response, plus degraded_reason when the watch is degraded
+func (e *EventProcessor) addWatchHealth(health map[string]interface{}) {
+	status, reason := e.watchHealth()
+	health["status"] = "healthy"
+	health["watch_connected"] = status.Connected
+	health["last_event_time"] = nil
+	if !status.LastEventTime.IsZero() {
+		health["last_event_time"] = status.LastEventTime.Format(time.RFC3339)
+	}
+	if reason != "" {
+		health["status"] = "degraded"
+		health["degraded_reason"] = reason
+	}
+}
+
+// startHealthServer runs the probe server until it fails; it's started before
+// Start so the liveness probe passes while the cache is still syncing.
+func (e *EventProcessor) startHealthServer(port int) {
+	if port <= 0 {
+		return
+	}
+
+	log.Printf("❤️ Health probes on :%d (/healthz, /readyz)", port)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           e.HealthHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("❌ Health server failed: %v", err)
+	}
+}
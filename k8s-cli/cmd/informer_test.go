@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-cli/internal/informer"
+)
+
+func TestEventProcessorStopDrainsQueue(t *testing.T) {
+	e := NewEventProcessor(fake.NewSimpleClientset(), &InformerConfig{Workers: 2})
+	for _, item := range []string{"add:default/a", "update:default/b", "delete:default/c"} {
+		e.workqueue.Add(item)
+	}
+
+	e.startWorkers(context.Background(), 2)
+	e.Stop()
+
+	if n := e.workqueue.Len(); n != 0 {
+		t.Errorf("queue still holds %d items after Stop", n)
+	}
+	if !e.workqueue.ShuttingDown() {
+		t.Error("queue not shut down after Stop")
+	}
+	if got := e.Summary().ProcessedItems; got != 3 {
+		t.Errorf("ProcessedItems = %d, want 3", got)
+	}
+}
+
+func TestEventProcessorPeakCacheSize(t *testing.T) {
+	config := &InformerConfig{}
+	config.CustomLogic.EnableDeleteHandling = true
+	e := NewEventProcessor(fake.NewSimpleClientset(), config)
+
+	deployments := []*appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "c"}},
+	}
+	for _, d := range deployments {
+		e.handleAddEvent(d)
+	}
+	e.handleDeleteEvent(deployments[0])
+	e.handleDeleteEvent(deployments[1])
+	e.handleAddEvent(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "d"}})
+
+	if got := e.Summary().PeakCacheSize; got != 3 {
+		t.Errorf("PeakCacheSize = %d, want 3", got)
+	}
+}
+
+func TestEventProcessorHealthHandler(t *testing.T) {
+	e := NewEventProcessor(fake.NewSimpleClientset(), &InformerConfig{})
+	handler := e.HealthHandler()
+
+	probe := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	if got := probe("/healthz"); got != http.StatusOK {
+		t.Errorf("/healthz before sync = %d, want 200", got)
+	}
+	if got := probe("/readyz"); got != http.StatusServiceUnavailable {
+		t.Errorf("/readyz before sync = %d, want 503", got)
+	}
+
+	if err := e.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer e.Stop()
+	if got := probe("/readyz"); got != http.StatusOK {
+		t.Errorf("/readyz after sync = %d, want 200", got)
+	}
+
+	// Nothing happens in the fake cluster, so the watch soon counts as stale
+	e.config.StaleThreshold = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	if got := probe("/readyz"); got != http.StatusServiceUnavailable {
+		t.Errorf("/readyz with a stale watch = %d, want 503", got)
+	}
+}
+
+func TestHealthAPIReportsWatchHealth(t *testing.T) {
+	e := NewEventProcessor(fake.NewSimpleClientset(), &InformerConfig{Workers: 1, StaleThreshold: time.Hour})
+
+	health := func(handler http.HandlerFunc) map[string]interface{} {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decoding %s: %v", rec.Body.String(), err)
+		}
+		return response.Data
+	}
+
+	for name, handler := range map[string]http.HandlerFunc{"v1": e.handleHealthAPI, "step8": e.handleStep8HealthAPI} {
+		data := health(handler)
+		if data["status"] != "degraded" || data["watch_connected"] != false || data["last_event_time"] != nil {
+			t.Errorf("%s before Start = %v, want degraded and not connected", name, data)
+		}
+	}
+
+	if err := e.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer e.Stop()
+
+	for name, handler := range map[string]http.HandlerFunc{"v1": e.handleHealthAPI, "step8": e.handleStep8HealthAPI} {
+		data := health(handler)
+		if data["status"] != "healthy" || data["watch_connected"] != true || data["last_event_time"] == nil {
+			t.Errorf("%s after Start = %v, want healthy and connected", name, data)
+		}
+		if _, ok := data["degraded_reason"]; ok {
+			t.Errorf("%s has degraded_reason while healthy", name)
+		}
+	}
+}
+
+func TestEventProcessorStartMarksSynced(t *testing.T) {
+	e := NewEventProcessor(fake.NewSimpleClientset(), &InformerConfig{Workers: 1})
+	if err := e.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer e.Stop()
+
+	if !e.synced.Load() {
+		t.Error("synced not set after Start")
+	}
+}
+
+func TestEventProcessorSubscribe(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}})
+	e := NewEventProcessor(clientset, &InformerConfig{Workers: 1})
+
+	events := make(chan informer.Event, 8)
+	e.Subscribe(events, informer.SubscribeOptions{})
+	if err := e.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer e.Stop()
+
+	select {
+	case event := <-events:
+		if event.Type != informer.Added || event.Deployment.Name != "web" {
+			t.Fatalf("event = %s %s, want ADDED web", event.Type, event.Deployment.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the ADDED event")
+	}
+
+	e.Unsubscribe(events)
+	close(events)
+	if err := clientset.AppsV1().Deployments("default").Delete(context.Background(), "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for event := range events {
+		t.Errorf("got %s %s after Unsubscribe", event.Type, event.Deployment.Name)
+	}
+}
+
+func TestLoadInformerConfigResyncPeriod(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    time.Duration
+		set     bool
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "unset uses default", want: 30 * time.Second},
+		{name: "explicit zero disables resync", set: true, want: 0},
+		{name: "explicit value", flag: time.Minute, set: true, want: time.Minute},
+		{name: "small value is kept", flag: time.Second, set: true, want: time.Second},
+		{name: "negative value", flag: -time.Second, set: true, wantErr: true},
+	}
+
+	origPeriod, origSet, origConfigFile := informerResyncPeriod, informerResyncPeriodSet, configFile
+	defer func() {
+		informerResyncPeriod, informerResyncPeriodSet, configFile = origPeriod, origSet, origConfigFile
+	}()
+	configFile = ""
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			informerResyncPeriod, informerResyncPeriodSet = tt.flag, tt.set
+
+			config, err := loadInformerConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadInformerConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && config.ResyncPeriod != tt.want {
+				t.Errorf("ResyncPeriod = %v, want %v", config.ResyncPeriod, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-cli/config/crd"
+	"k8s-cli/internal/utils"
+)
+
+// installCmd groups what k8s-cli can install into the cluster
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install k8s-cli resources into the cluster",
+	Long:  "Install resources bundled with k8s-cli, such as the FrontendPage CRD",
+}
+
+// installCRDCmd applies the embedded FrontendPage CRD
+var installCRDCmd = &cobra.Command{
+	Use:   "crd",
+	Short: "Install or update the FrontendPage CRD",
+	Long: `Apply the FrontendPage CustomResourceDefinition bundled in the binary, creating it
+or updating an existing one to this version's schema. Needs create, get and update
+on customresourcedefinitions (apiGroup apiextensions.k8s.io).`,
+	Args: cobra.NoArgs,
+	Example: `  # Install the CRD, then start the controller
+  k8s-cli install crd
+  k8s-cli crd`,
+	RunE: runInstallCRD,
+}
+
+// uninstallCmd groups the counterparts of install
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove k8s-cli resources from the cluster",
+	Long:  "Remove resources installed with k8s-cli install",
+}
+
+// uninstallCRDCmd deletes the FrontendPage CRD
+var uninstallCRDCmd = &cobra.Command{
+	Use:   "crd",
+	Short: "Delete the FrontendPage CRD",
+	Long: `Delete the FrontendPage CustomResourceDefinition. Kubernetes then deletes every
+FrontendPage in the cluster, and with them their Deployments and Services.`,
+	Args: cobra.NoArgs,
+	Example: `  # Delete the CRD and all FrontendPages
+  k8s-cli uninstall crd
+
+  # Without confirmation
+  k8s-cli uninstall crd --force`,
+	RunE: runUninstallCRD,
+}
+
+func init() {
+	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(uninstallCmd)
+	installCmd.AddCommand(installCRDCmd)
+	uninstallCmd.AddCommand(uninstallCRDCmd)
+
+	uninstallCRDCmd.Flags().Bool("force", false, "Force delete without confirmation")
+}
+
+func runInstallCRD(cmd *cobra.Command, args []string) error {
+	dyn, err := GetDynamicClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	applied, err := applyFrontendPageCRD(context.TODO(), dyn)
+	result := utils.ActionResult{Action: "install", Kind: "CustomResourceDefinition", Result: "applied"}
+	if applied != nil {
+		result.Name = applied.GetName()
+	}
+	reportResult(result, err)
+	if err != nil {
+		return fmt.Errorf("error installing CRD: %w", err)
+	}
+
+	infof("✅ CustomResourceDefinition '%s' applied, versions: %s\n", applied.GetName(), crdVersions(applied))
+	printCreatedName("customresourcedefinition", "apiextensions.k8s.io", applied.GetName())
+	return nil
+}
+
+func runUninstallCRD(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	definition, err := crd.FrontendPageCRD()
+	if err != nil {
+		return err
+	}
+	name := definition.GetName()
+
+	dyn, err := GetDynamicClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	// Confirm deletion unless force flag is used
+	if !force {
+		fmt.Printf("Deleting customresourcedefinition/%s also deletes every FrontendPage. Continue? (y/N): ", name)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Deletion cancelled")
+			reportResult(utils.ActionResult{Action: "uninstall", Kind: "CustomResourceDefinition", Name: name, Result: "cancelled"}, nil)
+			return nil
+		}
+	}
+
+	err = dyn.Resource(customResourceDefinitions).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		reportResult(utils.ActionResult{Action: "uninstall", Kind: "CustomResourceDefinition", Name: name, Result: "not-found"}, nil)
+		infof("ℹ️ CustomResourceDefinition '%s' is not installed\n", name)
+		return nil
+	}
+	reportResult(utils.ActionResult{Action: "uninstall", Kind: "CustomResourceDefinition", Name: name, Result: "deleted"}, err)
+	if err != nil {
+		return fmt.Errorf("error deleting CRD: %w", err)
+	}
+
+	infof("✅ CustomResourceDefinition '%s' deleted, versions: %s\n", name, crdVersions(definition))
+	return nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// writeJSON is the one place the API servers (api-server, step8-api and
+// platform) encode response bodies. Output is compact unless the request asks
+// for ?pretty=true, which indents it for reading with curl.
+func writeJSON(w http.ResponseWriter, r *http.Request, statusCode int, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	encoder := json.NewEncoder(w)
+	if prettyJSON(r) {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(v)
+}
+
+// prettyJSON reports whether the request asked for indented output
+func prettyJSON(r *http.Request) bool {
+	pretty, _ := strconv.ParseBool(r.URL.Query().Get("pretty"))
+	return pretty
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteJSONPretty(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "compact by default", query: "", want: "{\"status\":\"success\",\"count\":1}\n"},
+		{name: "pretty", query: "?pretty=true", want: "{\n  \"status\": \"success\",\n  \"count\": 1\n}\n"},
+		{name: "pretty=1", query: "?pretty=1", want: "{\n  \"status\": \"success\",\n  \"count\": 1\n}\n"},
+		{name: "pretty=false", query: "?pretty=false", want: "{\"status\":\"success\",\"count\":1}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/deployments"+tt.query, nil)
+
+			writeJSONResponse(rec, req, APIResponse{Status: "success", Count: 1})
+
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+		})
+	}
+}
@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"k8s-cli/internal/k8s"
 	"k8s-cli/internal/utils"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 // listCmd представляет команду list
@@ -30,7 +37,13 @@ var listPodsCmd = &cobra.Command{
   k8s-cli list pods -n kube-system
 
   # Вывод в JSON формате
-  k8s-cli list pods -o json`,
+  k8s-cli list pods -o json
+
+  # Только имена для xargs
+  k8s-cli list pods -o name
+
+  # Следить за подами с меткой app=nginx: строка ADDED/MODIFIED/DELETED на событие
+  k8s-cli list pods -l app=nginx --watch`,
 	RunE: runListPods,
 }
 
@@ -43,7 +56,10 @@ var listDeploymentsCmd = &cobra.Command{
   k8s-cli list deployments
 
   # Список деплойментов в определенном namespace
-  k8s-cli list deployments -n my-app`,
+  k8s-cli list deployments -n my-app
+
+  # Следить за раскаткой деплойментов
+  k8s-cli list deployments -n my-app --watch`,
 	RunE: runListDeployments,
 }
 
@@ -56,7 +72,10 @@ var listServicesCmd = &cobra.Command{
   k8s-cli list services
 
   # Список сервисов в определенном namespace
-  k8s-cli list services -n production`,
+  k8s-cli list services -n production
+
+  # Следить за сервисами
+  k8s-cli list services -n production -w`,
 	RunE: runListServices,
 }
 
@@ -81,17 +100,30 @@ func init() {
 	listPodsCmd.Flags().StringP("selector", "l", "", "селектор меток")
 	listDeploymentsCmd.Flags().StringP("selector", "l", "", "селектор меток")
 	listServicesCmd.Flags().StringP("selector", "l", "", "селектор меток")
+
+	listPodsCmd.Flags().BoolP("watch", "w", false, "после списка следить за изменениями, строка на каждое событие")
+	listDeploymentsCmd.Flags().BoolP("watch", "w", false, "после списка следить за изменениями, строка на каждое событие")
+	listServicesCmd.Flags().BoolP("watch", "w", false, "после списка следить за изменениями, строка на каждое событие")
 }
 
 func runListPods(cmd *cobra.Command, args []string) error {
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("ошибка создания клиента: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	namespace := viper.GetString("namespace")
 	selector, _ := cmd.Flags().GetString("selector")
 
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		factory := listWatchInformerFactory(client.GetClientset(), namespace, selector)
+		return watchList(cmd.Context(), factory, factory.Core().V1().Pods().Informer(), utils.NewPodWatchPrinter(os.Stdout))
+	}
+
 	listOptions := metav1.ListOptions{}
 	if selector != "" {
 		listOptions.LabelSelector = selector
@@ -102,19 +134,29 @@ func runListPods(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("ошибка получения подов: %w", err)
 	}
 
-	fmt.Printf("Поды в namespace '%s':\n", namespace)
-	return utils.PrintPods(pods.Items, viper.GetString("output"))
+	infof("Поды в namespace '%s':\n", namespace)
+	hideManagedFields(pods.Items)
+	return utils.PrintPods(pods.Items, listOutputFormat())
 }
 
 func runListDeployments(cmd *cobra.Command, args []string) error {
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("ошибка создания клиента: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	namespace := viper.GetString("namespace")
 	selector, _ := cmd.Flags().GetString("selector")
 
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		factory := listWatchInformerFactory(client.GetClientset(), namespace, selector)
+		return watchList(cmd.Context(), factory, factory.Apps().V1().Deployments().Informer(), utils.NewDeploymentWatchPrinter(os.Stdout))
+	}
+
 	listOptions := metav1.ListOptions{}
 	if selector != "" {
 		listOptions.LabelSelector = selector
@@ -125,19 +167,29 @@ func runListDeployments(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("ошибка получения деплойментов: %w", err)
 	}
 
-	fmt.Printf("Деплойменты в namespace '%s':\n", namespace)
-	return utils.PrintDeployments(deployments.Items, viper.GetString("output"))
+	infof("Деплойменты в namespace '%s':\n", namespace)
+	hideManagedFields(deployments.Items)
+	return utils.PrintDeployments(deployments.Items, listOutputFormat())
 }
 
 func runListServices(cmd *cobra.Command, args []string) error {
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("ошибка создания клиента: %w", err)
 	}
 
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
 	namespace := viper.GetString("namespace")
 	selector, _ := cmd.Flags().GetString("selector")
 
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		factory := listWatchInformerFactory(client.GetClientset(), namespace, selector)
+		return watchList(cmd.Context(), factory, factory.Core().V1().Services().Informer(), utils.NewServiceWatchPrinter(os.Stdout))
+	}
+
 	listOptions := metav1.ListOptions{}
 	if selector != "" {
 		listOptions.LabelSelector = selector
@@ -148,12 +200,13 @@ func runListServices(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("ошибка получения сервисов: %w", err)
 	}
 
-	fmt.Printf("Сервисы в namespace '%s':\n", namespace)
-	return utils.PrintServices(services.Items, viper.GetString("output"))
+	infof("Сервисы в namespace '%s':\n", namespace)
+	hideManagedFields(services.Items)
+	return utils.PrintServices(services.Items, listOutputFormat())
 }
 
 func runListNamespaces(cmd *cobra.Command, args []string) error {
-	client, err := k8s.NewClient(viper.GetString("kubeconfig"))
+	client, err := getClient()
 	if err != nil {
 		return fmt.Errorf("ошибка создания клиента: %w", err)
 	}
@@ -163,10 +216,81 @@ func runListNamespaces(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("ошибка получения namespace'ов: %w", err)
 	}
 
-	fmt.Println("Namespace'ы:")
+	if viper.GetBool("log-json") {
+		names := make([]string, 0, len(namespaces.Items))
+		for _, ns := range namespaces.Items {
+			names = append(names, ns.Name)
+		}
+		data, err := json.Marshal(names)
+		if err != nil {
+			return fmt.Errorf("ошибка сериализации namespace'ов: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	infof("Namespace'ы:\n")
 	for _, ns := range namespaces.Items {
+		if viper.GetString("output") == "name" {
+			fmt.Println(utils.ResourceName("namespace", "", ns.Name))
+			continue
+		}
 		fmt.Printf("  %s\n", ns.Name)
 	}
 
 	return nil
 }
+
+// listWatchInformerFactory lists and watches in namespace, filtered by the
+// --selector of the list command
+func listWatchInformerFactory(clientset kubernetes.Interface, namespace, selector string) informers.SharedInformerFactory {
+	return informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		}),
+	)
+}
+
+// watchList prints a row for every object the informer lists, then one per
+// change, until ctx ends or the command is interrupted
+func watchList(ctx context.Context, factory informers.SharedInformerFactory, informer cache.SharedIndexInformer, printer *utils.WatchPrinter) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			printer.Print(utils.WatchAdded, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldErr := meta.Accessor(oldObj)
+			newMeta, newErr := meta.Accessor(newObj)
+			if oldErr == nil && newErr == nil && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			printer.Print(utils.WatchModified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			printer.Print(utils.WatchDeleted, obj)
+		},
+	})
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to sync informer cache")
+	}
+
+	<-ctx.Done()
+	return nil
+}
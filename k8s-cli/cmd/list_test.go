@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-cli/internal/utils"
+)
+
+// syncBuffer lets the test read what the informer goroutine writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchListServices(t *testing.T) {
+	service := func(name, namespace, app string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}}}
+	}
+	clientset := fake.NewSimpleClientset(
+		service("web", "shop", "web"),
+		service("db", "shop", "db"),
+		service("web", "other", "web"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out syncBuffer
+	factory := listWatchInformerFactory(clientset, "shop", "app=web")
+	done := make(chan error, 1)
+	go func() {
+		done <- watchList(ctx, factory, factory.Core().V1().Services().Informer(), utils.NewServiceWatchPrinter(&out))
+	}()
+
+	waitForOutput(t, &out, "ADDED")
+	if err := clientset.CoreV1().Services("shop").Delete(context.Background(), "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForOutput(t, &out, "DELETED")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watchList() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "EVENT") {
+		t.Fatalf("want a header and 2 rows, got:\n%s", out.String())
+	}
+	for _, line := range lines[1:] {
+		if fields := strings.Fields(line); fields[1] != "web" || fields[2] != "shop" {
+			t.Errorf("row %q is not shop/web, the only service matching -n and --selector", line)
+		}
+	}
+}
+
+func waitForOutput(t *testing.T, out *syncBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %q in:\n%s", want, out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
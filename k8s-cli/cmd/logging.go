@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	// Controller-runtime logger flags shared by the manager-based commands
+	logLevel  string
+	logFormat string
+)
+
+// zapOptions builds the controller-runtime zap options for --log-level and --log-format.
+// The level is debug, info or error, or a number n to also show logr V(n) messages.
+func zapOptions(level, format string) (zap.Options, error) {
+	var opts zap.Options
+
+	switch strings.ToLower(format) {
+	case "console":
+		opts.Development = true
+		zap.ConsoleEncoder()(&opts)
+	case "json":
+		zap.JSONEncoder()(&opts)
+	default:
+		return opts, fmt.Errorf("invalid --log-format %q: must be console or json", format)
+	}
+
+	switch strings.ToLower(level) {
+	case "debug":
+		opts.Level = zapcore.DebugLevel
+	case "info":
+		opts.Level = zapcore.InfoLevel
+	case "error":
+		opts.Level = zapcore.ErrorLevel
+	default:
+		verbosity, err := strconv.Atoi(level)
+		if err != nil || verbosity < 0 {
+			return opts, fmt.Errorf("invalid --log-level %q: must be debug, info, error or a verbosity >= 0", level)
+		}
+		// logr V(n) is logged at zap level -n
+		opts.Level = zapcore.Level(-verbosity)
+	}
+
+	return opts, nil
+}
+
+// setupLogger installs the zap logger configured by --log-level and --log-format
+func setupLogger() {
+	opts, err := zapOptions(logLevel, logFormat)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+}
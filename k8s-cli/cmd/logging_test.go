@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestZapOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     string
+		format    string
+		wantLevel zapcore.Level
+		wantDev   bool
+		wantErr   bool
+	}{
+		{name: "console info default", level: "info", format: "console", wantLevel: zapcore.InfoLevel, wantDev: true},
+		{name: "json debug", level: "debug", format: "json", wantLevel: zapcore.DebugLevel},
+		{name: "json error uppercase", level: "ERROR", format: "JSON", wantLevel: zapcore.ErrorLevel},
+		{name: "verbosity number", level: "3", format: "console", wantLevel: zapcore.Level(-3), wantDev: true},
+		{name: "unknown format", level: "info", format: "xml", wantErr: true},
+		{name: "unknown level", level: "verbose", format: "json", wantErr: true},
+		{name: "negative verbosity", level: "-1", format: "json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := zapOptions(tt.level, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("zapOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Development != tt.wantDev {
+				t.Errorf("Development = %t, want %t", opts.Development, tt.wantDev)
+			}
+			if opts.Encoder == nil {
+				t.Error("expected an encoder to be configured")
+			}
+			if !opts.Level.Enabled(tt.wantLevel) || opts.Level.Enabled(tt.wantLevel-1) {
+				t.Errorf("Level does not enable exactly %v and above", tt.wantLevel)
+			}
+		})
+	}
+}
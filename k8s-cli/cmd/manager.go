@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,8 +16,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
-	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
 var (
@@ -26,6 +25,9 @@ var (
 	managerMetricsPort   int
 	managerHealthPort    int
 	managerNamespace     string
+	managerWatchNS       []string
+	managerMetricsSecure bool
+	managerMetricsCert   string
 )
 
 // Step 10: Enhanced manager configuration
@@ -33,8 +35,12 @@ type ManagerConfig struct {
 	LeaderElection   bool
 	LeaderElectionID string
 	MetricsPort      int
+	MetricsSecure    bool
+	MetricsCertDir   string
 	HealthPort       int
+	PprofAddress     string
 	Namespace        string
+	WatchNamespaces  []string
 	Workers          int
 }
 
@@ -49,29 +55,36 @@ func NewControllerManager(config *ManagerConfig) (*ControllerManager, error) {
 	log.Printf("   Leader Election: %t", config.LeaderElection)
 	log.Printf("   Leader Election ID: %s", config.LeaderElectionID)
 	log.Printf("   Metrics Port: %d", config.MetricsPort)
+	log.Printf("   Metrics Secure: %t", config.MetricsSecure)
 	log.Printf("   Health Port: %d", config.HealthPort)
 	log.Printf("   Namespace: %s", config.Namespace)
+	if len(config.WatchNamespaces) > 0 {
+		log.Printf("   Watch Namespaces: %s", strings.Join(config.WatchNamespaces, ", "))
+	}
 	log.Printf("   Workers: %d", config.Workers)
 
 	// Setup manager options
 	options := ctrl.Options{
-		Scheme: runtime.NewScheme(),
-		Metrics: server.Options{
-			BindAddress: fmt.Sprintf(":%d", config.MetricsPort),
-		},
+		Scheme:                  runtime.NewScheme(),
+		Metrics:                 metricsServerOptions(config.MetricsPort, config.MetricsSecure, config.MetricsCertDir),
 		HealthProbeBindAddress:  fmt.Sprintf(":%d", config.HealthPort),
+		PprofBindAddress:        config.PprofAddress,
 		LeaderElection:          config.LeaderElection,
 		LeaderElectionID:        config.LeaderElectionID,
 		LeaderElectionNamespace: config.Namespace,
 	}
 
-	// Set namespace if specified
-	if config.Namespace != "" {
-		options.Cache = cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				config.Namespace: {},
-			},
+	// Scope the cache to --watch-namespace, or to the manager namespace if only that is set
+	watchNamespaces := config.WatchNamespaces
+	if len(watchNamespaces) == 0 && config.Namespace != "" {
+		watchNamespaces = []string{config.Namespace}
+	}
+	if len(watchNamespaces) > 0 {
+		cacheOptions, err := watchNamespacesCache(watchNamespaces)
+		if err != nil {
+			return nil, err
 		}
+		options.Cache = cacheOptions
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
@@ -99,6 +112,22 @@ func NewControllerManager(config *ManagerConfig) (*ControllerManager, error) {
 	}, nil
 }
 
+// watchNamespacesCache limits the manager cache to a fixed set of namespaces,
+// e.g. the team namespaces a single controller serves in a shared cluster.
+func watchNamespacesCache(namespaces []string) (cache.Options, error) {
+	defaultNamespaces := make(map[string]cache.Config, len(namespaces))
+	for _, ns := range namespaces {
+		if ns == "" {
+			return cache.Options{}, usageErrorf("watch namespace must not be empty")
+		}
+		if _, dup := defaultNamespaces[ns]; dup {
+			return cache.Options{}, usageErrorf("watch namespace %q specified more than once", ns)
+		}
+		defaultNamespaces[ns] = cache.Config{}
+	}
+	return cache.Options{DefaultNamespaces: defaultNamespaces}, nil
+}
+
 func (cm *ControllerManager) SetupControllers() error {
 	log.Println("🔧 Step 10: Setting up controllers...")
 
@@ -154,7 +183,12 @@ Leader Election:
 • Uses Kubernetes lease resource for coordination
 • Only one manager instance processes events at a time
 • Automatic failover when leader goes down
-• Configurable lease duration and renew deadline`,
+• Configurable lease duration and renew deadline
+
+Namespaces:
+• --watch-namespace can be repeated to limit the cache to a fixed set of namespaces
+  (e.g. --watch-namespace team-a --watch-namespace team-b)
+• Without it the cache covers --manager-namespace, or the whole cluster if that is empty too`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runManager()
 	},
@@ -164,15 +198,19 @@ func runManager() {
 	log.Println("🎯 Starting Step 10: Controller Manager with Leader Election...")
 
 	// Setup logging
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	setupLogger()
 
 	// Create manager configuration
 	config := &ManagerConfig{
 		LeaderElection:   enableLeaderElection,
 		LeaderElectionID: leaderElectionID,
 		MetricsPort:      managerMetricsPort,
+		MetricsSecure:    managerMetricsSecure,
+		MetricsCertDir:   managerMetricsCert,
 		HealthPort:       managerHealthPort,
+		PprofAddress:     pprofBindAddress(enablePprof, pprofPort),
 		Namespace:        managerNamespace,
+		WatchNamespaces:  managerWatchNS,
 		Workers:          controllerWorkers,
 	}
 
@@ -216,15 +254,22 @@ func runManager() {
 	log.Println("   ✅ Graceful shutdown handling")
 	log.Println("")
 	log.Println("🔗 Endpoints:")
-	log.Printf("   📊 Metrics: http://localhost:%d/metrics", managerMetricsPort)
+	log.Printf("   📊 Metrics: %s://localhost:%d/metrics", metricsScheme(managerMetricsSecure), managerMetricsPort)
 	log.Printf("   ❤️ Health: http://localhost:%d/healthz", managerHealthPort)
 	log.Printf("   ✅ Ready: http://localhost:%d/readyz", managerHealthPort)
+	if enablePprof {
+		log.Printf("   🔬 pprof: http://%s/debug/pprof/", config.PprofAddress)
+	}
 	log.Println("")
 	log.Println("🧪 Test the manager:")
 	log.Println("   kubectl create deployment test-step10 --image=nginx:1.20")
 	log.Println("   kubectl get leases -n kube-system | grep k8s-cli")
 	log.Printf("   curl http://localhost:%d/healthz", managerHealthPort)
-	log.Printf("   curl http://localhost:%d/metrics", managerMetricsPort)
+	if managerMetricsSecure {
+		log.Printf("   curl -k -H \"Authorization: Bearer $(kubectl create token <sa>)\" https://localhost:%d/metrics", managerMetricsPort)
+	} else {
+		log.Printf("   curl http://localhost:%d/metrics", managerMetricsPort)
+	}
 
 	// Wait for shutdown signal
 	<-signalChan
@@ -242,9 +287,16 @@ func init() {
 	managerCmd.Flags().BoolVar(&enableLeaderElection, "enable-leader-election", true, "Enable leader election for manager")
 	managerCmd.Flags().StringVar(&leaderElectionID, "leader-election-id", "k8s-cli-manager", "Leader election ID")
 	managerCmd.Flags().IntVar(&managerMetricsPort, "metrics-port", 8080, "Port for metrics server")
+	managerCmd.Flags().BoolVar(&managerMetricsSecure, "metrics-secure", false, "Serve metrics over HTTPS to authenticated and authorized clients only")
+	managerCmd.Flags().StringVar(&managerMetricsCert, "metrics-cert-dir", "", "Directory containing tls.crt and tls.key for secure metrics (default: self-signed)")
 	managerCmd.Flags().IntVar(&managerHealthPort, "health-port", 8081, "Port for health checks")
+	managerCmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve net/http/pprof on localhost at --pprof-port")
+	managerCmd.Flags().IntVar(&pprofPort, "pprof-port", 6060, "Port for pprof debug endpoints")
 	managerCmd.Flags().StringVar(&managerNamespace, "manager-namespace", "", "Namespace for manager operations")
+	managerCmd.Flags().StringSliceVar(&managerWatchNS, "watch-namespace", nil, "Namespace to watch; repeat to watch several (default: --manager-namespace or all)")
 	managerCmd.Flags().IntVar(&controllerWorkers, "workers", 2, "Number of controller workers")
+	managerCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, error or a verbosity number")
+	managerCmd.Flags().StringVar(&logFormat, "log-format", "console", "Log format: console or json")
 
 	// Register command
 	RootCmd.AddCommand(managerCmd)
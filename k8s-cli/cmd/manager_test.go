@@ -0,0 +1,36 @@
+package cmd
+
+import "testing"
+
+func TestWatchNamespacesCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		wantErr    bool
+	}{
+		{name: "single namespace", namespaces: []string{"team-a"}},
+		{name: "several namespaces", namespaces: []string{"team-a", "team-b", "team-c"}},
+		{name: "duplicate namespace", namespaces: []string{"team-a", "team-b", "team-a"}, wantErr: true},
+		{name: "empty namespace", namespaces: []string{"team-a", ""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := watchNamespacesCache(tt.namespaces)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("watchNamespacesCache() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(opts.DefaultNamespaces) != len(tt.namespaces) {
+				t.Fatalf("DefaultNamespaces has %d entries, want %d", len(opts.DefaultNamespaces), len(tt.namespaces))
+			}
+			for _, ns := range tt.namespaces {
+				if _, ok := opts.DefaultNamespaces[ns]; !ok {
+					t.Errorf("DefaultNamespaces missing %q", ns)
+				}
+			}
+		})
+	}
+}
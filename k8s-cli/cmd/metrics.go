@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// metricsHandler is the Prometheus handler the k8s-cli HTTP servers share. It
+// serves controller-runtime's registry, so collectors registered there show up
+// both here and on a manager's own metrics endpoint.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(ctrlmetrics.Registry, promhttp.HandlerOpts{})
+}
+
+// metricsServerOptions serves /metrics over plain HTTP by default. With secure
+// set it serves HTTPS (a self-signed certificate unless certDir holds tls.crt
+// and tls.key) and only answers callers the API server authenticates and
+// authorizes for GET on the /metrics non-resource URL, using controller-runtime's
+// filters.WithAuthenticationAndAuthorization, which caches the TokenReview and
+// SubjectAccessReview results so every scrape doesn't hit the API server. That
+// needs RBAC for the controller to create tokenreviews and subjectaccessreviews,
+// and for scrapers to get nonResourceURLs ["/metrics"].
+func metricsServerOptions(port int, secure bool, certDir string) server.Options {
+	options := server.Options{
+		BindAddress: fmt.Sprintf(":%d", port),
+	}
+	if secure {
+		options.SecureServing = true
+		options.FilterProvider = filters.WithAuthenticationAndAuthorization
+		options.CertDir = certDir
+	}
+	return options
+}
+
+func metricsScheme(secure bool) string {
+	if secure {
+		return "https"
+	}
+	return "http"
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import "testing"
+
+func TestMetricsServerOptions(t *testing.T) {
+	plain := metricsServerOptions(8080, false, "")
+	if plain.BindAddress != ":8080" || plain.SecureServing || plain.FilterProvider != nil {
+		t.Errorf("insecure options = %+v, want plain HTTP on :8080 without filter", plain)
+	}
+
+	secure := metricsServerOptions(8443, true, "/certs")
+	if !secure.SecureServing || secure.FilterProvider == nil || secure.CertDir != "/certs" {
+		t.Errorf("secure options = %+v, want HTTPS with filter and cert dir", secure)
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"k8s-cli/internal/k8s"
+)
+
+// Step 11++: Multi-cluster client configuration
+type MultiClusterConfig struct {
+	Clusters map[string]ClusterConfig `yaml:"clusters"`
+}
+
+type ClusterConfig struct {
+	Name       string `yaml:"name"`
+	Kubeconfig string `yaml:"kubeconfig"`
+	Context    string `yaml:"context"`
+	Namespace  string `yaml:"namespace"`
+	Enabled    bool   `yaml:"enabled"`
+}
+
+// ReconcilerSetup registers controllers with one cluster's manager
+type ReconcilerSetup func(mgr ctrl.Manager) error
+
+// MultiClusterManager runs one controller-runtime manager per cluster, each
+// built from that cluster's own kubeconfig and context, with the same
+// reconcilers registered in every cluster.
+type MultiClusterManager struct {
+	scheme   *runtime.Scheme
+	setups   []ReconcilerSetup
+	configs  map[string]ClusterConfig
+	managers map[string]ctrl.Manager
+}
+
+func NewMultiClusterManager(scheme *runtime.Scheme, setups ...ReconcilerSetup) *MultiClusterManager {
+	return &MultiClusterManager{
+		scheme:   scheme,
+		setups:   setups,
+		configs:  make(map[string]ClusterConfig),
+		managers: make(map[string]ctrl.Manager),
+	}
+}
+
+func (mcm *MultiClusterManager) AddCluster(name string, config ClusterConfig) error {
+	log.Printf("🌐 Step 11++: Adding cluster '%s' to multi-cluster manager", name)
+
+	restConfig, err := clusterRESTConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig for cluster %s: %v", name, err)
+	}
+
+	options := ctrl.Options{
+		Scheme: mcm.scheme,
+		Metrics: server.Options{
+			BindAddress: "0", // Disable metrics for individual clusters
+		},
+		HealthProbeBindAddress: "0",   // Disable health for individual clusters
+		LeaderElection:         false, // No leader election per cluster
+	}
+	if config.Namespace != "" {
+		options.Cache = cache.Options{
+			DefaultNamespaces: map[string]cache.Config{
+				config.Namespace: {},
+			},
+		}
+	}
+
+	// Create manager for this cluster
+	mgr, err := ctrl.NewManager(restConfig, options)
+	if err != nil {
+		return fmt.Errorf("failed to create manager for cluster %s: %v", name, err)
+	}
+
+	for _, setup := range mcm.setups {
+		if err := setup(mgr); err != nil {
+			return fmt.Errorf("failed to setup reconciler for cluster %s: %v", name, err)
+		}
+	}
+
+	mcm.configs[name] = config
+	mcm.managers[name] = mgr
+
+	log.Printf("✅ Step 11++: Successfully configured cluster '%s'", name)
+	return nil
+}
+
+func (mcm *MultiClusterManager) StartAll(ctx context.Context) error {
+	log.Printf("🚀 Step 11++: Starting multi-cluster managers for %d clusters", len(mcm.managers))
+
+	for name, mgr := range mcm.managers {
+		if !mcm.configs[name].Enabled {
+			log.Printf("⏭️ Step 11++: Skipping disabled cluster '%s'", name)
+			continue
+		}
+
+		go func(clusterName string, manager ctrl.Manager) {
+			log.Printf("🏃 Step 11++: Starting manager for cluster '%s'", clusterName)
+			if err := manager.Start(ctx); err != nil {
+				log.Printf("❌ Step 11++: Manager for cluster '%s' failed: %v", clusterName, err)
+			}
+		}(name, mgr)
+	}
+
+	return nil
+}
+
+// clusterRESTConfig loads the cluster's kubeconfig (with ~ expanded) and
+// selects its context; an empty context keeps the file's current-context
+func clusterRESTConfig(config ClusterConfig) (*rest.Config, error) {
+	path := config.Kubeconfig
+	if strings.HasPrefix(path, "~/") {
+		path = filepath.Join(homedir.HomeDir(), path[2:])
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		k8s.NewLoadingRules(path),
+		&clientcmd.ConfigOverrides{CurrentContext: config.Context},
+	).ClientConfig()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const multiClusterTestKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+- name: production
+  cluster:
+    server: https://production.example.com
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: test
+- name: production
+  context:
+    cluster: production
+    user: test
+users:
+- name: test
+  user:
+    token: test-token
+`
+
+func TestClusterRESTConfigUsesClusterKubeconfigAndContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(multiClusterTestKubeconfig), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		context  string
+		wantHost string
+	}{
+		{name: "explicit context", context: "production", wantHost: "https://production.example.com"},
+		{name: "current context", context: "", wantHost: "https://staging.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restConfig, err := clusterRESTConfig(ClusterConfig{Kubeconfig: path, Context: tt.context})
+			if err != nil {
+				t.Fatalf("clusterRESTConfig() error = %v", err)
+			}
+			if restConfig.Host != tt.wantHost {
+				t.Errorf("Host = %s, want %s", restConfig.Host, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestClusterRESTConfigUnknownContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(multiClusterTestKubeconfig), 0600); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	if _, err := clusterRESTConfig(ClusterConfig{Kubeconfig: path, Context: "missing"}); err == nil {
+		t.Fatal("clusterRESTConfig() expected error for unknown context")
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-cli/internal/k8s"
+)
+
+// cordonCmd marks a node unschedulable
+var cordonCmd = &cobra.Command{
+	Use:   "cordon <node-name>",
+	Short: "Mark a node as unschedulable",
+	Long:  "Mark a node as unschedulable so no new pods are placed on it",
+	Args:  cobra.ExactArgs(1),
+	Example: `  # Cordon a node
+  k8s-cli cordon worker-1`,
+	RunE: runCordon,
+}
+
+// uncordonCmd marks a node schedulable again
+var uncordonCmd = &cobra.Command{
+	Use:   "uncordon <node-name>",
+	Short: "Mark a node as schedulable",
+	Long:  "Mark a node as schedulable again after maintenance",
+	Args:  cobra.ExactArgs(1),
+	Example: `  # Uncordon a node
+  k8s-cli uncordon worker-1`,
+	RunE: runUncordon,
+}
+
+// drainCmd cordons a node and evicts its pods
+var drainCmd = &cobra.Command{
+	Use:   "drain <node-name>",
+	Short: "Drain a node in preparation for maintenance",
+	Long: `Cordon a node and evict its pods through the eviction API.
+
+Evictions respect PodDisruptionBudgets and are retried while a budget blocks them.
+Mirror pods are skipped; DaemonSet pods block the drain unless --ignore-daemonsets is set.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Drain a node
+  k8s-cli drain worker-1 --ignore-daemonsets
+
+  # Drain with a shorter grace period and an overall timeout
+  k8s-cli drain worker-1 --ignore-daemonsets --grace-period 30 --timeout 5m`,
+	RunE: runDrain,
+}
+
+func init() {
+	rootCmd.AddCommand(cordonCmd)
+	rootCmd.AddCommand(uncordonCmd)
+	rootCmd.AddCommand(drainCmd)
+
+	// Add flags
+	drainCmd.Flags().Int("grace-period", -1, "Seconds given to each pod to terminate; negative uses the pod's own value")
+	drainCmd.Flags().Bool("ignore-daemonsets", false, "Skip DaemonSet-managed pods")
+	drainCmd.Flags().Duration("timeout", 0, "Give up after this long; zero waits indefinitely")
+}
+
+func runCordon(cmd *cobra.Command, args []string) error {
+	nodeName := args[0]
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := client.CordonNode(nodeName); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Node '%s' cordoned\n", nodeName)
+	return nil
+}
+
+func runUncordon(cmd *cobra.Command, args []string) error {
+	nodeName := args[0]
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := client.UncordonNode(nodeName); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Node '%s' uncordoned\n", nodeName)
+	return nil
+}
+
+func runDrain(cmd *cobra.Command, args []string) error {
+	nodeName := args[0]
+	gracePeriod, _ := cmd.Flags().GetInt("grace-period")
+	ignoreDaemonSets, _ := cmd.Flags().GetBool("ignore-daemonsets")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	fmt.Printf("🔄 Draining node '%s'...\n", nodeName)
+	start := time.Now()
+
+	result, err := client.DrainNode(context.Background(), nodeName, k8s.DrainOptions{
+		GracePeriodSeconds: gracePeriod,
+		IgnoreDaemonSets:   ignoreDaemonSets,
+		Timeout:            timeout,
+	})
+	if result != nil {
+		for _, pod := range result.Skipped {
+			fmt.Printf("   ⏭️  Skipped pod/%s\n", pod)
+		}
+		for _, pod := range result.Evicted {
+			fmt.Printf("   🗑️  Evicted pod/%s\n", pod)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Node '%s' drained in %v\n", nodeName, time.Since(start).Round(time.Second))
+	return nil
+}
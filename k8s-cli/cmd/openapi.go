@@ -0,0 +1,629 @@
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	k8scliv1 "k8s-cli/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenAPIDocument is the OpenAPI 3.0 description each API server serves at
+// /openapi.json. It is built from the known handlers rather than generated
+// from annotations, so new routes have to be added here as well.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	AllOf                []*openAPISchema          `json:"allOf,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIOperation struct {
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+// openAPIErrorCodes are the values of the "code" field in error responses
+var openAPIErrorCodes = []string{
+	ErrCodeMethodNotAllowed,
+	ErrCodeInvalidPath,
+	ErrCodeInvalidSelector,
+	ErrCodeInvalidParameter,
+	ErrCodeNotFound,
+	ErrCodeForbidden,
+	ErrCodeUnavailable,
+	ErrCodeInternal,
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	metaTimeType   = reflect.TypeOf(metav1.Time{})
+	objectMetaType = reflect.TypeOf(metav1.ObjectMeta{})
+)
+
+// openAPIBuilder collects paths and derives component schemas from the Go
+// response types by reflection, following the encoding/json field rules
+type openAPIBuilder struct {
+	doc *OpenAPIDocument
+}
+
+func newOpenAPIBuilder(title, description string) *openAPIBuilder {
+	return &openAPIBuilder{doc: &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: title, Description: description, Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: make(map[string]*openAPISchema),
+		},
+	}}
+}
+
+func (b *openAPIBuilder) add(method, path string, op *openAPIOperation) {
+	if b.doc.Paths[path] == nil {
+		b.doc.Paths[path] = make(map[string]*openAPIOperation)
+	}
+	b.doc.Paths[path][strings.ToLower(method)] = op
+}
+
+// ref returns a reference to the component schema for v's type, registering it on first use
+func (b *openAPIBuilder) ref(v interface{}) *openAPISchema {
+	return b.schemaFor(reflect.TypeOf(v))
+}
+
+func (b *openAPIBuilder) schemaFor(t reflect.Type) *openAPISchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType, metaTimeType:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case objectMetaType:
+		b.registerObjectMeta()
+		return &openAPISchema{Ref: "#/components/schemas/ObjectMeta"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &openAPISchema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, ok := b.doc.Components.Schemas[t.Name()]; !ok {
+			// Register a placeholder first so recursive types terminate
+			b.doc.Components.Schemas[t.Name()] = &openAPISchema{}
+			*b.doc.Components.Schemas[t.Name()] = *b.structSchema(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		// interface{} and anything else: any JSON value
+		return &openAPISchema{}
+	}
+}
+
+func (b *openAPIBuilder) structSchema(t reflect.Type) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	b.addStructFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// addStructFields adds t's JSON fields to schema. Embedded structs without a
+// JSON name are flattened, and fields without omitempty are always present,
+// so they are listed as required.
+func (b *openAPIBuilder) addStructFields(schema *openAPISchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			b.addStructFields(schema, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = b.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// registerObjectMeta adds a compact ObjectMeta with the fields clients use,
+// instead of reflecting the whole Kubernetes type
+func (b *openAPIBuilder) registerObjectMeta() {
+	if _, ok := b.doc.Components.Schemas["ObjectMeta"]; ok {
+		return
+	}
+	stringMap := &openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{Type: "string"}}
+	b.doc.Components.Schemas["ObjectMeta"] = &openAPISchema{
+		Type:        "object",
+		Description: "Kubernetes object metadata (abbreviated)",
+		Properties: map[string]*openAPISchema{
+			"name":              {Type: "string"},
+			"namespace":         {Type: "string"},
+			"uid":               {Type: "string"},
+			"resourceVersion":   {Type: "string"},
+			"generation":        {Type: "integer", Format: "int64"},
+			"creationTimestamp": {Type: "string", Format: "date-time"},
+			"labels":            stringMap,
+			"annotations":       stringMap,
+		},
+	}
+}
+
+// envelope is envelopeType's schema with "data" narrowed to data
+func (b *openAPIBuilder) envelope(envelopeType interface{}, data *openAPISchema) *openAPISchema {
+	return &openAPISchema{AllOf: []*openAPISchema{
+		b.ref(envelopeType),
+		{Type: "object", Properties: map[string]*openAPISchema{"data": data}},
+	}}
+}
+
+// restrictErrorCodes documents the stable error codes on an envelope's "code" field
+func (b *openAPIBuilder) restrictErrorCodes(envelopeType interface{}) {
+	b.ref(envelopeType)
+	schema := b.doc.Components.Schemas[reflect.TypeOf(envelopeType).Name()]
+	schema.Properties["code"] = &openAPISchema{Type: "string", Enum: openAPIErrorCodes}
+}
+
+func jsonContent(schema *openAPISchema) map[string]*openAPIMediaType {
+	return map[string]*openAPIMediaType{"application/json": {Schema: schema}}
+}
+
+func jsonResponse(description string, schema *openAPISchema) *openAPIResponse {
+	return &openAPIResponse{Description: description, Content: jsonContent(schema)}
+}
+
+func textResponse(description string) *openAPIResponse {
+	return &openAPIResponse{
+		Description: description,
+		Content:     map[string]*openAPIMediaType{"text/plain": {Schema: &openAPISchema{Type: "string"}}},
+	}
+}
+
+func jsonBody(schema *openAPISchema) *openAPIRequestBody {
+	return &openAPIRequestBody{Required: true, Content: jsonContent(schema)}
+}
+
+func queryParam(name, description string, schema *openAPISchema) *openAPIParameter {
+	return &openAPIParameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func pathParam(name, description string) *openAPIParameter {
+	return &openAPIParameter{Name: name, In: "path", Description: description, Required: true, Schema: &openAPISchema{Type: "string"}}
+}
+
+func objectSchema(properties map[string]*openAPISchema) *openAPISchema {
+	return &openAPISchema{Type: "object", Properties: properties}
+}
+
+var (
+	stringSchema  = &openAPISchema{Type: "string"}
+	integerSchema = &openAPISchema{Type: "integer"}
+	booleanSchema = &openAPISchema{Type: "boolean"}
+	anyObject     = &openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{}}
+)
+
+// apiServerOpenAPI describes the api-server endpoints in cmd/api.go
+func apiServerOpenAPI() *OpenAPIDocument {
+	b := newOpenAPIBuilder("k8s-cli API Server", "Read access to the deployment informer cache")
+	b.restrictErrorCodes(APIResponse{})
+	errorResponse := func(description string) *openAPIResponse {
+		return jsonResponse(description, b.ref(APIResponse{}))
+	}
+	namespace := queryParam("namespace", "Only return objects in this namespace", stringSchema)
+
+	b.add(http.MethodGet, "/api/v1/deployments", &openAPIOperation{
+		Summary: "List deployments from the informer cache",
+		Tags:    []string{"deployments"},
+		Parameters: []*openAPIParameter{
+			namespace,
+			queryParam("labelSelector", "Kubernetes label selector, e.g. app=web,tier!=db", stringSchema),
+		},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Deployments", b.envelope(APIResponse{}, &openAPISchema{Type: "array", Items: b.ref(DeploymentSummary{})})),
+			"400": errorResponse("Invalid label selector"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v1/deployments/{namespace}/{name}", &openAPIOperation{
+		Summary:    "Get one deployment from the informer cache",
+		Tags:       []string{"deployments"},
+		Parameters: []*openAPIParameter{pathParam("namespace", "Deployment namespace"), pathParam("name", "Deployment name")},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Deployment", b.envelope(APIResponse{}, b.ref(DeploymentSummary{}))),
+			"400": errorResponse("Malformed path"),
+			"404": errorResponse("Deployment is not in the cache"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v1/health", &openAPIOperation{
+		Summary:   "Health check",
+		Tags:      []string{"health"},
+		Responses: map[string]*openAPIResponse{"200": jsonResponse("Service health", b.envelope(APIResponse{}, anyObject))},
+	})
+	b.add(http.MethodGet, "/api/v1/cache/stats", &openAPIOperation{
+		Summary:   "Informer cache statistics",
+		Tags:      []string{"cache"},
+		Responses: map[string]*openAPIResponse{"200": jsonResponse("Cache statistics", b.envelope(APIResponse{}, anyObject))},
+	})
+	b.add(http.MethodGet, "/api/v1/frontendpages", &openAPIOperation{
+		Summary:    "List FrontendPages from the informer cache",
+		Tags:       []string{"frontendpages"},
+		Parameters: []*openAPIParameter{namespace},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("FrontendPages", b.envelope(APIResponse{}, &openAPISchema{Type: "array", Items: b.ref(FrontendPageSummary{})})),
+			"503": errorResponse("The FrontendPage cache is not running (--watch-frontendpages, CRD installed)"),
+		},
+	})
+
+	return b.doc
+}
+
+// step8OpenAPI describes the step8-api endpoints in cmd/cashe.go
+func step8OpenAPI() *OpenAPIDocument {
+	b := newOpenAPIBuilder("k8s-cli Step 8 API Server", "Filtering, search and metrics over the deployment informer cache")
+	b.restrictErrorCodes(Step8APIResponse{})
+	errorResponse := func(description string) *openAPIResponse {
+		return jsonResponse(description, b.ref(Step8APIResponse{}))
+	}
+	namespace := queryParam("namespace", "Only consider deployments in this namespace", stringSchema)
+
+	b.add(http.MethodGet, "/api/v2/deployments", &openAPIOperation{
+		Summary: "List deployments with filtering, sorting and pagination",
+		Tags:    []string{"deployments"},
+		Parameters: []*openAPIParameter{
+			namespace,
+			queryParam("status", "Only deployments with this status, e.g. Ready", stringSchema),
+			queryParam("image", "Only deployments whose image contains this string", stringSchema),
+			queryParam("labelSelector", "Kubernetes label selector, e.g. app=web,tier!=db", stringSchema),
+			queryParam("sortBy", "Sort field", &openAPISchema{Type: "string", Enum: []string{"name", "namespace", "created", "replicas"}}),
+			queryParam("order", "Sort order", &openAPISchema{Type: "string", Enum: []string{"asc", "desc"}}),
+			queryParam("page", "Page number, starting at 1", integerSchema),
+			queryParam("pageSize", "Deployments per page", integerSchema),
+		},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Deployments", b.envelope(Step8APIResponse{}, &openAPISchema{Type: "array", Items: b.ref(DeploymentDetail{})})),
+			"400": errorResponse("Invalid label selector"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v2/deployments/{namespace}/{name}", &openAPIOperation{
+		Summary:    "Get detailed information about one deployment",
+		Tags:       []string{"deployments"},
+		Parameters: []*openAPIParameter{pathParam("namespace", "Deployment namespace"), pathParam("name", "Deployment name")},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Deployment", b.envelope(Step8APIResponse{}, b.ref(DeploymentDetail{}))),
+			"400": errorResponse("Malformed path"),
+			"404": errorResponse("Deployment is not in the cache"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v2/cache/metrics", &openAPIOperation{
+		Summary:    "Cache metrics and analytics",
+		Tags:       []string{"cache"},
+		Parameters: []*openAPIParameter{namespace},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Cache metrics", b.envelope(Step8APIResponse{}, b.ref(CacheMetrics{}))),
+			"400": errorResponse("Invalid namespace"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v2/cache/search", &openAPIOperation{
+		Summary: "Search cached deployments, ranked by relevance",
+		Tags:    []string{"cache"},
+		Parameters: []*openAPIParameter{
+			{Name: "q", In: "query", Description: "Search text", Required: true, Schema: stringSchema},
+			namespace,
+			queryParam("fields", "Comma-separated fields to search", &openAPISchema{Type: "string", Description: "Any of " + strings.Join(searchFields, ", ")}),
+			queryParam("offset", "Results to skip", integerSchema),
+			queryParam("limit", "Maximum results to return", integerSchema),
+			queryParam("debug", "Include the relevance score of each result", booleanSchema),
+		},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Search results", b.envelope(Step8APIResponse{}, &openAPISchema{Type: "array", Items: b.ref(SearchResult{})})),
+			"400": errorResponse("Missing query or invalid parameter"),
+		},
+	})
+	b.add(http.MethodGet, "/api/v2/cache/status", &openAPIOperation{
+		Summary:   "Cache status and health",
+		Tags:      []string{"cache"},
+		Responses: map[string]*openAPIResponse{"200": jsonResponse("Cache status", b.envelope(Step8APIResponse{}, anyObject))},
+	})
+	b.add(http.MethodGet, "/api/v2/health", &openAPIOperation{
+		Summary:   "Health check",
+		Tags:      []string{"health"},
+		Responses: map[string]*openAPIResponse{"200": jsonResponse("Service health", b.envelope(Step8APIResponse{}, anyObject))},
+	})
+	b.ref(DeploymentChangeEvent{})
+	b.add(http.MethodGet, "/api/v2/ws/deployments", &openAPIOperation{
+		Summary: "WebSocket stream of deployment changes; every text message is a DeploymentChangeEvent",
+		Tags:    []string{"deployments"},
+		Responses: map[string]*openAPIResponse{
+			"101": {Description: "Switching to the WebSocket protocol"},
+		},
+	})
+
+	return b.doc
+}
+
+// platformOpenAPI describes the platform API routes in cmd/platform.go
+func platformOpenAPI() *OpenAPIDocument {
+	b := newOpenAPIBuilder("k8s-cli Platform Engineering API", "FrontendPage CRUD and Port.io self-service actions")
+	b.doc.Components.SecuritySchemes = map[string]*openAPISecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer"},
+	}
+	// Enforced only when the server runs with --api-token
+	bearer := []map[string][]string{{"bearerAuth": {}}}
+
+	frontendPage := b.ref(k8scliv1.FrontendPage{})
+	result := func(extra map[string]*openAPISchema) *openAPISchema {
+		properties := map[string]*openAPISchema{"status": stringSchema}
+		for key, value := range extra {
+			properties[key] = value
+		}
+		return objectSchema(properties)
+	}
+	name := pathParam("name", "FrontendPage name")
+	tooLarge := textResponse("Request body larger than --max-body-bytes")
+	busy := textResponse("Every --max-concurrent-actions slot is busy; retry after the Retry-After header")
+
+	b.add(http.MethodPost, "/webhook/port", &openAPIOperation{
+		Summary:     "Run a Port.io self-service action",
+		Tags:        []string{"actions"},
+		RequestBody: jsonBody(b.ref(ActionRequest{})),
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Action result", b.ref(ActionResponse{})),
+			"429": busy,
+			"400": textResponse("Invalid payload, unknown field or invalid action inputs"),
+			"401": textResponse("Missing or wrong bearer token"),
+			"413": tooLarge,
+		},
+		Security: bearer,
+	})
+	b.add(http.MethodGet, "/api/v1/actions", &openAPIOperation{
+		Summary: "List the available actions",
+		Tags:    []string{"actions"},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Action catalog", result(map[string]*openAPISchema{
+				"actions": {Type: "array", Items: b.ref(PortAction{})},
+				"count":   integerSchema,
+			})),
+		},
+	})
+	b.add(http.MethodGet, "/api/v1/frontendpages", &openAPIOperation{
+		Summary: "List FrontendPages",
+		Tags:    []string{"frontendpages"},
+		Parameters: []*openAPIParameter{
+			queryParam("limit", "Page size (at most 500)", integerSchema),
+			queryParam("continue", "Continue token from the previous page", stringSchema),
+			queryParam("phase", "Only FrontendPages in this phase, e.g. Running (case-insensitive)", stringSchema),
+			queryParam("sortBy", "Sort field; age sorts youngest first", &openAPISchema{Type: "string", Enum: []string{"name", "age"}}),
+			queryParam("order", "Sort order", &openAPISchema{Type: "string", Enum: []string{"asc", "desc"}}),
+		},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("FrontendPages", result(map[string]*openAPISchema{
+				"data":  {Type: "array", Items: frontendPage},
+				"count": integerSchema,
+				"metadata": objectSchema(map[string]*openAPISchema{
+					"continue":             stringSchema,
+					"limit":                integerSchema,
+					"remaining_item_count": integerSchema,
+					"filter_by":            stringSchema,
+					"sort_by":              stringSchema,
+				}),
+			})),
+			"400": textResponse("Invalid limit, sortBy or order"),
+			"410": textResponse("Continue token expired"),
+		},
+	})
+	b.add(http.MethodPost, "/api/v1/frontendpages", &openAPIOperation{
+		Summary:     "Create a FrontendPage",
+		Tags:        []string{"frontendpages"},
+		Parameters:  []*openAPIParameter{queryParam("wait", "Block until the FrontendPage is Ready, up to --wait-timeout", booleanSchema)},
+		RequestBody: jsonBody(frontendPage),
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Created FrontendPage; with wait, also Ready", result(map[string]*openAPISchema{"message": stringSchema, "url": stringSchema, "data": frontendPage})),
+			"202": jsonResponse("Created but not Ready within --wait-timeout", result(map[string]*openAPISchema{"message": stringSchema, "data": frontendPage})),
+			"400": textResponse("Invalid payload or wait value"),
+			"413": tooLarge,
+			"401": textResponse("Missing or wrong bearer token"),
+		},
+		Security: bearer,
+	})
+	b.add(http.MethodGet, "/api/v1/frontendpages/{name}", &openAPIOperation{
+		Summary:    "Get a FrontendPage",
+		Tags:       []string{"frontendpages"},
+		Parameters: []*openAPIParameter{name},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("FrontendPage", result(map[string]*openAPISchema{"data": frontendPage})),
+			"404": textResponse("FrontendPage not found"),
+		},
+	})
+	b.add(http.MethodPut, "/api/v1/frontendpages/{name}", &openAPIOperation{
+		Summary:     "Replace a FrontendPage spec; fields missing from the body are reset",
+		Tags:        []string{"frontendpages"},
+		Parameters:  []*openAPIParameter{name},
+		RequestBody: jsonBody(frontendPage),
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Updated FrontendPage", result(map[string]*openAPISchema{"message": stringSchema, "data": frontendPage})),
+			"400": textResponse("Invalid payload"),
+			"413": tooLarge,
+			"401": textResponse("Missing or wrong bearer token"),
+			"404": textResponse("FrontendPage not found"),
+		},
+		Security: bearer,
+	})
+	b.add(http.MethodPatch, "/api/v1/frontendpages/{name}", &openAPIOperation{
+		Summary:    "Change single FrontendPage fields with a JSON merge patch (RFC 7386)",
+		Tags:       []string{"frontendpages"},
+		Parameters: []*openAPIParameter{name},
+		RequestBody: &openAPIRequestBody{
+			Required: true,
+			Content: map[string]*openAPIMediaType{
+				"application/merge-patch+json": {Schema: frontendPage},
+				"application/json":             {Schema: frontendPage},
+			},
+		},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Patched FrontendPage", result(map[string]*openAPISchema{"message": stringSchema, "data": frontendPage})),
+			"400": textResponse("Invalid payload, unknown field or a field outside spec, metadata.labels and metadata.annotations"),
+			"413": tooLarge,
+			"401": textResponse("Missing or wrong bearer token"),
+			"404": textResponse("FrontendPage not found"),
+			"415": textResponse("Content-Type is not a JSON merge patch"),
+			"422": textResponse("Rejected by validation"),
+		},
+		Security: bearer,
+	})
+	b.add(http.MethodDelete, "/api/v1/frontendpages/{name}", &openAPIOperation{
+		Summary:    "Delete a FrontendPage",
+		Tags:       []string{"frontendpages"},
+		Parameters: []*openAPIParameter{name},
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Deleted", result(map[string]*openAPISchema{"message": stringSchema})),
+			"401": textResponse("Missing or wrong bearer token"),
+		},
+		Security: bearer,
+	})
+	b.add(http.MethodPost, "/api/v1/frontendpages/update", &openAPIOperation{
+		Summary: "Update a FrontendPage through the update_frontend action",
+		Tags:    []string{"frontendpages", "actions"},
+		RequestBody: jsonBody(objectSchema(map[string]*openAPISchema{
+			"name":    stringSchema,
+			"updates": anyObject,
+			"dryRun":  booleanSchema,
+		})),
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Action result", b.ref(ActionResponse{})),
+			"429": busy,
+			"400": textResponse("Invalid payload"),
+			"413": tooLarge,
+			"401": textResponse("Missing or wrong bearer token"),
+		},
+		Security: bearer,
+	})
+	upserted := result(map[string]*openAPISchema{
+		"operation": {Type: "string", Enum: []string{"created", "updated", "unchanged"}},
+		"data":      frontendPage,
+	})
+	b.add(http.MethodPut, "/api/v2/frontendpages/{namespace}/{name}", &openAPIOperation{
+		Summary:     "Create or update a FrontendPage",
+		Tags:        []string{"frontendpages"},
+		Parameters:  []*openAPIParameter{pathParam("namespace", "FrontendPage namespace"), name},
+		RequestBody: jsonBody(frontendPage),
+		Responses: map[string]*openAPIResponse{
+			"200": jsonResponse("Updated or unchanged", upserted),
+			"201": jsonResponse("Created", upserted),
+			"400": textResponse("Invalid payload or metadata that doesn't match the path"),
+			"413": tooLarge,
+			"401": textResponse("Missing or wrong bearer token"),
+			"422": textResponse("Rejected by validation"),
+		},
+		Security: bearer,
+	})
+	for path, summary := range map[string]string{
+		"/health":       "Health check",
+		"/health/live":  "Liveness probe",
+		"/health/ready": "Readiness probe with dependency checks",
+	} {
+		responses := map[string]*openAPIResponse{"200": jsonResponse("Healthy", anyObject)}
+		if path != "/health/live" {
+			responses["503"] = jsonResponse("Kubernetes is unreachable", anyObject)
+		}
+		b.add(http.MethodGet, path, &openAPIOperation{
+			Summary:   summary,
+			Tags:      []string{"health"},
+			Responses: responses,
+		})
+	}
+
+	return b.doc
+}
+
+// serveOpenAPI returns a handler for /openapi.json serving doc
+func serveOpenAPI(doc *OpenAPIDocument) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, doc)
+	}
+}
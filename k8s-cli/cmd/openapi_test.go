@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   *OpenAPIDocument
+		paths []string
+	}{
+		{
+			name:  "api-server",
+			doc:   apiServerOpenAPI(),
+			paths: []string{"/api/v1/deployments", "/api/v1/deployments/{namespace}/{name}", "/api/v1/cache/stats", "/api/v1/frontendpages"},
+		},
+		{
+			name:  "step8-api",
+			doc:   step8OpenAPI(),
+			paths: []string{"/api/v2/deployments", "/api/v2/deployments/{namespace}/{name}", "/api/v2/cache/metrics", "/api/v2/cache/search", "/api/v2/ws/deployments"},
+		},
+		{
+			name:  "platform",
+			doc:   platformOpenAPI(),
+			paths: []string{"/api/v1/frontendpages", "/api/v1/frontendpages/{name}", "/api/v2/frontendpages/{namespace}/{name}", "/webhook/port"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			serveOpenAPI(tt.doc)(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+
+			var doc struct {
+				OpenAPI    string                     `json:"openapi"`
+				Paths      map[string]json.RawMessage `json:"paths"`
+				Components struct {
+					Schemas map[string]json.RawMessage `json:"schemas"`
+				} `json:"components"`
+			}
+			body := rec.Body.String()
+			if err := json.Unmarshal([]byte(body), &doc); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if !strings.HasPrefix(doc.OpenAPI, "3.0") {
+				t.Errorf("openapi = %q, want 3.0.x", doc.OpenAPI)
+			}
+			for _, path := range tt.paths {
+				if _, ok := doc.Paths[path]; !ok {
+					t.Errorf("missing path %s", path)
+				}
+			}
+
+			// Every $ref has to resolve to a component schema
+			for _, part := range strings.Split(body, `"$ref":"#/components/schemas/`)[1:] {
+				name := part[:strings.Index(part, `"`)]
+				if _, ok := doc.Components.Schemas[name]; !ok {
+					t.Errorf("dangling $ref to %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPISchemaFromStruct(t *testing.T) {
+	doc := step8OpenAPI()
+
+	detail := doc.Components.Schemas["DeploymentDetail"]
+	if detail == nil {
+		t.Fatal("DeploymentDetail schema missing")
+	}
+	// The embedded DeploymentSummary is flattened like encoding/json does
+	for _, property := range []string{"name", "namespace", "ready_replicas", "creation_time", "conditions", "strategy"} {
+		if detail.Properties[property] == nil {
+			t.Errorf("DeploymentDetail is missing property %q", property)
+		}
+	}
+	if got := detail.Properties["creation_time"].Format; got != "date-time" {
+		t.Errorf("creation_time format = %q, want date-time", got)
+	}
+	if got := detail.Properties["conditions"].Items.Ref; got != "#/components/schemas/DeploymentCondition" {
+		t.Errorf("conditions items = %q, want DeploymentCondition ref", got)
+	}
+
+	required := strings.Join(detail.Required, ",")
+	if !strings.Contains(required, "name") || strings.Contains(required, "image") {
+		t.Errorf("required = %v, want name but not the omitempty image", detail.Required)
+	}
+
+	code := doc.Components.Schemas["Step8APIResponse"].Properties["code"]
+	if len(code.Enum) != len(openAPIErrorCodes) {
+		t.Errorf("code enum = %v, want the error codes", code.Enum)
+	}
+}
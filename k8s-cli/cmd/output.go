@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"k8s-cli/internal/utils"
+)
+
+// resultWriter is where --log-json result objects go; tests swap it out
+var resultWriter io.Writer = os.Stdout
+
+// infof prints the human-oriented progress and summary lines of the imperative
+// commands. They are dropped with --quiet, with --log-json, where stdout carries
+// only result objects, and with -o name, where it carries only resource names.
+func infof(format string, args ...interface{}) {
+	if viper.GetBool("quiet") || viper.GetBool("log-json") || viper.GetString("output") == "name" {
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// printCreatedName prints "<resource>.<group>/<name>" for -o name so the result can be piped on
+func printCreatedName(resource, group, name string) {
+	if viper.GetString("output") == "name" {
+		fmt.Println(utils.ResourceName(resource, group, name))
+	}
+}
+
+// reportResult emits the outcome of an action as a JSON line when --log-json is set
+func reportResult(result utils.ActionResult, err error) {
+	if !viper.GetBool("log-json") {
+		return
+	}
+	if err != nil {
+		result.Result = "failed"
+		result.Error = err.Error()
+	}
+	utils.PrintActionResult(resultWriter, result)
+}
+
+// listOutputFormat makes list commands print JSON with --log-json
+func listOutputFormat() string {
+	if viper.GetBool("log-json") {
+		return "json"
+	}
+	return viper.GetString("output")
+}
+
+// hideManagedFields strips managedFields from listed objects unless
+// --show-managed-fields asks for them, e.g. to debug server-side apply
+func hideManagedFields[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) {
+	if viper.GetBool("show-managed-fields") {
+		return
+	}
+	utils.StripManagedFields[T, PT](items)
+}
+
+// yamlObjectRef reads kind and name of the first object in a manifest for result reporting
+func yamlObjectRef(yamlData []byte) (kind, name string) {
+	var obj unstructured.Unstructured
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(yamlData), 4096).Decode(&obj); err != nil {
+		return "", ""
+	}
+	return obj.GetKind(), obj.GetName()
+}
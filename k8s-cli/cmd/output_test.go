@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/viper"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-cli/internal/utils"
+)
+
+func TestReportResult(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(w io.Writer) { resultWriter = w }(resultWriter)
+	resultWriter = &buf
+	defer viper.Set("log-json", false)
+
+	result := utils.ActionResult{Action: "create", Kind: "Deployment", Name: "x", Namespace: "default", Result: "created"}
+
+	viper.Set("log-json", false)
+	reportResult(result, nil)
+	if buf.Len() != 0 {
+		t.Fatalf("result printed without --log-json: %q", buf.String())
+	}
+
+	viper.Set("log-json", true)
+	reportResult(result, nil)
+	reportResult(result, errors.New("already exists"))
+
+	want := `{"action":"create","kind":"Deployment","name":"x","namespace":"default","result":"created"}
+{"action":"create","kind":"Deployment","name":"x","namespace":"default","result":"failed","error":"already exists"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestYAMLObjectRef(t *testing.T) {
+	kind, name := yamlObjectRef([]byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"))
+	if kind != "Deployment" || name != "web" {
+		t.Errorf("yamlObjectRef() = %q, %q, want Deployment, web", kind, name)
+	}
+}
+
+func TestHideManagedFields(t *testing.T) {
+	defer viper.Set("show-managed-fields", false)
+	managed := []metav1.ManagedFieldsEntry{{Manager: "k8s-cli", Operation: metav1.ManagedFieldsOperationApply}}
+
+	for _, show := range []bool{false, true} {
+		viper.Set("show-managed-fields", show)
+		deployments := []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "web", ManagedFields: managed}}}
+
+		hideManagedFields(deployments)
+		if kept := deployments[0].ManagedFields != nil; kept != show {
+			t.Errorf("show-managed-fields=%v: managedFields kept = %v", show, kept)
+		}
+	}
+}
@@ -3,27 +3,41 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	k8scliv1 "k8s-cli/api/v1"
+	"k8s-cli/controllers"
 )
 
 var (
@@ -34,6 +48,39 @@ var (
 	enableWebhooks    bool
 	discordWebhookURL string
 
+	// Bearer token required for mutating FrontendPage CRUD requests; empty disables auth
+	platformAPIToken string
+
+	// Actions that trigger Discord notifications; empty means all
+	discordNotifyActions []string
+
+	// Batch Discord notifications over this window; zero sends each one immediately
+	discordBatchWindow time.Duration
+
+	platformShutdownTimeout time.Duration
+
+	// Deadline for the Kubernetes API calls made while serving one request
+	platformAPICallTimeout time.Duration
+
+	// Largest JSON request body accepted by the write endpoints
+	platformMaxBodyBytes int64
+
+	// Longest a create asked to wait blocks for the page to become Ready
+	platformWaitTimeout time.Duration
+
+	// Actions processed at once, and how long a request waits for a slot before a 429
+	platformMaxConcurrentActions int
+	platformActionQueueTimeout   time.Duration
+
+	// Replica bounds enforced on scale_frontend; a max of 0 means no upper bound
+	platformMinReplicas          int
+	platformMaxReplicas          int
+	platformNamespaceMaxReplicas map[string]int
+
+	// Append-only JSON lines audit log of processed actions, rotated at the size in MiB
+	platformAuditLog        string
+	platformAuditLogMaxSize int64
+
 	// Platform scheme
 	platformScheme = runtime.NewScheme()
 )
@@ -45,12 +92,40 @@ func init() {
 
 // Step 12: Platform Engineering API based on Port.io
 type PlatformAPI struct {
-	client        client.Client
-	scheme        *runtime.Scheme
-	portClient    *PortClient
-	discordClient *DiscordClient
+	client         client.Client
+	apiReader      client.Reader
+	scheme         *runtime.Scheme
+	portClient     *PortClient
+	discordClient  *DiscordClient
+	discordBatcher *DiscordBatcher
+	notifyActions  map[string]bool
+	apiToken       string
+	apiCallTimeout time.Duration
+	maxBodyBytes   int64
+	waitTimeout    time.Duration
+	auditLog       *AuditLogger
+
+	// namespace holds the FrontendPages of the actions and v1 endpoints; empty means "default"
+	namespace string
+
+	minReplicas          int
+	maxReplicas          int
+	namespaceMaxReplicas map[string]int
+
+	// actionSlots bounds concurrent action processing; nil means unlimited
+	actionSlots        chan struct{}
+	actionQueueTimeout time.Duration
+
+	server       *http.Server
+	shutdownDone chan struct{}
+
+	// notifications tracks in-flight Discord sends so Shutdown can wait for them
+	notifications sync.WaitGroup
 }
 
+// portRequestTimeout bounds each Port.io API call, including reading the response
+const portRequestTimeout = 30 * time.Second
+
 // Port.io API Client
 type PortClient struct {
 	BaseURL    string
@@ -65,12 +140,15 @@ type DiscordClient struct {
 }
 
 // Port.io Action structures
+// PortAction.Inputs maps input names to their type ("string" or "number");
+// Required lists the inputs that must be present and non-empty.
 type PortAction struct {
 	Identifier  string                 `json:"identifier"`
 	Title       string                 `json:"title"`
 	Trigger     string                 `json:"trigger"`
 	Description string                 `json:"description"`
 	Inputs      map[string]interface{} `json:"inputs"`
+	Required    []string               `json:"required,omitempty"`
 	Run         string                 `json:"run"`
 }
 
@@ -80,13 +158,19 @@ type ActionRequest struct {
 	Trigger    string                 `json:"trigger"`
 	Inputs     map[string]interface{} `json:"inputs"`
 	Context    map[string]interface{} `json:"context"`
+	// DryRun computes the would-be result without creating, updating or deleting anything
+	DryRun bool `json:"dryRun,omitempty"`
+	// Wait makes create_frontend block until the page is Ready, up to --wait-timeout
+	Wait bool `json:"wait,omitempty"`
 }
 
 type ActionResponse struct {
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Logs    []string    `json:"logs,omitempty"`
+	Status    string      `json:"status"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data,omitempty"`
+	Logs      []string    `json:"logs,omitempty"`
+	DryRun    bool        `json:"dryRun,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 // Discord message structure
@@ -109,11 +193,26 @@ type DiscordEmbedField struct {
 	Inline bool   `json:"inline"`
 }
 
-func NewPlatformAPI(client client.Client, scheme *runtime.Scheme) *PlatformAPI {
+// defaultNamespace is where the actions and v1 endpoints, which name pages
+// without a namespace, find them: -n or the kubeconfig context's namespace
+func (p *PlatformAPI) defaultNamespace() string {
+	if p.namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return p.namespace
+}
+
+// platformWriteTimeout is how long the platform API server has to write a
+// response, so it bounds --wait-timeout
+const platformWriteTimeout = 30 * time.Second
+
+// NewPlatformAPI takes the manager's cached client for handlers and an uncached
+// apiReader so readiness checks reach the Kubernetes API itself.
+func NewPlatformAPI(client client.Client, apiReader client.Reader, scheme *runtime.Scheme) *PlatformAPI {
 	portClient := &PortClient{
 		BaseURL:    portBaseURL,
 		Token:      portAPIToken,
-		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		HTTPClient: &http.Client{Timeout: portRequestTimeout},
 	}
 
 	var discordClient *DiscordClient
@@ -124,58 +223,151 @@ func NewPlatformAPI(client client.Client, scheme *runtime.Scheme) *PlatformAPI {
 		}
 	}
 
-	return &PlatformAPI{
-		client:        client,
-		scheme:        scheme,
-		portClient:    portClient,
-		discordClient: discordClient,
+	p := &PlatformAPI{
+		client:         client,
+		apiReader:      apiReader,
+		scheme:         scheme,
+		portClient:     portClient,
+		discordClient:  discordClient,
+		shutdownDone:   make(chan struct{}),
+		apiToken:       platformAPIToken,
+		apiCallTimeout: platformAPICallTimeout,
+		maxBodyBytes:   platformMaxBodyBytes,
+		waitTimeout:    platformWaitTimeout,
+		namespace:      viper.GetString("namespace"),
+
+		minReplicas:          platformMinReplicas,
+		maxReplicas:          platformMaxReplicas,
+		namespaceMaxReplicas: platformNamespaceMaxReplicas,
+		actionQueueTimeout:   platformActionQueueTimeout,
+	}
+
+	if platformMaxConcurrentActions > 0 {
+		p.actionSlots = make(chan struct{}, platformMaxConcurrentActions)
+	}
+
+	if discordClient != nil && discordBatchWindow > 0 {
+		p.discordBatcher = NewDiscordBatcher(discordClient, discordBatchWindow)
+	}
+
+	if len(discordNotifyActions) > 0 {
+		p.notifyActions = make(map[string]bool, len(discordNotifyActions))
+		for _, action := range discordNotifyActions {
+			p.notifyActions[strings.TrimSpace(action)] = true
+		}
+	}
+
+	p.server = &http.Server{
+		Addr:         fmt.Sprintf(":%d", platformPort),
+		Handler:      p.routes(),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: platformWriteTimeout,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	return p
+}
+
+// requestContext derives the context for a handler's Kubernetes API calls from
+// the request, so a hung API server fails the request instead of wedging it.
+func (p *PlatformAPI) requestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	if p.apiCallTimeout <= 0 {
+		return context.WithCancel(r.Context())
 	}
+	return context.WithTimeout(r.Context(), p.apiCallTimeout)
 }
 
 // Step 12: API handlers for CRUD actions
-func (p *PlatformAPI) StartServer() {
+func (p *PlatformAPI) routes() http.Handler {
 	mux := http.NewServeMux()
 
 	// Platform engineering endpoints
 	mux.HandleFunc("/", p.handleRoot)
-	mux.HandleFunc("/webhook/port", p.handlePortWebhook)
+	// The webhook runs the same actions as the CRUD endpoints, so it needs the token too
+	mux.Handle("/webhook/port", p.requireToken(http.HandlerFunc(p.handlePortWebhook)))
 	mux.HandleFunc("/api/v1/actions", p.handleActions)
 
-	// CRUD endpoints for FrontendPage
-	mux.HandleFunc("/api/v1/frontendpages", p.handleFrontendPages)
-	mux.HandleFunc("/api/v1/frontendpages/", p.handleFrontendPageByName)
+	// CRUD endpoints for FrontendPage; writes need the --api-token bearer token
+	mux.Handle("/api/v1/frontendpages", p.requireToken(http.HandlerFunc(p.handleFrontendPages)))
+	mux.Handle("/api/v1/frontendpages/", p.requireToken(http.HandlerFunc(p.handleFrontendPageByName)))
 
 	// Step 12+: Update action support
-	mux.HandleFunc("/api/v1/frontendpages/update", p.handleUpdateAction)
+	mux.Handle("/api/v1/frontendpages/update", p.requireToken(http.HandlerFunc(p.handleUpdateAction)))
+
+	// Namespaced upsert: create if absent, update if present
+	mux.Handle("/api/v2/frontendpages/", p.requireToken(http.HandlerFunc(p.handleFrontendPageUpsert)))
 
 	// Health and metrics
 	mux.HandleFunc("/health", p.handleHealth)
-	mux.HandleFunc("/metrics", p.handleMetrics)
-
-	// Enable CORS
-	handler := p.enableCORS(mux)
+	mux.HandleFunc("/health/live", p.handleLiveness)
+	mux.HandleFunc("/health/ready", p.handleHealth)
+	mux.Handle("/metrics", metricsHandler())
+	mux.HandleFunc("/openapi.json", serveOpenAPI(platformOpenAPI()))
 
-	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", platformPort),
-		Handler:      handler,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		IdleTimeout:  120 * time.Second,
-	}
+	// Enable CORS, request IDs and client addresses for the audit log
+	return withRequestID(withAuditRemoteAddr(p.enableCORS(mux)))
+}
 
+// StartServer blocks until the server fails to start or a Shutdown call has completed
+func (p *PlatformAPI) StartServer() {
 	log.Printf("🌐 Starting Platform Engineering API on port %d", platformPort)
 	log.Printf("📋 Available endpoints:")
 	log.Printf("  POST /webhook/port - Port.io webhook handler")
 	log.Printf("  GET  /api/v1/actions - List available actions")
-	log.Printf("  GET  /api/v1/frontendpages - List FrontendPages")
+	log.Printf("  GET  /api/v1/frontendpages - List FrontendPages (?limit=&continue=&phase=&sortBy=name|age&order=)")
 	log.Printf("  POST /api/v1/frontendpages - Create FrontendPage")
-	log.Printf("  PUT  /api/v1/frontendpages/{name} - Update FrontendPage")
+	log.Printf("  PUT  /api/v1/frontendpages/{name} - Replace FrontendPage spec")
+	log.Printf("  PATCH /api/v1/frontendpages/{name} - Merge-patch FrontendPage fields")
 	log.Printf("  DELETE /api/v1/frontendpages/{name} - Delete FrontendPage")
 	log.Printf("  POST /api/v1/frontendpages/update - Update action support")
+	log.Printf("  PUT  /api/v2/frontendpages/{namespace}/{name} - Create or update FrontendPage")
+	log.Printf("  GET  /health/live - Liveness probe")
+	log.Printf("  GET  /health/ready - Readiness probe with dependency checks")
+	log.Printf("  GET  /openapi.json - OpenAPI 3.0 specification")
 
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := p.server.ListenAndServe(); err != http.ErrServerClosed {
 		log.Printf("❌ Platform API server failed: %v", err)
+		return
+	}
+
+	// ListenAndServe returns as soon as Shutdown starts; wait for draining to finish
+	<-p.shutdownDone
+}
+
+// Shutdown stops accepting requests, waits for in-flight handlers and Discord
+// notifications to complete, and gives up when ctx expires.
+func (p *PlatformAPI) Shutdown(ctx context.Context) error {
+	defer close(p.shutdownDone)
+
+	err := p.server.Shutdown(ctx)
+
+	// Handlers have returned by now, so no new notifications can be queued
+	notified := make(chan struct{})
+	go func() {
+		p.notifications.Wait()
+		close(notified)
+	}()
+
+	select {
+	case <-notified:
+	case <-ctx.Done():
+		if err == nil {
+			err = fmt.Errorf("waiting for Discord notifications: %w", ctx.Err())
+		}
+	}
+
+	// Flush whatever is still waiting in the batch window
+	if p.discordBatcher != nil {
+		if batchErr := p.discordBatcher.Close(ctx); batchErr != nil && err == nil {
+			err = batchErr
+		}
+	}
+
+	if auditErr := p.auditLog.Close(); auditErr != nil && err == nil {
+		err = fmt.Errorf("closing audit log: %w", auditErr)
 	}
+
+	return err
 }
 
 func (p *PlatformAPI) handleRoot(w http.ResponseWriter, r *http.Request) {
@@ -194,12 +386,16 @@ func (p *PlatformAPI) handleRoot(w http.ResponseWriter, r *http.Request) {
 			"webhook":       "/webhook/port",
 			"actions":       "/api/v1/actions",
 			"frontendpages": "/api/v1/frontendpages",
+			"upsert":        "/api/v2/frontendpages/{namespace}/{name}",
 			"health":        "/health",
+			"liveness":      "/health/live",
+			"readiness":     "/health/ready",
 			"metrics":       "/metrics",
+			"openapi":       "/openapi.json",
 		},
 	}
 
-	p.writeJSONResponse(w, response)
+	p.writeJSONResponse(w, r, response)
 }
 
 // Step 12: Port.io webhook handler
@@ -209,39 +405,126 @@ func (p *PlatformAPI) handlePortWebhook(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+	var actionReq ActionRequest
+	if !p.decodeJSONBody(w, r, &actionReq) {
 		return
 	}
 
-	var actionReq ActionRequest
-	if err := json.Unmarshal(body, &actionReq); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	release, ok := p.acquireActionSlot(r.Context())
+	if !ok {
+		p.writeTooManyActions(w, r)
 		return
 	}
+	defer release()
 
-	log.Printf("📨 Step 12: Received Port.io action: %s", actionReq.Action)
-	log.Printf("   Resource ID: %s", actionReq.ResourceId)
-	log.Printf("   Trigger: %s", actionReq.Trigger)
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+	logRequestf(ctx, "📨 Step 12: Received Port.io action: %s", actionReq.Action)
+	logRequestf(ctx, "   Resource ID: %s", actionReq.ResourceId)
+	logRequestf(ctx, "   Trigger: %s", actionReq.Trigger)
 
 	// Process the action
-	response, err := p.processAction(r.Context(), &actionReq)
+	response, err := p.processAction(ctx, &actionReq)
 	if err != nil {
-		log.Printf("❌ Failed to process action: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		logRequestf(ctx, "❌ Failed to process action: %v", err)
+		p.writeActionError(w, r, err)
 		return
 	}
 
 	// Send Discord notification if configured
-	if p.discordClient != nil {
-		go p.sendDiscordNotification(&actionReq, response)
-	}
+	p.notifyDiscord(requestIDFromContext(ctx), &actionReq, response)
 
-	p.writeJSONResponse(w, response)
+	p.writeJSONResponse(w, r, response)
 }
 
+// processAction validates inputs against the action schema, runs the action and reports the
+// outcome to Port.io. Invalid inputs return an *ActionValidationError without running anything.
 func (p *PlatformAPI) processAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+	start := time.Now()
+	platformActionAttempts.WithLabelValues(actionMetricLabel(req.Action)).Inc()
+
+	if action, ok := findPortAction(req.Action); ok {
+		if failures := validateActionInputs(action, req.Inputs); len(failures) > 0 {
+			err := &ActionValidationError{Action: req.Action, Failures: failures}
+			p.reportActionRun(ctx, req, nil, err)
+			p.auditAction(ctx, req, nil, err)
+			observeAction(req.Action, start, nil, err)
+			return nil, err
+		}
+	}
+
+	response, err := p.dispatchAction(ctx, req)
+	if req.DryRun && response != nil {
+		response.DryRun = true
+		response.Message = "[DRY RUN] " + response.Message
+		response.Logs = append([]string{"DRY RUN: no changes were made to the cluster"}, response.Logs...)
+	}
+	p.reportActionRun(ctx, req, response, err)
+	p.auditAction(ctx, req, response, err)
+	observeAction(req.Action, start, response, err)
+	return response, err
+}
+
+// acquireActionSlot waits up to actionQueueTimeout for one of the
+// --max-concurrent-actions slots, so a burst of Port.io runs queues briefly
+// instead of hitting the API server all at once. It returns false when no slot
+// frees up in time or the client goes away; otherwise release must be called.
+func (p *PlatformAPI) acquireActionSlot(ctx context.Context) (release func(), ok bool) {
+	if p.actionSlots == nil {
+		platformActionsInFlight.Inc()
+		return platformActionsInFlight.Dec, true
+	}
+	acquired := func() (func(), bool) {
+		platformActionsInFlight.Inc()
+		return func() {
+			platformActionsInFlight.Dec()
+			<-p.actionSlots
+		}, true
+	}
+
+	select {
+	case p.actionSlots <- struct{}{}:
+		return acquired()
+	default:
+	}
+
+	if p.actionQueueTimeout > 0 {
+		timer := time.NewTimer(p.actionQueueTimeout)
+		defer timer.Stop()
+		select {
+		case p.actionSlots <- struct{}{}:
+			return acquired()
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	platformActionsRejected.Inc()
+	return nil, false
+}
+
+// writeTooManyActions answers 429 with a Retry-After hint
+func (p *PlatformAPI) writeTooManyActions(w http.ResponseWriter, r *http.Request) {
+	logRequestf(r.Context(), "🚦 Rejected action: all %d action slots busy", cap(p.actionSlots))
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, "Too many actions in progress, retry later", http.StatusTooManyRequests)
+}
+
+// writeActionError answers 400 with the failures for invalid inputs and 500 otherwise
+func (p *PlatformAPI) writeActionError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *ActionValidationError
+	if errors.As(err, &validationErr) {
+		p.writeJSONStatus(w, r, http.StatusBadRequest, &ActionResponse{
+			Status:  "error",
+			Message: fmt.Sprintf("Invalid inputs for action %s", validationErr.Action),
+			Logs:    validationErr.Failures,
+		})
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (p *PlatformAPI) dispatchAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
 	switch req.Action {
 	case "create_frontend":
 		return p.createFrontendPageAction(ctx, req)
@@ -259,8 +542,39 @@ func (p *PlatformAPI) processAction(ctx context.Context, req *ActionRequest) (*A
 	}
 }
 
+// reportActionRun tells Port.io how an action run ended so the outcome shows in the Port UI.
+// Runs triggered outside Port (no context.runId) and unconfigured clients are skipped.
+func (p *PlatformAPI) reportActionRun(ctx context.Context, req *ActionRequest, response *ActionResponse, actionErr error) {
+	runID, _ := req.Context["runId"].(string)
+	if runID == "" || !p.portClient.Enabled() {
+		return
+	}
+
+	status := PortRunStatusSuccess
+	var logs []string
+	switch {
+	case actionErr != nil:
+		status = PortRunStatusFailure
+		logs = []string{actionErr.Error()}
+	case response != nil:
+		if response.Status == "error" {
+			status = PortRunStatusFailure
+		}
+		logs = append(logs, response.Logs...)
+		if response.Message != "" {
+			logs = append(logs, response.Message)
+		}
+	}
+
+	if err := p.portClient.ReportActionRun(ctx, runID, status, logs); err != nil {
+		logRequestf(ctx, "❌ Failed to report action run %s to Port.io: %v", runID, err)
+		return
+	}
+	logRequestf(ctx, "✅ Reported action run %s to Port.io as %s", runID, status)
+}
+
 func (p *PlatformAPI) createFrontendPageAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
-	log.Printf("🔨 Step 12: Creating FrontendPage from Port.io action")
+	logRequestf(ctx, "🔨 Step 12: Creating FrontendPage from Port.io action")
 
 	// Extract inputs
 	name, _ := req.Inputs["name"].(string)
@@ -281,7 +595,7 @@ func (p *PlatformAPI) createFrontendPageAction(ctx context.Context, req *ActionR
 	frontendPage := &k8scliv1.FrontendPage{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: p.defaultNamespace(),
 			Labels: map[string]string{
 				"created-by": "port-io",
 				"action":     req.Action,
@@ -300,6 +614,27 @@ func (p *PlatformAPI) createFrontendPageAction(ctx context.Context, req *ActionR
 		},
 	}
 
+	if req.DryRun {
+		// A server-side dry run goes through validation, admission and the
+		// AlreadyExists check like the real create, but persists nothing
+		if err := p.client.Create(ctx, frontendPage, client.DryRunAll); err != nil {
+			return &ActionResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("FrontendPage '%s' would not be created: %v", name, err),
+			}, err
+		}
+		return &ActionResponse{
+			Status:  "success",
+			Message: fmt.Sprintf("FrontendPage '%s' would be created", name),
+			Data:    frontendPage,
+			Logs: []string{
+				fmt.Sprintf("Would create FrontendPage: %s", name),
+				fmt.Sprintf("Title: %s", title),
+				fmt.Sprintf("Path: %s", path),
+			},
+		}, nil
+	}
+
 	if err := p.client.Create(ctx, frontendPage); err != nil {
 		return &ActionResponse{
 			Status:  "error",
@@ -307,25 +642,53 @@ func (p *PlatformAPI) createFrontendPageAction(ctx context.Context, req *ActionR
 		}, err
 	}
 
-	return &ActionResponse{
+	data := map[string]interface{}{
+		"name":      name,
+		"namespace": frontendPage.Namespace,
+		"title":     title,
+	}
+	response := &ActionResponse{
 		Status:  "success",
 		Message: fmt.Sprintf("FrontendPage '%s' created successfully", name),
-		Data: map[string]interface{}{
-			"name":      name,
-			"namespace": frontendPage.Namespace,
-			"title":     title,
-		},
+		Data:    data,
 		Logs: []string{
 			fmt.Sprintf("Created FrontendPage: %s", name),
 			fmt.Sprintf("Title: %s", title),
 			fmt.Sprintf("Path: %s", path),
 		},
-	}, nil
+	}
+	if !req.Wait {
+		return response, nil
+	}
+
+	// The page exists either way, so a timeout is reported as a failed run
+	// rather than an error that would suggest nothing was created
+	ready, err := p.waitForFrontendPage(ctx, frontendPage)
+	if err != nil {
+		response.Status = "error"
+		response.Message = fmt.Sprintf("FrontendPage '%s' was created but is not ready: %v", name, err)
+		response.Logs = append(response.Logs, fmt.Sprintf("Not ready: %v", err))
+		return response, nil
+	}
+	response.Message = fmt.Sprintf("FrontendPage '%s' created and ready at %s", name, ready.Status.URL)
+	data["url"] = ready.Status.URL
+	response.Logs = append(response.Logs, fmt.Sprintf("Ready at: %s", ready.Status.URL))
+	return response, nil
+}
+
+// waitForFrontendPage waits up to waitTimeout for page to become Ready
+func (p *PlatformAPI) waitForFrontendPage(ctx context.Context, page *k8scliv1.FrontendPage) (*k8scliv1.FrontendPage, error) {
+	if p.waitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.waitTimeout)
+		defer cancel()
+	}
+	return waitForFrontendPageReady(ctx, p.client, client.ObjectKeyFromObject(page))
 }
 
 // Step 12+: Update action support
 func (p *PlatformAPI) updateFrontendPageAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
-	log.Printf("🔄 Step 12+: Updating FrontendPage from Port.io action")
+	logRequestf(ctx, "🔄 Step 12+: Updating FrontendPage from Port.io action")
 
 	name, _ := req.Inputs["name"].(string)
 	if name == "" {
@@ -337,40 +700,16 @@ func (p *PlatformAPI) updateFrontendPageAction(ctx context.Context, req *ActionR
 
 	// Get existing FrontendPage
 	var frontendPage k8scliv1.FrontendPage
-	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &frontendPage); err != nil {
+	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: p.defaultNamespace()}, &frontendPage); err != nil {
 		return &ActionResponse{
 			Status:  "error",
 			Message: fmt.Sprintf("FrontendPage not found: %v", err),
 		}, err
 	}
 
-	// Update fields if provided
-	updated := false
-	logs := []string{fmt.Sprintf("Updating FrontendPage: %s", name)}
-
-	if title, ok := req.Inputs["title"].(string); ok && title != "" {
-		frontendPage.Spec.Title = title
-		updated = true
-		logs = append(logs, fmt.Sprintf("Updated title: %s", title))
-	}
-
-	if description, ok := req.Inputs["description"].(string); ok && description != "" {
-		frontendPage.Spec.Description = description
-		updated = true
-		logs = append(logs, fmt.Sprintf("Updated description: %s", description))
-	}
-
-	if replicas, ok := req.Inputs["replicas"].(float64); ok && replicas > 0 {
-		frontendPage.Spec.Replicas = int32(replicas)
-		updated = true
-		logs = append(logs, fmt.Sprintf("Updated replicas: %d", int32(replicas)))
-	}
-
-	if image, ok := req.Inputs["image"].(string); ok && image != "" {
-		frontendPage.Spec.Image = image
-		updated = true
-		logs = append(logs, fmt.Sprintf("Updated image: %s", image))
-	}
+	original := frontendPage.Spec
+	updated, logs := applyFrontendPageUpdates(&frontendPage, req.Inputs)
+	logs = append([]string{fmt.Sprintf("Updating FrontendPage: %s", name)}, logs...)
 
 	if !updated {
 		return &ActionResponse{
@@ -380,8 +719,24 @@ func (p *PlatformAPI) updateFrontendPageAction(ctx context.Context, req *ActionR
 		}, nil
 	}
 
-	// Update the resource
-	if err := p.client.Update(ctx, &frontendPage); err != nil {
+	if req.DryRun {
+		return &ActionResponse{
+			Status:  "success",
+			Message: fmt.Sprintf("FrontendPage '%s' would be updated", name),
+			Data: map[string]interface{}{
+				"name":      name,
+				"namespace": frontendPage.Namespace,
+				"diff":      frontendPageSpecDiff(original, frontendPage.Spec),
+			},
+			Logs: logs,
+		}, nil
+	}
+
+	// Update the resource, re-applying the inputs on top of the latest version on conflict
+	if err := controllers.UpdateWithRetry(ctx, p.client, p.uncachedReader(), &frontendPage, func() error {
+		applyFrontendPageUpdates(&frontendPage, req.Inputs)
+		return nil
+	}); err != nil {
 		return &ActionResponse{
 			Status:  "error",
 			Message: fmt.Sprintf("Failed to update FrontendPage: %v", err),
@@ -400,8 +755,57 @@ func (p *PlatformAPI) updateFrontendPageAction(ctx context.Context, req *ActionR
 	}, nil
 }
 
+// applyFrontendPageUpdates copies the provided update inputs onto the FrontendPage spec
+func applyFrontendPageUpdates(frontendPage *k8scliv1.FrontendPage, inputs map[string]interface{}) (bool, []string) {
+	updated := false
+	var logs []string
+
+	if title, ok := inputs["title"].(string); ok && title != "" {
+		frontendPage.Spec.Title = title
+		updated = true
+		logs = append(logs, fmt.Sprintf("Updated title: %s", title))
+	}
+
+	if description, ok := inputs["description"].(string); ok && description != "" {
+		frontendPage.Spec.Description = description
+		updated = true
+		logs = append(logs, fmt.Sprintf("Updated description: %s", description))
+	}
+
+	if replicas, ok := inputs["replicas"].(float64); ok && replicas > 0 {
+		frontendPage.Spec.Replicas = int32(replicas)
+		updated = true
+		logs = append(logs, fmt.Sprintf("Updated replicas: %d", int32(replicas)))
+	}
+
+	if image, ok := inputs["image"].(string); ok && image != "" {
+		frontendPage.Spec.Image = image
+		updated = true
+		logs = append(logs, fmt.Sprintf("Updated image: %s", image))
+	}
+
+	return updated, logs
+}
+
+// frontendPageSpecDiff reports the fields an update action changes as {field: {old, new}}
+func frontendPageSpecDiff(before, after k8scliv1.FrontendPageSpec) map[string]interface{} {
+	diff := map[string]interface{}{}
+	change := func(field string, oldValue, newValue interface{}) {
+		if oldValue != newValue {
+			diff[field] = map[string]interface{}{"old": oldValue, "new": newValue}
+		}
+	}
+
+	change("title", before.Title, after.Title)
+	change("description", before.Description, after.Description)
+	change("replicas", before.Replicas, after.Replicas)
+	change("image", before.Image, after.Image)
+
+	return diff
+}
+
 func (p *PlatformAPI) deleteFrontendPageAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
-	log.Printf("🗑️ Step 12: Deleting FrontendPage from Port.io action")
+	logRequestf(ctx, "🗑️ Step 12: Deleting FrontendPage from Port.io action")
 
 	name, _ := req.Inputs["name"].(string)
 	if name == "" {
@@ -414,10 +818,27 @@ func (p *PlatformAPI) deleteFrontendPageAction(ctx context.Context, req *ActionR
 	frontendPage := &k8scliv1.FrontendPage{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: p.defaultNamespace(),
 		},
 	}
 
+	if req.DryRun {
+		if err := p.client.Get(ctx, client.ObjectKeyFromObject(frontendPage), frontendPage); err != nil {
+			return &ActionResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("FrontendPage not found: %v", err),
+			}, err
+		}
+		return &ActionResponse{
+			Status:  "success",
+			Message: fmt.Sprintf("FrontendPage '%s' would be deleted", name),
+			Data:    frontendPage,
+			Logs: []string{
+				fmt.Sprintf("Would delete FrontendPage: %s", name),
+			},
+		}, nil
+	}
+
 	if err := p.client.Delete(ctx, frontendPage); err != nil {
 		return &ActionResponse{
 			Status:  "error",
@@ -435,7 +856,7 @@ func (p *PlatformAPI) deleteFrontendPageAction(ctx context.Context, req *ActionR
 }
 
 func (p *PlatformAPI) scaleFrontendPageAction(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
-	log.Printf("📈 Step 12: Scaling FrontendPage from Port.io action")
+	logRequestf(ctx, "📈 Step 12: Scaling FrontendPage from Port.io action")
 
 	name, _ := req.Inputs["name"].(string)
 	replicas, _ := req.Inputs["replicas"].(float64)
@@ -448,17 +869,57 @@ func (p *PlatformAPI) scaleFrontendPageAction(ctx context.Context, req *ActionRe
 	}
 
 	var frontendPage k8scliv1.FrontendPage
-	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &frontendPage); err != nil {
+	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: p.defaultNamespace()}, &frontendPage); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &ActionResponse{
+				Status:  "error",
+				Message: fmt.Sprintf("FrontendPage '%s' does not exist in namespace %s, nothing to scale", name, p.defaultNamespace()),
+			}, nil
+		}
 		return &ActionResponse{
 			Status:  "error",
-			Message: fmt.Sprintf("FrontendPage not found: %v", err),
+			Message: fmt.Sprintf("Failed to get FrontendPage: %v", err),
 		}, err
 	}
 
-	oldReplicas := frontendPage.Spec.Replicas
-	frontendPage.Spec.Replicas = int32(replicas)
+	if failure := p.checkReplicaBounds(frontendPage.Namespace, replicas); failure != "" {
+		logRequestf(ctx, "🛑 Rejected scaling FrontendPage %s: %s", name, failure)
+		p.notifyGuardrail(requestIDFromContext(ctx), req, failure)
+		return nil, &ActionValidationError{Action: req.Action, Failures: []string{failure}}
+	}
 
-	if err := p.client.Update(ctx, &frontendPage); err != nil {
+	if req.DryRun {
+		return &ActionResponse{
+			Status:  "success",
+			Message: fmt.Sprintf("FrontendPage '%s' would be scaled from %d to %d replicas", name, frontendPage.Spec.Replicas, int32(replicas)),
+			Data: map[string]interface{}{
+				"name":         name,
+				"old_replicas": frontendPage.Spec.Replicas,
+				"new_replicas": int32(replicas),
+			},
+			Logs: []string{
+				fmt.Sprintf("Would scale FrontendPage %s from %d to %d replicas", name, frontendPage.Spec.Replicas, int32(replicas)),
+			},
+		}, nil
+	}
+
+	// Scale through the scale subresource so only spec.replicas is touched.
+	// The resourceVersion pins the write to the replicas we report as old; conflicts are retried.
+	var oldReplicas int32
+	if err := controllers.RetryOnConflict(ctx, p.uncachedReader(), &frontendPage, func() error {
+		oldReplicas = frontendPage.Spec.Replicas
+		scale := &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            frontendPage.Name,
+				Namespace:       frontendPage.Namespace,
+				ResourceVersion: frontendPage.ResourceVersion,
+			},
+			Spec: autoscalingv1.ScaleSpec{
+				Replicas: int32(replicas),
+			},
+		}
+		return p.client.SubResource("scale").Update(ctx, &frontendPage, client.WithSubResourceBody(scale))
+	}); err != nil {
 		return &ActionResponse{
 			Status:  "error",
 			Message: fmt.Sprintf("Failed to scale FrontendPage: %v", err),
@@ -479,6 +940,29 @@ func (p *PlatformAPI) scaleFrontendPageAction(ctx context.Context, req *ActionRe
 	}, nil
 }
 
+// checkReplicaBounds returns why replicas is outside the --min-replicas and
+// --max-replicas bounds for namespace, or "" when the scale is allowed
+func (p *PlatformAPI) checkReplicaBounds(namespace string, replicas float64) string {
+	if replicas != float64(int32(replicas)) {
+		return fmt.Sprintf("replicas must be a whole number, got %v", replicas)
+	}
+	if p.minReplicas > 0 && replicas < float64(p.minReplicas) {
+		return fmt.Sprintf("replicas %d is below the minimum of %d", int32(replicas), p.minReplicas)
+	}
+
+	maxReplicas, overridden := p.namespaceMaxReplicas[namespace]
+	if !overridden {
+		maxReplicas = p.maxReplicas
+	}
+	if maxReplicas > 0 && replicas > float64(maxReplicas) {
+		if overridden {
+			return fmt.Sprintf("replicas %d exceeds the maximum of %d for namespace %s", int32(replicas), maxReplicas, namespace)
+		}
+		return fmt.Sprintf("replicas %d exceeds the maximum of %d", int32(replicas), maxReplicas)
+	}
+	return ""
+}
+
 // CRUD API handlers
 func (p *PlatformAPI) handleFrontendPages(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -503,6 +987,8 @@ func (p *PlatformAPI) handleFrontendPageByName(w http.ResponseWriter, r *http.Re
 		p.getFrontendPage(w, r, name)
 	case http.MethodPut:
 		p.updateFrontendPage(w, r, name)
+	case http.MethodPatch:
+		p.patchFrontendPage(w, r, name)
 	case http.MethodDelete:
 		p.deleteFrontendPage(w, r, name)
 	default:
@@ -510,77 +996,455 @@ func (p *PlatformAPI) handleFrontendPageByName(w http.ResponseWriter, r *http.Re
 	}
 }
 
-func (p *PlatformAPI) listFrontendPages(w http.ResponseWriter, r *http.Request) {
-	var frontendPages k8scliv1.FrontendPageList
-	if err := p.client.List(r.Context(), &frontendPages); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list FrontendPages: %v", err), http.StatusInternalServerError)
-		return
+// decodeJSONBody decodes the request body into v, reading at most maxBodyBytes
+// and rejecting fields v doesn't have, so a typo in a field name fails the
+// request instead of being silently dropped. On failure it writes a 400, or a
+// 413 for an oversized body, and returns false.
+func (p *PlatformAPI) decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := decodeStrictJSON(p.requestBody(w, r), v); err != nil {
+		writeDecodeError(w, err)
+		return false
 	}
-
-	p.writeJSONResponse(w, map[string]interface{}{
-		"status": "success",
-		"data":   frontendPages.Items,
-		"count":  len(frontendPages.Items),
-	})
+	return true
 }
 
-func (p *PlatformAPI) createFrontendPage(w http.ResponseWriter, r *http.Request) {
-	var frontendPage k8scliv1.FrontendPage
-	if err := json.NewDecoder(r.Body).Decode(&frontendPage); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
-		return
-	}
-
-	if err := p.client.Create(r.Context(), &frontendPage); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create FrontendPage: %v", err), http.StatusInternalServerError)
-		return
+// requestBody is r.Body limited to maxBodyBytes
+func (p *PlatformAPI) requestBody(w http.ResponseWriter, r *http.Request) io.Reader {
+	if p.maxBodyBytes > 0 {
+		return http.MaxBytesReader(w, r.Body, p.maxBodyBytes)
 	}
+	return r.Body
+}
 
-	p.writeJSONResponse(w, map[string]interface{}{
-		"status":  "success",
-		"message": "FrontendPage created successfully",
-		"data":    frontendPage,
-	})
+func decodeStrictJSON(body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
 }
 
-func (p *PlatformAPI) getFrontendPage(w http.ResponseWriter, r *http.Request, name string) {
-	var frontendPage k8scliv1.FrontendPage
-	if err := p.client.Get(r.Context(), client.ObjectKey{Name: name, Namespace: "default"}, &frontendPage); err != nil {
-		http.Error(w, fmt.Sprintf("FrontendPage not found: %v", err), http.StatusNotFound)
-		return
+// writeDecodeError answers a request whose body couldn't be read or decoded
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		http.Error(w, fmt.Sprintf("Request body larger than %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		http.Error(w, fmt.Sprintf("Invalid JSON payload: unknown field %s", field), http.StatusBadRequest)
+	case errors.Is(err, io.EOF):
+		http.Error(w, "Invalid JSON payload: request body is empty", http.StatusBadRequest)
+	default:
+		http.Error(w, fmt.Sprintf("Invalid JSON payload: %v", err), http.StatusBadRequest)
 	}
-
-	p.writeJSONResponse(w, map[string]interface{}{
-		"status": "success",
-		"data":   frontendPage,
-	})
 }
 
-func (p *PlatformAPI) updateFrontendPage(w http.ResponseWriter, r *http.Request, name string) {
-	var frontendPage k8scliv1.FrontendPage
-	if err := p.client.Get(r.Context(), client.ObjectKey{Name: name, Namespace: "default"}, &frontendPage); err != nil {
-		http.Error(w, fmt.Sprintf("FrontendPage not found: %v", err), http.StatusNotFound)
+// maxFrontendPageListLimit caps ?limit= so one page can't pull the whole cluster
+const maxFrontendPageListLimit = 500
+
+// listFrontendPages supports ?limit= and ?continue= pagination. Paged requests go
+// through the uncached API reader because the informer cache can't serve continue tokens.
+// ?phase= filters and ?sortBy=name|age with ?order=asc|desc sorts the items; with
+// pagination both apply to each page, as the API server pages in its own order.
+func (p *PlatformAPI) listFrontendPages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	continueToken := query.Get("continue")
+	phase := query.Get("phase")
+
+	sortBy, order := query.Get("sortBy"), query.Get("order")
+	if sortBy != "" && sortBy != "name" && sortBy != "age" {
+		http.Error(w, "sortBy must be name or age", http.StatusBadRequest)
 		return
 	}
-
-	var updateData k8scliv1.FrontendPage
-	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if order != "" && order != "asc" && order != "desc" {
+		http.Error(w, "order must be asc or desc", http.StatusBadRequest)
 		return
 	}
 
-	// Update spec fields
-	frontendPage.Spec = updateData.Spec
+	var limit int64
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+		if limit > maxFrontendPageListLimit {
+			limit = maxFrontendPageListLimit
+		}
+	}
 
-	if err := p.client.Update(r.Context(), &frontendPage); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to update FrontendPage: %v", err), http.StatusInternalServerError)
-		return
+	var reader client.Reader = p.client
+	var opts []client.ListOption
+	if limit > 0 || continueToken != "" {
+		if p.apiReader != nil {
+			reader = p.apiReader
+		}
+		if limit > 0 {
+			opts = append(opts, client.Limit(limit))
+		}
+		if continueToken != "" {
+			opts = append(opts, client.Continue(continueToken))
+		}
 	}
 
-	p.writeJSONResponse(w, map[string]interface{}{
-		"status":  "success",
-		"message": "FrontendPage updated successfully",
-		"data":    frontendPage,
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	var frontendPages k8scliv1.FrontendPageList
+	if err := reader.List(ctx, &frontendPages, opts...); err != nil {
+		if apierrors.IsResourceExpired(err) {
+			http.Error(w, "Continue token expired, restart the listing without it", http.StatusGone)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to list FrontendPages: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	items := filterFrontendPagesByPhase(frontendPages.Items, phase)
+	sortFrontendPages(items, sortBy, order)
+
+	metadata := map[string]interface{}{
+		"continue": frontendPages.Continue,
+	}
+	if limit > 0 {
+		metadata["limit"] = limit
+	}
+	if frontendPages.RemainingItemCount != nil {
+		metadata["remaining_item_count"] = *frontendPages.RemainingItemCount
+	}
+	if phase != "" {
+		metadata["filter_by"] = phase
+	}
+	if sortBy != "" {
+		metadata["sort_by"] = sortBy
+	}
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status":   "success",
+		"data":     items,
+		"count":    len(items),
+		"metadata": metadata,
+	})
+}
+
+// filterFrontendPagesByPhase keeps the items in phase, compared case-insensitively;
+// an empty phase keeps everything
+func filterFrontendPagesByPhase(items []k8scliv1.FrontendPage, phase string) []k8scliv1.FrontendPage {
+	if phase == "" {
+		return items
+	}
+
+	filtered := make([]k8scliv1.FrontendPage, 0, len(items))
+	for _, item := range items {
+		if strings.EqualFold(item.Status.Phase, phase) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// sortFrontendPages sorts like the Step 8 deployments endpoint: by name unless
+// sortBy is "age", where ascending means youngest first. Ties fall back to
+// namespace and name so pages come back in a stable order.
+func sortFrontendPages(items []k8scliv1.FrontendPage, sortBy, order string) {
+	if sortBy == "" {
+		sortBy = "name"
+	}
+
+	less := func(a, b *k8scliv1.FrontendPage) bool {
+		if sortBy == "age" && !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			// Younger objects were created later
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Namespace < b.Namespace
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if order == "desc" {
+			return less(&items[j], &items[i])
+		}
+		return less(&items[i], &items[j])
+	})
+}
+
+// createFrontendPage creates the page in the body. With ?wait=true it then
+// blocks up to --wait-timeout for the page to become Ready and returns its
+// URL; if it isn't ready by then the answer is 202 with status "pending".
+func (p *PlatformAPI) createFrontendPage(w http.ResponseWriter, r *http.Request) {
+	waitReady := false
+	if value := r.URL.Query().Get("wait"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid wait %q: must be true or false", value), http.StatusBadRequest)
+			return
+		}
+		waitReady = parsed
+	}
+
+	var frontendPage k8scliv1.FrontendPage
+	if !p.decodeJSONBody(w, r, &frontendPage) {
+		return
+	}
+	if frontendPage.Namespace == "" {
+		frontendPage.Namespace = p.defaultNamespace()
+	}
+
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	if err := p.client.Create(ctx, &frontendPage); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create FrontendPage: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if !waitReady {
+		p.writeJSONResponse(w, r, map[string]interface{}{
+			"status":  "success",
+			"message": "FrontendPage created successfully",
+			"data":    frontendPage,
+		})
+		return
+	}
+
+	ready, err := p.waitForFrontendPage(ctx, &frontendPage)
+	if err != nil {
+		data := interface{}(frontendPage)
+		if ready != nil {
+			data = ready
+		}
+		p.writeJSONStatus(w, r, http.StatusAccepted, map[string]interface{}{
+			"status":  "pending",
+			"message": fmt.Sprintf("FrontendPage created but not ready: %v", err),
+			"data":    data,
+		})
+		return
+	}
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status":  "success",
+		"message": "FrontendPage created and ready",
+		"url":     ready.Status.URL,
+		"data":    ready,
+	})
+}
+
+func (p *PlatformAPI) getFrontendPage(w http.ResponseWriter, r *http.Request, name string) {
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	var frontendPage k8scliv1.FrontendPage
+	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: p.defaultNamespace()}, &frontendPage); err != nil {
+		http.Error(w, fmt.Sprintf("FrontendPage not found: %v", err), http.StatusNotFound)
+		return
+	}
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status": "success",
+		"data":   frontendPage,
+	})
+}
+
+// updateFrontendPage serves PUT /api/v1/frontendpages/{name}. PUT replaces the
+// whole spec: a field missing from the body is reset, so a body without
+// replicas sets replicas to 0. patchFrontendPage changes single fields.
+func (p *PlatformAPI) updateFrontendPage(w http.ResponseWriter, r *http.Request, name string) {
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	var frontendPage k8scliv1.FrontendPage
+	if err := p.client.Get(ctx, client.ObjectKey{Name: name, Namespace: p.defaultNamespace()}, &frontendPage); err != nil {
+		http.Error(w, fmt.Sprintf("FrontendPage not found: %v", err), http.StatusNotFound)
+		return
+	}
+
+	var updateData k8scliv1.FrontendPage
+	if !p.decodeJSONBody(w, r, &updateData) {
+		return
+	}
+
+	// Update spec fields
+	frontendPage.Spec = updateData.Spec
+
+	if err := p.client.Update(ctx, &frontendPage); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update FrontendPage: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status":  "success",
+		"message": "FrontendPage updated successfully",
+		"data":    frontendPage,
+	})
+}
+
+// patchableMetadataFields are the metadata fields a PATCH may change
+var patchableMetadataFields = map[string]bool{"labels": true, "annotations": true}
+
+// patchFrontendPage serves PATCH /api/v1/frontendpages/{name} with a JSON merge
+// patch (RFC 7386): only the fields in the body change and a field set to null
+// is removed, so {"spec":{"title":"New"}} keeps replicas and image as they are.
+// Only spec, metadata.labels and metadata.annotations can be patched.
+func (p *PlatformAPI) patchFrontendPage(w http.ResponseWriter, r *http.Request, name string) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != string(types.MergePatchType) && mediaType != "application/json") {
+			w.Header().Set("Accept-Patch", string(types.MergePatchType))
+			http.Error(w, "Unsupported patch type, send a JSON merge patch (application/merge-patch+json)", http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+
+	patch, err := io.ReadAll(p.requestBody(w, r))
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	// Decoding into a FrontendPage catches misspelled fields and wrong types
+	// that the API server would otherwise prune or reject with a vague error
+	if err := decodeStrictJSON(bytes.NewReader(patch), &k8scliv1.FrontendPage{}); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if err := checkFrontendPagePatchFields(patch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	frontendPage := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: p.defaultNamespace()},
+	}
+	if err := p.client.Patch(ctx, frontendPage, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		switch {
+		case apierrors.IsNotFound(err):
+			http.Error(w, fmt.Sprintf("FrontendPage not found: %v", err), http.StatusNotFound)
+		case apierrors.IsInvalid(err):
+			http.Error(w, fmt.Sprintf("Invalid patch: %v", err), http.StatusUnprocessableEntity)
+		default:
+			http.Error(w, fmt.Sprintf("Failed to patch FrontendPage: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status":  "success",
+		"message": "FrontendPage patched successfully",
+		"data":    frontendPage,
+	})
+}
+
+// checkFrontendPagePatchFields rejects patches that reach outside spec,
+// metadata.labels and metadata.annotations, or that null out spec or metadata
+func checkFrontendPagePatchFields(patch []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil || fields == nil {
+		return fmt.Errorf("patch must be a JSON object")
+	}
+
+	for field, value := range fields {
+		if field != "spec" && field != "metadata" {
+			return fmt.Errorf("field %q can't be patched, only spec, metadata.labels and metadata.annotations", field)
+		}
+		if string(value) == "null" {
+			return fmt.Errorf("field %q can't be removed", field)
+		}
+		if field != "metadata" {
+			continue
+		}
+
+		var metadata map[string]json.RawMessage
+		if err := json.Unmarshal(value, &metadata); err != nil {
+			return fmt.Errorf("metadata must be a JSON object")
+		}
+		for metadataField := range metadata {
+			if !patchableMetadataFields[metadataField] {
+				return fmt.Errorf("field \"metadata.%s\" can't be patched, only spec, metadata.labels and metadata.annotations", metadataField)
+			}
+		}
+	}
+	return nil
+}
+
+// handleFrontendPageUpsert serves PUT /api/v2/frontendpages/{namespace}/{name}. The body's
+// spec, labels and annotations are applied with CreateOrUpdate, answering 201 when the
+// FrontendPage was created and 200 otherwise, so repeating a request is a no-op.
+func (p *PlatformAPI) handleFrontendPageUpsert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v2/frontendpages/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "Invalid path. Use /api/v2/frontendpages/{namespace}/{name}", http.StatusBadRequest)
+		return
+	}
+	namespace, name := parts[0], parts[1]
+
+	var desired k8scliv1.FrontendPage
+	if !p.decodeJSONBody(w, r, &desired) {
+		return
+	}
+	if (desired.Name != "" && desired.Name != name) || (desired.Namespace != "" && desired.Namespace != namespace) {
+		http.Error(w, "metadata.name/namespace in the body must match the path", http.StatusBadRequest)
+		return
+	}
+
+	frontendPage := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	var result controllerutil.OperationResult
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var err error
+		result, err = controllerutil.CreateOrUpdate(ctx, p.client, frontendPage, func() error {
+			frontendPage.Spec = desired.Spec
+			for key, value := range desired.Labels {
+				if frontendPage.Labels == nil {
+					frontendPage.Labels = map[string]string{}
+				}
+				frontendPage.Labels[key] = value
+			}
+			for key, value := range desired.Annotations {
+				if frontendPage.Annotations == nil {
+					frontendPage.Annotations = map[string]string{}
+				}
+				frontendPage.Annotations[key] = value
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if apierrors.IsInvalid(err) {
+			statusCode = http.StatusUnprocessableEntity
+		}
+		http.Error(w, fmt.Sprintf("Failed to upsert FrontendPage: %v", err), statusCode)
+		return
+	}
+
+	logRequestf(ctx, "🔁 FrontendPage %s/%s upsert: %s", namespace, name, result)
+
+	statusCode := http.StatusOK
+	if result == controllerutil.OperationResultCreated {
+		statusCode = http.StatusCreated
+	}
+
+	p.writeJSONStatus(w, r, statusCode, map[string]interface{}{
+		"status":    "success",
+		"operation": string(result),
+		"data":      frontendPage,
 	})
 }
 
@@ -588,16 +1452,19 @@ func (p *PlatformAPI) deleteFrontendPage(w http.ResponseWriter, r *http.Request,
 	frontendPage := &k8scliv1.FrontendPage{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: "default",
+			Namespace: p.defaultNamespace(),
 		},
 	}
 
-	if err := p.client.Delete(r.Context(), frontendPage); err != nil {
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	if err := p.client.Delete(ctx, frontendPage); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to delete FrontendPage: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	p.writeJSONResponse(w, map[string]interface{}{
+	p.writeJSONResponse(w, r, map[string]interface{}{
 		"status":  "success",
 		"message": "FrontendPage deleted successfully",
 	})
@@ -613,10 +1480,10 @@ func (p *PlatformAPI) handleUpdateAction(w http.ResponseWriter, r *http.Request)
 	var updateReq struct {
 		Name    string                 `json:"name"`
 		Updates map[string]interface{} `json:"updates"`
+		DryRun  bool                   `json:"dryRun"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if !p.decodeJSONBody(w, r, &updateReq) {
 		return
 	}
 
@@ -627,6 +1494,7 @@ func (p *PlatformAPI) handleUpdateAction(w http.ResponseWriter, r *http.Request)
 		Inputs: map[string]interface{}{
 			"name": updateReq.Name,
 		},
+		DryRun: updateReq.DryRun,
 	}
 
 	// Add update fields to inputs
@@ -634,66 +1502,147 @@ func (p *PlatformAPI) handleUpdateAction(w http.ResponseWriter, r *http.Request)
 		actionReq.Inputs[key] = value
 	}
 
-	response, err := p.updateFrontendPageAction(r.Context(), actionReq)
+	release, ok := p.acquireActionSlot(r.Context())
+	if !ok {
+		p.writeTooManyActions(w, r)
+		return
+	}
+	defer release()
+
+	ctx, cancel := p.requestContext(r)
+	defer cancel()
+
+	response, err := p.processAction(ctx, actionReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		p.writeActionError(w, r, err)
 		return
 	}
 
-	p.writeJSONResponse(w, response)
+	p.writeJSONResponse(w, r, response)
 }
 
-func (p *PlatformAPI) handleActions(w http.ResponseWriter, r *http.Request) {
-	actions := []PortAction{
-		{
-			Identifier:  "create_frontend",
-			Title:       "Create Frontend Page",
-			Trigger:     "manual",
-			Description: "Create a new frontend page application",
-			Inputs: map[string]interface{}{
-				"name":        "string",
-				"title":       "string",
-				"description": "string",
-				"path":        "string",
-				"image":       "string",
-				"replicas":    "number",
-			},
+// platformActions is the self-service action catalog advertised to Port.io and used to validate inputs
+var platformActions = []PortAction{
+	{
+		Identifier:  "create_frontend",
+		Title:       "Create Frontend Page",
+		Trigger:     "manual",
+		Description: "Create a new frontend page application",
+		Inputs: map[string]interface{}{
+			"name":        "string",
+			"title":       "string",
+			"description": "string",
+			"path":        "string",
+			"image":       "string",
+			"replicas":    "number",
 		},
-		{
-			Identifier:  "update_frontend",
-			Title:       "Update Frontend Page",
-			Trigger:     "manual",
-			Description: "Update an existing frontend page",
-			Inputs: map[string]interface{}{
-				"name":        "string",
-				"title":       "string",
-				"description": "string",
-				"replicas":    "number",
-				"image":       "string",
-			},
+		Required: []string{"name"},
+	},
+	{
+		Identifier:  "update_frontend",
+		Title:       "Update Frontend Page",
+		Trigger:     "manual",
+		Description: "Update an existing frontend page",
+		Inputs: map[string]interface{}{
+			"name":        "string",
+			"title":       "string",
+			"description": "string",
+			"replicas":    "number",
+			"image":       "string",
 		},
-		{
-			Identifier:  "delete_frontend",
-			Title:       "Delete Frontend Page",
-			Trigger:     "manual",
-			Description: "Delete a frontend page application",
-			Inputs: map[string]interface{}{
-				"name": "string",
-			},
+		Required: []string{"name"},
+	},
+	{
+		Identifier:  "delete_frontend",
+		Title:       "Delete Frontend Page",
+		Trigger:     "manual",
+		Description: "Delete a frontend page application",
+		Inputs: map[string]interface{}{
+			"name": "string",
 		},
-		{
-			Identifier:  "scale_frontend",
-			Title:       "Scale Frontend Page",
-			Trigger:     "manual",
-			Description: "Scale frontend page replicas",
-			Inputs: map[string]interface{}{
-				"name":     "string",
-				"replicas": "number",
-			},
+		Required: []string{"name"},
+	},
+	{
+		Identifier:  "scale_frontend",
+		Title:       "Scale Frontend Page",
+		Trigger:     "manual",
+		Description: "Scale frontend page replicas",
+		Inputs: map[string]interface{}{
+			"name":     "string",
+			"replicas": "number",
 		},
+		Required: []string{"name", "replicas"},
+	},
+}
+
+// findPortAction returns the catalog entry for an action identifier
+func findPortAction(identifier string) (PortAction, bool) {
+	for _, action := range platformActions {
+		if action.Identifier == identifier {
+			return action, true
+		}
+	}
+	return PortAction{}, false
+}
+
+// ActionValidationError lists every input that failed validation against the action schema
+type ActionValidationError struct {
+	Action   string
+	Failures []string
+}
+
+func (e *ActionValidationError) Error() string {
+	return fmt.Sprintf("invalid inputs for action %s: %s", e.Action, strings.Join(e.Failures, "; "))
+}
+
+// validateActionInputs checks required inputs and input types declared in the action schema.
+// Inputs not in the schema are ignored; Port.io may send extra context fields.
+func validateActionInputs(action PortAction, inputs map[string]interface{}) []string {
+	var failures []string
+
+	for _, name := range action.Required {
+		value, ok := inputs[name]
+		if !ok || value == nil {
+			failures = append(failures, fmt.Sprintf("%s is required", name))
+			continue
+		}
+		if str, isString := value.(string); isString && strings.TrimSpace(str) == "" {
+			failures = append(failures, fmt.Sprintf("%s must not be empty", name))
+		}
 	}
 
-	p.writeJSONResponse(w, map[string]interface{}{
+	names := make([]string, 0, len(action.Inputs))
+	for name := range action.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := inputs[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		switch action.Inputs[name] {
+		case "string":
+			if _, isString := value.(string); !isString {
+				failures = append(failures, fmt.Sprintf("%s must be a string, got %T", name, value))
+			}
+		case "number":
+			// JSON numbers decode as float64
+			if _, isNumber := value.(float64); !isNumber {
+				failures = append(failures, fmt.Sprintf("%s must be a number, got %T", name, value))
+			}
+		}
+	}
+
+	return failures
+}
+
+func (p *PlatformAPI) handleActions(w http.ResponseWriter, r *http.Request) {
+	actions := platformActions
+
+	p.writeJSONResponse(w, r, map[string]interface{}{
 		"status":  "success",
 		"actions": actions,
 		"count":   len(actions),
@@ -701,13 +1650,74 @@ func (p *PlatformAPI) handleActions(w http.ResponseWriter, r *http.Request) {
 }
 
 // Step 12++: Discord notifications
-func (p *PlatformAPI) sendDiscordNotification(req *ActionRequest, response *ActionResponse) {
+
+// shouldNotify reports whether an action's result goes to Discord: a webhook must be
+// configured and, when --discord-notify-actions is set, the action must be listed.
+func (p *PlatformAPI) shouldNotify(action string) bool {
 	if p.discordClient == nil {
+		return false
+	}
+	return p.notifyActions == nil || p.notifyActions[action]
+}
+
+// notifyDiscord sends the notification in the background, tracked for Shutdown
+func (p *PlatformAPI) notifyDiscord(requestID string, req *ActionRequest, response *ActionResponse) {
+	if !p.shouldNotify(req.Action) {
+		return
+	}
+
+	if p.discordBatcher != nil {
+		p.discordBatcher.Add(requestID, req, response)
 		return
 	}
 
-	log.Printf("📱 Step 12++: Sending Discord notification for action: %s", req.Action)
+	p.notifications.Add(1)
+	go func() {
+		defer p.notifications.Done()
+		p.sendDiscordNotification(requestID, req, response)
+	}()
+}
 
+// notifyGuardrail sends a Warning notification for an action a guardrail
+// refused. It skips the batch window so the warning isn't delayed.
+func (p *PlatformAPI) notifyGuardrail(requestID string, req *ActionRequest, reason string) {
+	if !p.shouldNotify(req.Action) {
+		return
+	}
+
+	embed := buildDiscordEmbed(requestID, req, &ActionResponse{Status: "rejected", Message: reason})
+	embed.Color = 0xFFA500 // Orange for warnings
+	message := DiscordMessage{
+		Content: "⚠️ k8s-cli Platform guardrail rejected an action",
+		Embeds:  []DiscordEmbed{embed},
+	}
+
+	p.notifications.Add(1)
+	go func() {
+		defer p.notifications.Done()
+		if err := p.discordClient.SendMessage(message); err != nil {
+			logWithRequestID(requestID, "❌ Failed to send Discord warning: %v", err)
+		}
+	}()
+}
+
+// sendDiscordNotification runs after the request has returned, so it takes the request ID rather than its context
+func (p *PlatformAPI) sendDiscordNotification(requestID string, req *ActionRequest, response *ActionResponse) {
+	logWithRequestID(requestID, "📱 Step 12++: Sending Discord notification for action: %s", req.Action)
+
+	message := DiscordMessage{
+		Content: fmt.Sprintf("🤖 k8s-cli Platform Action completed"),
+		Embeds:  []DiscordEmbed{buildDiscordEmbed(requestID, req, response)},
+	}
+
+	if err := p.discordClient.SendMessage(message); err != nil {
+		logWithRequestID(requestID, "❌ Failed to send Discord notification: %v", err)
+	} else {
+		logWithRequestID(requestID, "✅ Discord notification sent successfully")
+	}
+}
+
+func buildDiscordEmbed(requestID string, req *ActionRequest, response *ActionResponse) DiscordEmbed {
 	color := 0x00FF00 // Green for success
 	if response.Status == "error" {
 		color = 0xFF0000 // Red for error
@@ -740,6 +1750,14 @@ func (p *PlatformAPI) sendDiscordNotification(req *ActionRequest, response *Acti
 		})
 	}
 
+	if requestID != "" {
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:   "Request ID",
+			Value:  requestID,
+			Inline: true,
+		})
+	}
+
 	if len(response.Logs) > 0 {
 		logsText := ""
 		for _, logEntry := range response.Logs {
@@ -752,16 +1770,112 @@ func (p *PlatformAPI) sendDiscordNotification(req *ActionRequest, response *Acti
 		})
 	}
 
-	message := DiscordMessage{
-		Content: fmt.Sprintf("🤖 k8s-cli Platform Action completed"),
-		Embeds:  []DiscordEmbed{embed},
+	return embed
+}
+
+// Port.io action run statuses accepted by ReportActionRun
+const (
+	PortRunStatusSuccess = "SUCCESS"
+	PortRunStatusFailure = "FAILURE"
+)
+
+// Enabled reports whether a token is configured; without one every Port.io call would be rejected
+func (pc *PortClient) Enabled() bool {
+	return pc != nil && pc.Token != ""
+}
+
+// UpsertEntity creates or replaces an entity of the given blueprint in the Port.io catalog
+func (pc *PortClient) UpsertEntity(ctx context.Context, blueprint, identifier string, props map[string]interface{}) error {
+	body := map[string]interface{}{
+		"identifier": identifier,
+		"properties": props,
+	}
+	path := fmt.Sprintf("/v1/blueprints/%s/entities?upsert=true", url.PathEscape(blueprint))
+	if err := pc.do(ctx, http.MethodPost, path, body); err != nil {
+		return fmt.Errorf("upserting %s entity %q: %w", blueprint, identifier, err)
 	}
+	return nil
+}
 
-	if err := p.discordClient.SendMessage(message); err != nil {
-		log.Printf("❌ Failed to send Discord notification: %v", err)
-	} else {
-		log.Printf("✅ Discord notification sent successfully")
+// ReportActionRun appends logs to a Port.io action run and then marks it finished with status.
+// It gives up when ctx ends, e.g. because the webhook caller went away.
+func (pc *PortClient) ReportActionRun(ctx context.Context, runID, status string, logs []string) error {
+	runPath := "/v1/actions/runs/" + url.PathEscape(runID)
+
+	for _, message := range logs {
+		if err := pc.do(ctx, http.MethodPost, runPath+"/logs", map[string]string{"message": message}); err != nil {
+			return fmt.Errorf("adding log to action run %q: %w", runID, err)
+		}
+	}
+
+	if err := pc.do(ctx, http.MethodPatch, runPath, map[string]string{"status": status}); err != nil {
+		return fmt.Errorf("updating action run %q status: %w", runID, err)
 	}
+	return nil
+}
+
+// Ping sends a HEAD request to the base URL; any response below 500 means Port.io is reachable
+func (pc *PortClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, pc.BaseURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := pc.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("Port.io responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// httpClient is HTTPClient, or one with portRequestTimeout when none is set,
+// so a hung Port.io API never blocks an action forever
+func (pc *PortClient) httpClient() *http.Client {
+	if pc.HTTPClient == nil {
+		return &http.Client{Timeout: portRequestTimeout}
+	}
+	return pc.HTTPClient
+}
+
+func (pc *PortClient) do(ctx context.Context, method, path string, body interface{}) error {
+	if !pc.Enabled() {
+		return fmt.Errorf("Port.io API token not configured (--port-token)")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(pc.BaseURL, "/")+path, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+pc.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pc.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Port.io access tokens are short-lived; an expired one is the usual cause of a 401
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("Port.io rejected the API token (401 Unauthorized): it may have expired, generate a new access token and pass it via --port-token")
+	}
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Port.io API %s %s failed with status %d: %s", method, path, resp.StatusCode, string(respBody))
+	}
+
+	return nil
 }
 
 func (dc *DiscordClient) SendMessage(message DiscordMessage) error {
@@ -784,12 +1898,220 @@ func (dc *DiscordClient) SendMessage(message DiscordMessage) error {
 	return nil
 }
 
-func (p *PlatformAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
-	p.writeJSONResponse(w, map[string]interface{}{
-		"status":    "healthy",
-		"service":   "k8s-cli Platform Engineering API",
-		"step":      "Step 12/12+/12++",
+// Discord allows at most 25 fields per embed and 1024 characters per field value
+const (
+	discordMaxEmbedFields    = 25
+	discordMaxFieldValueSize = 1024
+)
+
+type discordNotification struct {
+	requestID string
+	req       *ActionRequest
+	response  *ActionResponse
+}
+
+// DiscordBatcher collects action results for a window and posts them as one message,
+// keeping bursts of Port.io actions under Discord's webhook rate limit.
+type DiscordBatcher struct {
+	client *DiscordClient
+	window time.Duration
+	items  chan discordNotification
+	done   chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	dropped int64
+}
+
+func NewDiscordBatcher(client *DiscordClient, window time.Duration) *DiscordBatcher {
+	b := &DiscordBatcher{
+		client: client,
+		window: window,
+		items:  make(chan discordNotification, 100),
+		done:   make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Add queues a result for the current batch. It never blocks: results are
+// dropped while the queue is full or after Close.
+func (b *DiscordBatcher) Add(requestID string, req *ActionRequest, response *ActionResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		b.dropped++
+		log.Printf("⚠️ Discord batcher closed, dropped notification for %s (%d dropped so far)", req.Action, b.dropped)
+		return
+	}
+
+	select {
+	case b.items <- discordNotification{requestID: requestID, req: req, response: response}:
+	default:
+		b.dropped++
+		log.Printf("⚠️ Discord batch queue full, dropped notification for %s (%d dropped so far)", req.Action, b.dropped)
+	}
+}
+
+// Dropped returns how many results were discarded instead of queued
+func (b *DiscordBatcher) Dropped() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Close flushes pending results and waits for the final send or ctx to expire.
+// It is safe to call more than once.
+func (b *DiscordBatcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.items)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("flushing Discord batch: %w", ctx.Err())
+	}
+}
+
+func (b *DiscordBatcher) run() {
+	defer close(b.done)
+
+	var pending []discordNotification
+	var flushTimer <-chan time.Time
+
+	for {
+		select {
+		case item, ok := <-b.items:
+			if !ok {
+				b.flush(pending)
+				return
+			}
+			pending = append(pending, item)
+			// The window starts with the first result of a batch
+			if flushTimer == nil {
+				flushTimer = time.After(b.window)
+			}
+		case <-flushTimer:
+			b.flush(pending)
+			pending = nil
+			flushTimer = nil
+		}
+	}
+}
+
+func (b *DiscordBatcher) flush(pending []discordNotification) {
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > discordMaxEmbedFields {
+			n = discordMaxEmbedFields
+		}
+		chunk := pending[:n]
+		pending = pending[n:]
+
+		log.Printf("📱 Step 12++: Sending batched Discord notification for %d actions", len(chunk))
+		if err := b.client.SendMessage(buildDiscordBatchMessage(chunk)); err != nil {
+			log.Printf("❌ Failed to send batched Discord notification: %v", err)
+		} else {
+			log.Printf("✅ Batched Discord notification sent successfully")
+		}
+	}
+}
+
+// buildDiscordBatchMessage renders one embed with a field per action result
+func buildDiscordBatchMessage(batch []discordNotification) DiscordMessage {
+	color := 0x00FF00 // Green when every action succeeded
+	fields := make([]DiscordEmbedField, 0, len(batch))
+
+	for _, item := range batch {
+		icon := "✅"
+		if item.response.Status == "error" {
+			icon = "❌"
+			color = 0xFF0000 // Red if any action failed
+		}
+
+		value := item.response.Message
+		if item.req.ResourceId != "" {
+			value += "\nResource ID: " + item.req.ResourceId
+		}
+		if item.requestID != "" {
+			value += "\nRequest ID: " + item.requestID
+		}
+		if runes := []rune(value); len(runes) > discordMaxFieldValueSize {
+			value = string(runes[:discordMaxFieldValueSize-3]) + "..."
+		}
+
+		fields = append(fields, DiscordEmbedField{
+			Name:   fmt.Sprintf("%s %s (%s)", icon, item.req.Action, item.req.Trigger),
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	return DiscordMessage{
+		Content: fmt.Sprintf("🤖 k8s-cli Platform Actions completed (%d)", len(batch)),
+		Embeds: []DiscordEmbed{{
+			Title:     fmt.Sprintf("Platform Actions: %d results", len(batch)),
+			Color:     color,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Fields:    fields,
+		}},
+	}
+}
+
+// healthCheckTimeout bounds each dependency probe so a hung dependency can't hang the probe
+const healthCheckTimeout = 5 * time.Second
+
+// DependencyStatus is the result of probing one external dependency
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Critical  bool   `json:"critical"`
+	Healthy   bool   `json:"healthy"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// handleLiveness only reports that the process is serving; it never touches dependencies
+func (p *PlatformAPI) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	p.writeJSONResponse(w, r, map[string]interface{}{
+		"status":    "alive",
 		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// handleHealth serves /health and /health/ready. It returns 503 when a critical
+// dependency (Kubernetes) is unreachable so orchestrators take the pod out of service.
+func (p *PlatformAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
+	dependencies := []DependencyStatus{p.checkKubernetes(r.Context())}
+	if p.portClient.Enabled() {
+		dependencies = append(dependencies, p.checkPort(r.Context()))
+	}
+
+	status := "healthy"
+	statusCode := http.StatusOK
+	for _, dep := range dependencies {
+		if dep.Healthy {
+			continue
+		}
+		if dep.Critical {
+			status = "unhealthy"
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+		status = "degraded"
+	}
+
+	p.writeJSONStatus(w, r, statusCode, map[string]interface{}{
+		"status":       status,
+		"service":      "k8s-cli Platform Engineering API",
+		"step":         "Step 12/12+/12++",
+		"timestamp":    time.Now().Format(time.RFC3339),
+		"dependencies": dependencies,
 		"features": map[string]bool{
 			"port_integration":      portAPIToken != "",
 			"discord_notifications": discordWebhookURL != "",
@@ -800,19 +2122,81 @@ func (p *PlatformAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (p *PlatformAPI) handleMetrics(w http.ResponseWriter, r *http.Request) {
-	// Simple Prometheus-style metrics
-	w.Header().Set("Content-Type", "text/plain")
-	fmt.Fprintf(w, "# HELP k8s_cli_platform_requests_total Total platform API requests\n")
-	fmt.Fprintf(w, "# TYPE k8s_cli_platform_requests_total counter\n")
-	fmt.Fprintf(w, "k8s_cli_platform_requests_total 100\n")
+// uncachedReader is the manager's APIReader, or the client when there is none
+func (p *PlatformAPI) uncachedReader() client.Reader {
+	if p.apiReader == nil {
+		return p.client
+	}
+	return p.apiReader
+}
+
+// checkKubernetes lists a single FrontendPage through the uncached reader
+func (p *PlatformAPI) checkKubernetes(ctx context.Context) DependencyStatus {
+	reader := p.uncachedReader()
+
+	return probeDependency(ctx, "kubernetes", true, func(ctx context.Context) error {
+		return reader.List(ctx, &k8scliv1.FrontendPageList{}, client.Limit(1))
+	})
+}
+
+func (p *PlatformAPI) checkPort(ctx context.Context) DependencyStatus {
+	return probeDependency(ctx, "port.io", false, p.portClient.Ping)
+}
+
+func probeDependency(ctx context.Context, name string, critical bool, probe func(context.Context) error) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := probe(ctx)
+
+	status := DependencyStatus{
+		Name:      name,
+		Critical:  critical,
+		Healthy:   err == nil,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// requireToken rejects POST/PUT/DELETE requests without a matching bearer token.
+// Reads stay open, and everything is allowed when no --api-token is configured.
+func (p *PlatformAPI) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p.apiToken == "" || !isMutatingMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(p.apiToken)) != 1 {
+			logRequestf(r.Context(), "🔒 Rejected unauthenticated %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="k8s-cli-platform"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withAuditActor(r.Context(), "api-token")))
+	})
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
 }
 
 func (p *PlatformAPI) enableCORS(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -823,9 +2207,23 @@ func (p *PlatformAPI) enableCORS(handler http.Handler) http.Handler {
 	})
 }
 
-func (p *PlatformAPI) writeJSONResponse(w http.ResponseWriter, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(data)
+func (p *PlatformAPI) writeJSONResponse(w http.ResponseWriter, r *http.Request, data interface{}) {
+	p.writeJSONStatus(w, r, http.StatusOK, data)
+}
+
+func (p *PlatformAPI) writeJSONStatus(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	// Echo the request ID set by withRequestID in the body as well as the header
+	if requestID := w.Header().Get(requestIDHeader); requestID != "" {
+		switch d := data.(type) {
+		case map[string]interface{}:
+			d["request_id"] = requestID
+		case *ActionResponse:
+			d.RequestID = requestID
+		}
+	}
+	if err := writeJSON(w, r, statusCode, data); err != nil {
+		log.Printf("❌ Error encoding platform response: %v", err)
+	}
 }
 
 // Step 12: Platform command
@@ -851,16 +2249,33 @@ Step 12++ Features:
 • Rich embed messages for action results
 • Configurable notification channels
 • Status updates and logging integration`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validatePlatformWaitTimeout(platformWaitTimeout, platformAPICallTimeout); err != nil {
+			return err
+		}
 		runPlatformAPI()
+		return nil
 	},
 }
 
+// validatePlatformWaitTimeout rejects a --wait-timeout that can't finish: the
+// response has to be written before the server's write timeout, and the wait
+// runs under the request's --api-call-timeout deadline
+func validatePlatformWaitTimeout(waitTimeout, apiCallTimeout time.Duration) error {
+	if waitTimeout <= 0 || waitTimeout >= platformWriteTimeout {
+		return usageErrorf("--wait-timeout %s must be above 0 and under the %s write timeout", waitTimeout, platformWriteTimeout)
+	}
+	if apiCallTimeout > 0 && waitTimeout >= apiCallTimeout {
+		return usageErrorf("--wait-timeout %s must be under --api-call-timeout %s", waitTimeout, apiCallTimeout)
+	}
+	return nil
+}
+
 func runPlatformAPI() {
 	log.Println("🎯 Starting Step 12: Platform Engineering API with Port.io integration...")
 
 	// Setup controller-runtime client
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	setupLogger()
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: platformScheme,
@@ -875,7 +2290,14 @@ func runPlatformAPI() {
 	}
 
 	// Create platform API
-	platformAPI := NewPlatformAPI(mgr.GetClient(), mgr.GetScheme())
+	platformAPI := NewPlatformAPI(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme())
+	if platformAuditLog != "" {
+		auditLog, err := NewAuditLogger(platformAuditLog, platformAuditLogMaxSize*1024*1024)
+		if err != nil {
+			log.Fatalf("❌ Failed to open audit log: %v", err)
+		}
+		platformAPI.auditLog = auditLog
+	}
 
 	// Setup context and signal handling
 	ctx, cancel := context.WithCancel(context.Background())
@@ -885,14 +2307,20 @@ func runPlatformAPI() {
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
 	// Start manager in background
+	managerDone := make(chan struct{})
 	go func() {
+		defer close(managerDone)
 		if err := mgr.Start(ctx); err != nil {
 			log.Fatalf("❌ Manager failed to start: %v", err)
 		}
 	}()
 
 	// Start platform API server
-	go platformAPI.StartServer()
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		platformAPI.StartServer()
+	}()
 
 	log.Println("🎉 Step 12: Platform Engineering API is running!")
 	log.Println("")
@@ -910,10 +2338,34 @@ func runPlatformAPI() {
 
 	if discordWebhookURL != "" {
 		log.Printf("   ✅ Discord notifications enabled")
+		if len(discordNotifyActions) > 0 {
+			log.Printf("   🔔 Discord notifications limited to: %s", strings.Join(discordNotifyActions, ", "))
+		}
+		if discordBatchWindow > 0 {
+			log.Printf("   📦 Discord notifications batched every %v", discordBatchWindow)
+		}
 	} else {
 		log.Printf("   ⚠️ Discord webhook not configured")
 	}
 
+	if platformAPIToken != "" {
+		log.Printf("   🔒 CRUD write endpoints require a bearer token")
+	} else {
+		log.Printf("   ⚠️⚠️⚠️ WARNING: --api-token not set, webhook and FrontendPage CRUD endpoints are UNAUTHENTICATED ⚠️⚠️⚠️")
+		log.Printf("   ⚠️ Anyone who can reach port %d can create, update or delete FrontendPages", platformPort)
+	}
+
+	if platformAuditLog != "" {
+		log.Printf("   📝 Auditing actions to %s", platformAuditLog)
+	}
+
+	if platformMaxReplicas > 0 {
+		log.Printf("   🛡️ scale_frontend limited to %d-%d replicas", platformMinReplicas, platformMaxReplicas)
+	}
+	for namespace, maxReplicas := range platformNamespaceMaxReplicas {
+		log.Printf("   🛡️ scale_frontend in %s limited to %d replicas", namespace, maxReplicas)
+	}
+
 	log.Printf("   ✅ Step 12+ Update action support")
 	log.Printf("   ✅ Step 12++ Discord notifications integration")
 	log.Println("")
@@ -923,6 +2375,7 @@ func runPlatformAPI() {
 	log.Printf("   📋 Available Actions: http://localhost:%d/api/v1/actions", platformPort)
 	log.Printf("   🏗️ FrontendPages API: http://localhost:%d/api/v1/frontendpages", platformPort)
 	log.Printf("   ❤️ Health Check: http://localhost:%d/health", platformPort)
+	log.Printf("   💓 Liveness/Readiness: http://localhost:%d/health/live, /health/ready", platformPort)
 	log.Println("")
 	log.Println("🧪 Test the platform API:")
 	log.Println("   # Create a FrontendPage via API:")
@@ -954,11 +2407,23 @@ func runPlatformAPI() {
 	log.Println("     }'")
 
 	// Wait for shutdown signal
-	<-signalChan
-	log.Println("\n🛑 Shutdown signal received, stopping platform API...")
+	select {
+	case <-signalChan:
+		log.Println("\n🛑 Shutdown signal received, stopping platform API...")
+
+		// Drain the API before stopping the manager, since handlers use its client
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), platformShutdownTimeout)
+		defer shutdownCancel()
+		if err := platformAPI.Shutdown(shutdownCtx); err != nil {
+			log.Printf("⚠️ Platform API did not shut down cleanly: %v", err)
+		}
+		<-serverDone
+	case <-serverDone:
+		log.Println("🛑 Platform API server exited, stopping manager...")
+	}
 
 	cancel()
-	time.Sleep(2 * time.Second)
+	<-managerDone
 	log.Println("👋 Step 12: Platform Engineering API stopped gracefully")
 }
 
@@ -969,6 +2434,22 @@ func init() {
 	platformCmd.Flags().StringVar(&portBaseURL, "port-url", "https://api.getport.io", "Port.io API base URL")
 	platformCmd.Flags().BoolVar(&enableWebhooks, "enable-webhooks", true, "Enable webhook handlers")
 	platformCmd.Flags().StringVar(&discordWebhookURL, "discord-webhook", "", "Discord webhook URL for notifications")
+	platformCmd.Flags().StringVar(&platformAPIToken, "api-token", "", "Bearer token required for /webhook/port and POST/PUT/DELETE on /api/v1/frontendpages (empty disables auth)")
+	platformCmd.Flags().StringSliceVar(&discordNotifyActions, "discord-notify-actions", nil, "Comma-separated actions to notify Discord about (e.g. create_frontend,delete_frontend); empty notifies on all")
+	platformCmd.Flags().DurationVar(&discordBatchWindow, "discord-batch-window", 0, "Collect Discord notifications for this long and send them as one message (e.g. 5s); 0 sends immediately")
+	platformCmd.Flags().DurationVar(&platformShutdownTimeout, "shutdown-timeout", 30*time.Second, "Time to wait for in-flight requests and notifications on shutdown")
+	platformCmd.Flags().IntVar(&platformMaxConcurrentActions, "max-concurrent-actions", 10, "Actions processed at once; 0 removes the limit")
+	platformCmd.Flags().DurationVar(&platformActionQueueTimeout, "action-queue-timeout", 5*time.Second, "How long an action waits for a free slot before the request gets 429; 0 rejects at once")
+	platformCmd.Flags().IntVar(&platformMinReplicas, "min-replicas", 1, "Smallest replica count scale_frontend accepts")
+	platformCmd.Flags().IntVar(&platformMaxReplicas, "max-replicas", 50, "Largest replica count scale_frontend accepts; 0 removes the bound")
+	platformCmd.Flags().StringToIntVar(&platformNamespaceMaxReplicas, "namespace-max-replicas", nil, "Per-namespace --max-replicas overrides, e.g. prod=100,sandbox=5")
+	platformCmd.Flags().StringVar(&platformAuditLog, "audit-log", "", "Append one JSON line per processed action to this file (empty disables auditing)")
+	platformCmd.Flags().Int64Var(&platformAuditLogMaxSize, "audit-log-max-size", 100, "Rotate the audit log when it would exceed this many MiB; 0 disables rotation")
+	platformCmd.Flags().Int64Var(&platformMaxBodyBytes, "max-body-bytes", 1<<20, "Largest JSON request body accepted, in bytes; 0 disables the limit")
+	platformCmd.Flags().DurationVar(&platformWaitTimeout, "wait-timeout", 20*time.Second, "Longest a create with wait blocks for the FrontendPage to become Ready; must be under the 30s write timeout and --api-call-timeout")
+	platformCmd.Flags().DurationVar(&platformAPICallTimeout, "api-call-timeout", 30*time.Second, "Deadline for the Kubernetes API calls made by each request; 0 disables it")
+	platformCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, error or a verbosity number")
+	platformCmd.Flags().StringVar(&logFormat, "log-format", "console", "Log format: console or json")
 
 	// Register command
 	RootCmd.AddCommand(platformCmd)
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Platform action metrics, labelled by action name. Names that aren't in the
+// action catalog are counted as "unknown" so arbitrary webhook payloads can't
+// create unbounded label values.
+var (
+	platformActionAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_cli_platform_action_attempts_total",
+		Help: "Platform actions received for processing",
+	}, []string{"action"})
+
+	platformActionSuccesses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_cli_platform_action_successes_total",
+		Help: "Platform actions that completed successfully",
+	}, []string{"action"})
+
+	platformActionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_cli_platform_action_failures_total",
+		Help: "Platform actions that were invalid, returned an error status or failed",
+	}, []string{"action"})
+
+	platformActionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8s_cli_platform_action_duration_seconds",
+		Help:    "Time spent processing platform actions",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"action"})
+
+	platformActionsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "k8s_cli_platform_actions_in_flight",
+		Help: "Platform actions being processed",
+	})
+
+	platformActionsRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "k8s_cli_platform_actions_rejected_total",
+		Help: "Platform actions rejected with 429 because every --max-concurrent-actions slot was busy",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		platformActionAttempts,
+		platformActionSuccesses,
+		platformActionFailures,
+		platformActionDuration,
+		platformActionsInFlight,
+		platformActionsRejected,
+	)
+}
+
+func actionMetricLabel(action string) string {
+	if _, ok := findPortAction(action); ok {
+		return action
+	}
+	return "unknown"
+}
+
+// observeAction records the outcome and latency of one processAction call
+func observeAction(action string, start time.Time, response *ActionResponse, actionErr error) {
+	label := actionMetricLabel(action)
+	platformActionDuration.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	if actionErr != nil || (response != nil && response.Status == "error") {
+		platformActionFailures.WithLabelValues(label).Inc()
+		return
+	}
+	platformActionSuccesses.WithLabelValues(label).Inc()
+}
@@ -0,0 +1,692 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+func TestPlatformRequestContextDeadline(t *testing.T) {
+	p := &PlatformAPI{apiCallTimeout: time.Minute}
+	ctx, cancel := p.requestContext(httptest.NewRequest(http.MethodGet, "/", nil))
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("deadline = %v (set %t), want within 1m", deadline, ok)
+	}
+
+	p.apiCallTimeout = 0
+	ctx, cancel = p.requestContext(httptest.NewRequest(http.MethodGet, "/", nil))
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("deadline set although --api-call-timeout is 0")
+	}
+}
+
+func TestValidatePlatformWaitTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		waitTimeout    time.Duration
+		apiCallTimeout time.Duration
+		wantErr        bool
+	}{
+		{name: "defaults", waitTimeout: 20 * time.Second, apiCallTimeout: 30 * time.Second},
+		{name: "no api call deadline", waitTimeout: 25 * time.Second},
+		{name: "zero", waitTimeout: 0, apiCallTimeout: 30 * time.Second, wantErr: true},
+		{name: "at write timeout", waitTimeout: 30 * time.Second, wantErr: true},
+		{name: "over write timeout", waitTimeout: time.Minute, apiCallTimeout: 2 * time.Minute, wantErr: true},
+		{name: "at api call timeout", waitTimeout: 10 * time.Second, apiCallTimeout: 10 * time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePlatformWaitTimeout(tt.waitTimeout, tt.apiCallTimeout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil && ExitCode(err) != ExitUsage {
+				t.Errorf("exit code = %d, want %d", ExitCode(err), ExitUsage)
+			}
+		})
+	}
+}
+
+func TestPlatformHungAPIServerTimesOut(t *testing.T) {
+	hung := fake.NewClientBuilder().WithScheme(platformScheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}).Build()
+
+	p := &PlatformAPI{client: hung, apiCallTimeout: 20 * time.Millisecond}
+
+	done := make(chan int)
+	go func() {
+		rec := httptest.NewRecorder()
+		p.getFrontendPage(rec, httptest.NewRequest(http.MethodGet, "/api/v1/frontendpages/home", nil), "home")
+		done <- rec.Code
+	}()
+
+	select {
+	case code := <-done:
+		if code == http.StatusOK {
+			t.Errorf("status = %d, want an error", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler still blocked after the API call deadline")
+	}
+}
+
+func TestPlatformDecodeJSONBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "unknown field", body: `{"spec":{"title":"Home","titel":"typo"}}`, wantStatus: http.StatusBadRequest, wantBody: `unknown field "titel"`},
+		{name: "malformed", body: `{"spec":`, wantStatus: http.StatusBadRequest, wantBody: "Invalid JSON payload"},
+		{name: "empty", body: ``, wantStatus: http.StatusBadRequest, wantBody: "request body is empty"},
+		{name: "too large", body: `{"spec":{"description":"` + strings.Repeat("x", 256) + `"}}`, wantStatus: http.StatusRequestEntityTooLarge, wantBody: "larger than 128 bytes"},
+	}
+
+	p := &PlatformAPI{maxBodyBytes: 128}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			p.createFrontendPage(rec, httptest.NewRequest(http.MethodPost, "/api/v1/frontendpages", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestPlatformWebhookRejectsUnknownFields(t *testing.T) {
+	p := &PlatformAPI{maxBodyBytes: 1 << 20}
+	rec := httptest.NewRecorder()
+	body := strings.NewReader(`{"action":"create_frontend","dry_run":true}`)
+	p.handlePortWebhook(rec, httptest.NewRequest(http.MethodPost, "/webhook/port", body))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `unknown field "dry_run"`) {
+		t.Errorf("got %d %q, want 400 naming the unknown field", rec.Code, rec.Body.String())
+	}
+}
+
+func TestPlatformWebhookRequiresToken(t *testing.T) {
+	p := &PlatformAPI{maxBodyBytes: 1 << 20, apiToken: "secret"}
+	handler := p.routes()
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "missing token", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", header: "Bearer bad", wantStatus: http.StatusUnauthorized},
+		// A valid token reaches the handler, which rejects the body
+		{name: "valid token", header: "Bearer secret", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhook/port", strings.NewReader(`{"action":"create_frontend","dry_run":true}`))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPlatformPatchFrontendPage(t *testing.T) {
+	existing := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default", Labels: map[string]string{"app": "home"}},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Home", Path: "/", Replicas: 3, Image: "nginx:1.25"},
+	}
+
+	tests := []struct {
+		name       string
+		patch      string
+		wantStatus int
+		check      func(t *testing.T, page *k8scliv1.FrontendPage)
+	}{
+		{
+			name:       "changes only the given field",
+			patch:      `{"spec":{"title":"Welcome"}}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, page *k8scliv1.FrontendPage) {
+				if page.Spec.Title != "Welcome" || page.Spec.Replicas != 3 || page.Spec.Image != "nginx:1.25" || page.Spec.Path != "/" {
+					t.Errorf("spec = %+v, want title changed and everything else kept", page.Spec)
+				}
+			},
+		},
+		{
+			name:       "null removes a label",
+			patch:      `{"metadata":{"labels":{"app":null,"team":"web"}}}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, page *k8scliv1.FrontendPage) {
+				if _, ok := page.Labels["app"]; ok || page.Labels["team"] != "web" {
+					t.Errorf("labels = %v, want only team=web", page.Labels)
+				}
+			},
+		},
+		{name: "unknown field", patch: `{"spec":{"replica":5}}`, wantStatus: http.StatusBadRequest},
+		{name: "status", patch: `{"status":{"ready":true}}`, wantStatus: http.StatusBadRequest},
+		{name: "metadata name", patch: `{"metadata":{"name":"other"}}`, wantStatus: http.StatusBadRequest},
+		{name: "null spec", patch: `{"spec":null}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(existing.DeepCopy()).Build()
+			p := &PlatformAPI{client: c, maxBodyBytes: 1 << 20}
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/frontendpages/home", strings.NewReader(tt.patch))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			rec := httptest.NewRecorder()
+			p.handleFrontendPageByName(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.check == nil {
+				return
+			}
+			var page k8scliv1.FrontendPage
+			if err := c.Get(context.TODO(), client.ObjectKey{Name: "home", Namespace: "default"}, &page); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			tt.check(t, &page)
+		})
+	}
+}
+
+func TestPlatformPatchFrontendPageContentType(t *testing.T) {
+	p := &PlatformAPI{}
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/frontendpages/home", strings.NewReader(`[{"op":"remove","path":"/spec/image"}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	rec := httptest.NewRecorder()
+	p.handleFrontendPageByName(rec, req)
+
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want 415", rec.Code)
+	}
+	if got := rec.Header().Get("Accept-Patch"); got != "application/merge-patch+json" {
+		t.Errorf("Accept-Patch = %q", got)
+	}
+}
+
+func TestPlatformListFrontendPagesFilterAndSort(t *testing.T) {
+	now := time.Now()
+	page := func(name, phase string, age time.Duration) *k8scliv1.FrontendPage {
+		return &k8scliv1.FrontendPage{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status:     k8scliv1.FrontendPageStatus{Phase: phase},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(
+		page("blog", "Running", time.Hour),
+		page("about", "Pending", 2*time.Hour),
+		page("shop", "Running", 3*time.Hour),
+		page("docs", "running", time.Minute),
+	).Build()
+	p := &PlatformAPI{client: c}
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		want       []string
+	}{
+		{query: "", wantStatus: http.StatusOK, want: []string{"about", "blog", "docs", "shop"}},
+		{query: "?order=desc", wantStatus: http.StatusOK, want: []string{"shop", "docs", "blog", "about"}},
+		{query: "?phase=Running", wantStatus: http.StatusOK, want: []string{"blog", "docs", "shop"}},
+		{query: "?phase=Running&sortBy=age", wantStatus: http.StatusOK, want: []string{"docs", "blog", "shop"}},
+		{query: "?sortBy=age&order=desc", wantStatus: http.StatusOK, want: []string{"shop", "about", "blog", "docs"}},
+		{query: "?sortBy=replicas", wantStatus: http.StatusBadRequest},
+		{query: "?order=up", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			p.listFrontendPages(rec, httptest.NewRequest(http.MethodGet, "/api/v1/frontendpages"+tt.query, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data []k8scliv1.FrontendPage `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			var got []string
+			for _, item := range response.Data {
+				got = append(got, item.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformScaleGuardrails(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		warnings []DiscordMessage
+	)
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message DiscordMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		mu.Lock()
+		warnings = append(warnings, message)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer discord.Close()
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec:       k8scliv1.FrontendPageSpec{Replicas: 2},
+	}
+
+	tests := []struct {
+		name         string
+		inputs       map[string]interface{}
+		namespaceMax map[string]int
+		wantFailure  string
+		wantMessage  string
+		wantWarnings int
+	}{
+		{name: "within bounds", inputs: map[string]interface{}{"name": "home", "replicas": float64(8)}, wantMessage: "would be scaled from 2 to 8"},
+		{name: "above max", inputs: map[string]interface{}{"name": "home", "replicas": float64(10000)}, wantFailure: "replicas 10000 exceeds the maximum of 10", wantWarnings: 1},
+		{name: "namespace override", inputs: map[string]interface{}{"name": "home", "replicas": float64(30)}, namespaceMax: map[string]int{"default": 40}, wantMessage: "would be scaled from 2 to 30"},
+		{name: "above namespace override", inputs: map[string]interface{}{"name": "home", "replicas": float64(8)}, namespaceMax: map[string]int{"default": 5}, wantFailure: "maximum of 5 for namespace default", wantWarnings: 1},
+		{name: "fractional", inputs: map[string]interface{}{"name": "home", "replicas": 2.5}, wantFailure: "whole number", wantWarnings: 1},
+		{name: "missing page", inputs: map[string]interface{}{"name": "nope", "replicas": float64(3)}, wantMessage: "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			warnings = nil
+			mu.Unlock()
+
+			p := &PlatformAPI{
+				client:               fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(page.DeepCopy()).Build(),
+				portClient:           &PortClient{},
+				discordClient:        &DiscordClient{WebhookURL: discord.URL, HTTPClient: discord.Client()},
+				minReplicas:          1,
+				maxReplicas:          10,
+				namespaceMaxReplicas: tt.namespaceMax,
+			}
+
+			response, err := p.processAction(context.Background(), &ActionRequest{Action: "scale_frontend", Inputs: tt.inputs, DryRun: true})
+			p.notifications.Wait()
+
+			if tt.wantFailure != "" {
+				var validationErr *ActionValidationError
+				if !errors.As(err, &validationErr) || !strings.Contains(strings.Join(validationErr.Failures, ";"), tt.wantFailure) {
+					t.Fatalf("err = %v, want a validation error containing %q", err, tt.wantFailure)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("processAction() error = %v", err)
+				}
+				if !strings.Contains(response.Message, tt.wantMessage) {
+					t.Errorf("message = %q, want it to contain %q", response.Message, tt.wantMessage)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(warnings) != tt.wantWarnings {
+				t.Fatalf("got %d Discord warnings, want %d", len(warnings), tt.wantWarnings)
+			}
+			if tt.wantWarnings > 0 && warnings[0].Embeds[0].Color != 0xFFA500 {
+				t.Errorf("warning color = %#x, want orange", warnings[0].Embeds[0].Color)
+			}
+		})
+	}
+}
+
+func TestPlatformActionSlots(t *testing.T) {
+	p := &PlatformAPI{actionSlots: make(chan struct{}, 1)}
+	rejectedBefore := testutil.ToFloat64(platformActionsRejected)
+	inFlightBefore := testutil.ToFloat64(platformActionsInFlight)
+
+	release, ok := p.acquireActionSlot(context.Background())
+	if !ok {
+		t.Fatal("first acquire failed")
+	}
+	if _, ok := p.acquireActionSlot(context.Background()); ok {
+		t.Fatal("second acquire succeeded with every slot busy and no queue timeout")
+	}
+
+	rec := httptest.NewRecorder()
+	p.handlePortWebhook(rec, httptest.NewRequest(http.MethodPost, "/webhook/port", strings.NewReader(`{"action":"create_frontend"}`)))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("webhook got %d (Retry-After %q), want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if got := testutil.ToFloat64(platformActionsRejected) - rejectedBefore; got != 2 {
+		t.Errorf("rejected = %v, want 2", got)
+	}
+
+	// A queued request gets the slot once it is released
+	p.actionQueueTimeout = 5 * time.Second
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		release()
+	}()
+	release, ok = p.acquireActionSlot(context.Background())
+	if !ok {
+		t.Fatal("queued acquire failed after the slot was released")
+	}
+	if got := testutil.ToFloat64(platformActionsInFlight) - inFlightBefore; got != 1 {
+		t.Errorf("in flight = %v, want 1", got)
+	}
+	release()
+
+	// A client that goes away stops waiting
+	p.actionSlots <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := p.acquireActionSlot(ctx); ok {
+		t.Error("acquire succeeded for a cancelled request")
+	}
+}
+
+func TestPlatformActionMetrics(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(&k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+	}).Build()
+	p := &PlatformAPI{client: c, portClient: &PortClient{}}
+
+	counts := func(action string) [3]float64 {
+		return [3]float64{
+			testutil.ToFloat64(platformActionAttempts.WithLabelValues(action)),
+			testutil.ToFloat64(platformActionSuccesses.WithLabelValues(action)),
+			testutil.ToFloat64(platformActionFailures.WithLabelValues(action)),
+		}
+	}
+	deleteBefore, unknownBefore := counts("delete_frontend"), counts("unknown")
+
+	p.processAction(context.Background(), &ActionRequest{Action: "delete_frontend", Inputs: map[string]interface{}{"name": "home"}})
+	p.processAction(context.Background(), &ActionRequest{Action: "delete_frontend", Inputs: map[string]interface{}{}})
+	p.processAction(context.Background(), &ActionRequest{Action: "drop_database"})
+
+	deleteAfter, unknownAfter := counts("delete_frontend"), counts("unknown")
+	if got := [3]float64{deleteAfter[0] - deleteBefore[0], deleteAfter[1] - deleteBefore[1], deleteAfter[2] - deleteBefore[2]}; got != [3]float64{2, 1, 1} {
+		t.Errorf("delete_frontend attempts/successes/failures = %v, want [2 1 1]", got)
+	}
+	if got := unknownAfter[2] - unknownBefore[2]; got != 1 {
+		t.Errorf("unknown failures = %v, want 1", got)
+	}
+
+	rec := httptest.NewRecorder()
+	metricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`k8s_cli_platform_action_attempts_total{action="delete_frontend"}`,
+		`k8s_cli_platform_action_duration_seconds_bucket{action="delete_frontend"`,
+		"k8s_cli_platform_actions_in_flight",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("/metrics is missing %s", want)
+		}
+	}
+}
+
+func TestPlatformCreateFrontendPageWait(t *testing.T) {
+	defer func(d time.Duration) { frontendPageWaitInterval = d }(frontendPageWaitInterval)
+	frontendPageWaitInterval = time.Millisecond
+
+	create := func(p *PlatformAPI, query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		body := `{"metadata":{"name":"shop","namespace":"default"},"spec":{"title":"Shop","path":"/shop"}}`
+		p.createFrontendPage(rec, httptest.NewRequest(http.MethodPost, "/api/v1/frontendpages"+query, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		p := &PlatformAPI{client: readyAfterGets(2), waitTimeout: time.Second}
+		rec := create(p, "?wait=true")
+		var resp struct {
+			Status string `json:"status"`
+			URL    string `json:"url"`
+		}
+		json.NewDecoder(rec.Body).Decode(&resp)
+		if rec.Code != http.StatusOK || resp.Status != "success" || resp.URL != "http://shop.svc/" {
+			t.Errorf("got %d %+v, want 200 with the URL", rec.Code, resp)
+		}
+	})
+
+	t.Run("not ready in time", func(t *testing.T) {
+		p := &PlatformAPI{client: readyAfterGets(0), waitTimeout: 20 * time.Millisecond}
+		rec := create(p, "?wait=true")
+		if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"status":"pending"`) {
+			t.Errorf("got %d %s, want 202 pending", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid wait", func(t *testing.T) {
+		if rec := create(&PlatformAPI{client: readyAfterGets(0)}, "?wait=soon"); rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", rec.Code)
+		}
+	})
+
+	t.Run("action", func(t *testing.T) {
+		p := &PlatformAPI{client: readyAfterGets(2), waitTimeout: time.Second, portClient: &PortClient{}}
+		resp, err := p.createFrontendPageAction(context.Background(), &ActionRequest{
+			Action: "create_frontend",
+			Wait:   true,
+			Inputs: map[string]interface{}{"name": "shop", "title": "Shop", "path": "/shop"},
+		})
+		if err != nil || resp.Status != "success" || resp.Data.(map[string]interface{})["url"] != "http://shop.svc/" {
+			t.Errorf("got %+v, %v, want success with the URL", resp, err)
+		}
+
+		p = &PlatformAPI{client: readyAfterGets(0), waitTimeout: 20 * time.Millisecond, portClient: &PortClient{}}
+		resp, err = p.createFrontendPageAction(context.Background(), &ActionRequest{
+			Action: "create_frontend",
+			Wait:   true,
+			Inputs: map[string]interface{}{"name": "slow", "title": "Slow", "path": "/slow"},
+		})
+		if err != nil || resp.Status != "error" || !strings.Contains(resp.Message, "was created but is not ready") {
+			t.Errorf("got %+v, %v, want an error run that says the page exists", resp, err)
+		}
+	})
+}
+
+func TestPlatformAPIUsesDefaultNamespace(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(
+		&k8scliv1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "team-a"}},
+		&k8scliv1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "default"}},
+	).Build()
+
+	tests := []struct {
+		namespace string
+		want      string
+	}{
+		{namespace: "team-a", want: "team-a"},
+		{namespace: "", want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			p := &PlatformAPI{client: c, namespace: tt.namespace}
+			rec := httptest.NewRecorder()
+			p.getFrontendPage(rec, httptest.NewRequest(http.MethodGet, "/api/v1/frontendpages/blog", nil), "blog")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			var response struct {
+				Data k8scliv1.FrontendPage `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if response.Data.Namespace != tt.want {
+				t.Errorf("got FrontendPage in %q, want %q", response.Data.Namespace, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscordBatcherAddConcurrentWithClose(t *testing.T) {
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer discord.Close()
+	b := NewDiscordBatcher(&DiscordClient{WebhookURL: discord.URL, HTTPClient: discord.Client()}, time.Millisecond)
+
+	req := &ActionRequest{Action: "scale_deployment", Trigger: "manual"}
+	response := &ActionResponse{Status: "success"}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				b.Add("", req, response)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.Close(ctx); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	wg.Wait()
+	if err := b.Close(ctx); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestDiscordBatcherDropsWhenFull(t *testing.T) {
+	// No run loop drains the queue, so the second result finds it full
+	b := &DiscordBatcher{items: make(chan discordNotification, 1), done: make(chan struct{})}
+	req := &ActionRequest{Action: "scale_deployment"}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.Add("1", req, &ActionResponse{})
+		b.Add("2", req, &ActionResponse{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Add() blocked on a full queue")
+	}
+	if got := b.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %d, want 1", got)
+	}
+}
+
+func TestPortClientHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	port := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // hang until the test is over
+	}))
+	defer port.Close()
+	defer close(release)
+	pc := &PortClient{BaseURL: port.URL, Token: "token", HTTPClient: port.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- pc.ReportActionRun(ctx, "run-1", PortRunStatusSuccess, []string{"scaled"}) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("ReportActionRun() error = %v, want the context deadline", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReportActionRun() ignored the context deadline")
+	}
+}
+
+func TestPlatformCreateActionDryRun(t *testing.T) {
+	existing := &k8scliv1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"}}
+	var dryRuns int
+	c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(existing).WithInterceptorFuncs(interceptor.Funcs{
+		// The fake client skips dry-run creates entirely; answer like the API server
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			createOptions := &client.CreateOptions{}
+			createOptions.ApplyOptions(opts)
+			if len(createOptions.DryRun) == 0 {
+				return c.Create(ctx, obj, opts...)
+			}
+			dryRuns++
+			if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &k8scliv1.FrontendPage{}); err == nil {
+				return apierrors.NewAlreadyExists(k8scliv1.GroupVersion.WithResource("frontendpages").GroupResource(), obj.GetName())
+			}
+			return nil
+		},
+	}).Build()
+	p := &PlatformAPI{client: c, portClient: &PortClient{}}
+
+	tests := []struct {
+		name       string
+		page       string
+		wantStatus string
+	}{
+		{name: "new page", page: "blog", wantStatus: "success"},
+		{name: "existing page", page: "shop", wantStatus: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := p.createFrontendPageAction(context.Background(), &ActionRequest{
+				Action: "create_frontend",
+				DryRun: true,
+				Inputs: map[string]interface{}{"name": tt.page, "title": "Page"},
+			})
+			if response == nil || response.Status != tt.wantStatus {
+				t.Fatalf("response = %+v, want status %s", response, tt.wantStatus)
+			}
+		})
+	}
+
+	if dryRuns != len(tests) {
+		t.Errorf("got %d dry-run creates, want %d", dryRuns, len(tests))
+	}
+	var pages k8scliv1.FrontendPageList
+	if err := c.List(context.Background(), &pages); err != nil {
+		t.Fatal(err)
+	}
+	if len(pages.Items) != 1 {
+		t.Errorf("dry run persisted pages: %d exist, want 1", len(pages.Items))
+	}
+}
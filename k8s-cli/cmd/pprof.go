@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+var (
+	// Profiling endpoints, off by default
+	enablePprof bool
+	pprofPort   int
+)
+
+// pprofBindAddress returns the address the debug server listens on, or "" when
+// profiling is disabled. It binds to localhost only: profiles expose memory
+// contents, so reach them with kubectl port-forward rather than a Service.
+func pprofBindAddress(enabled bool, port int) string {
+	if !enabled {
+		return ""
+	}
+	return fmt.Sprintf("127.0.0.1:%d", port)
+}
+
+// newPprofMux mounts the net/http/pprof handlers under /debug/pprof/
+func newPprofMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// startPprofServer serves pprof on its own port so profiling never shares the
+// API server's timeouts or middleware. The informer commands call it in a
+// goroutine; the controller-runtime managers use PprofBindAddress instead.
+func startPprofServer(addr string) {
+	if addr == "" {
+		return
+	}
+
+	log.Printf("🔬 pprof endpoints available at: http://%s/debug/pprof/", addr)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newPprofMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("❌ pprof server failed: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPprofBindAddress(t *testing.T) {
+	if got := pprofBindAddress(false, 6060); got != "" {
+		t.Errorf("pprofBindAddress(false) = %q, want empty", got)
+	}
+	if got := pprofBindAddress(true, 6060); got != "127.0.0.1:6060" {
+		t.Errorf("pprofBindAddress(true) = %q, want 127.0.0.1:6060", got)
+	}
+}
+
+func TestPprofMux(t *testing.T) {
+	mux := newPprofMux()
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/goroutine?debug=1", "/debug/pprof/cmdline"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, rec.Code)
+		}
+	}
+}
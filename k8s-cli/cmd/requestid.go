@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// requestIDHeader carries the correlation ID between clients and the k8s-cli API servers
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength caps client-supplied IDs so they can't bloat logs and responses
+const maxRequestIDLength = 128
+
+type requestIDContextKey struct{}
+
+// withRequestID reuses the caller's X-Request-ID (or generates a UUID), stores it in the
+// request context and sets it on the response header before calling next.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+		ctx := context.WithValue(r.Context(), requestIDContextKey{}, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestIDFromContext returns the ID stored by withRequestID, or "" outside a request
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// logRequestf logs like log.Printf, prefixed with the request ID when one is present
+func logRequestf(ctx context.Context, format string, args ...interface{}) {
+	logWithRequestID(requestIDFromContext(ctx), format, args...)
+}
+
+func logWithRequestID(requestID, format string, args ...interface{}) {
+	if requestID != "" {
+		format = "[" + requestID + "] " + format
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// rolloutCmd groups rollout management commands
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Manage deployment rollouts",
+	Long:  "Manage the rollout history of deployments",
+}
+
+// rolloutUndoCmd rolls a deployment back to an earlier revision
+var rolloutUndoCmd = &cobra.Command{
+	Use:   "undo deployment/<deployment-name>",
+	Short: "Roll back a deployment",
+	Long: `Roll a deployment back to a previous revision.
+
+Revisions come from the deployment.kubernetes.io/revision annotation on the
+ReplicaSets owned by the deployment. Without --to-revision the deployment is
+rolled back to the revision before the current one.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  # Roll back to the previous revision
+  k8s-cli rollout undo deployment/nginx-deployment
+
+  # Roll back to a specific revision
+  k8s-cli rollout undo deployment/nginx-deployment --to-revision=2 -n my-app`,
+	RunE: runRolloutUndo,
+}
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+	rolloutCmd.AddCommand(rolloutUndoCmd)
+
+	// Add flags
+	rolloutUndoCmd.Flags().Int64("to-revision", 0, "Revision to roll back to (0 = previous revision)")
+}
+
+func runRolloutUndo(cmd *cobra.Command, args []string) error {
+	deploymentName, err := parseDeploymentRef(args[0])
+	if err != nil {
+		return err
+	}
+	toRevision, _ := cmd.Flags().GetInt64("to-revision")
+	if toRevision < 0 {
+		return usageErrorf("--to-revision must not be negative")
+	}
+	namespace := viper.GetString("namespace")
+
+	client, err := getClient()
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	if err := checkNamespace(client); err != nil {
+		return err
+	}
+
+	revision, err := client.RollbackDeployment(namespace, deploymentName, toRevision)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Deployment '%s' rolled back to revision %d\n", deploymentName, revision)
+	return nil
+}
+
+// parseDeploymentRef accepts deployment/<name>, deploy/<name> or a bare name
+func parseDeploymentRef(ref string) (string, error) {
+	kind, name, found := strings.Cut(ref, "/")
+	if !found {
+		return ref, nil
+	}
+	switch kind {
+	case "deployment", "deployments", "deploy":
+	default:
+		return "", usageErrorf("unsupported resource type '%s', only deployments can be rolled back", kind)
+	}
+	if name == "" {
+		return "", usageErrorf("deployment name is required")
+	}
+	return name, nil
+}
@@ -3,14 +3,20 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 	"path/filepath"
+
+	"k8s-cli/internal/k8s"
 )
 
 var (
@@ -20,6 +26,17 @@ var (
 
 	// Step 7: Добавленные переменные для аутентификации
 	inCluster bool
+
+	// Fail fast when -n names a namespace that doesn't exist
+	strictNamespace bool
+
+	// Output for scripts and CI: no decorative lines, or one JSON result object per action
+	quiet   bool
+	logJSON bool
+
+	// Run commands as another user, e.g. to check what a service account may do
+	impersonateUser   string
+	impersonateGroups []string
 )
 
 // rootCmd представляет базовую команду при вызове без подкоманд
@@ -35,48 +52,175 @@ var rootCmd = &cobra.Command{
 • Step 7: Мониторинг deployments через информеры (watch-informer)
 • Step 7+: JSON API для доступа к кешу информеров (api-server)
 • Step 7++: Управление конфигурацией (config)
-• Step 8: Расширенный JSON API с аналитикой (step8-api)`,
-	Version: "1.0.0",
+• Step 8: Расширенный JSON API с аналитикой (step8-api)
+
+` + exitCodeHelp,
+}
+
+// commandStarted становится true, когда cobra проверила флаги и аргументы и
+// запускает команду. Всё, что упало раньше (неизвестная команда, флаги,
+// аргументы, обязательные и взаимоисключающие флаги), Execute возвращает
+// как ошибку использования.
+var commandStarted bool
+
+var markCommandsOnce sync.Once
+
+// markCommandStart оборачивает Run/RunE каждой команды. PersistentPreRun для
+// этого не годится: cobra проверяет обязательные флаги и группы флагов уже
+// после него.
+func markCommandStart(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			commandStarted = true
+			return run(cmd, args)
+		}
+	}
+	if run := cmd.Run; run != nil {
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			commandStarted = true
+			run(cmd, args)
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markCommandStart(child)
+	}
 }
 
-// Execute добавляет все дочерние команды к корневой команде и устанавливает флаги
+// Execute добавляет все дочерние команды к корневой команде и устанавливает флаги.
+// Код выхода для возвращённой ошибки даёт ExitCode.
 func Execute() error {
-	return rootCmd.Execute()
+	markCommandsOnce.Do(func() { markCommandStart(rootCmd) })
+	err := rootCmd.Execute()
+	if err != nil && !commandStarted {
+		return usageError{err: err}
+	}
+	return err
 }
 
 // Step 7: GetKubernetesClient - экспортируемая функция для получения клиента
 // Поддерживает kubeconfig и in-cluster аутентификацию
 func GetKubernetesClient() (kubernetes.Interface, error) {
+	config, err := getRESTConfig(true)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %v", err)
+	}
+
+	return clientset, nil
+}
+
+// GetDynamicClient - dynamic клиент с той же аутентификацией, что и GetKubernetesClient,
+// для CRD без типизированного клиента (например, FrontendPage)
+func GetDynamicClient() (dynamic.Interface, error) {
+	config, err := getRESTConfig(false)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %v", err)
+	}
+
+	return dynamicClient, nil
+}
+
+func getRESTConfig(verbose bool) (*rest.Config, error) {
 	var config *rest.Config
 	var err error
 
 	if inCluster {
-		fmt.Println("🔗 Using in-cluster authentication")
+		if verbose {
+			fmt.Println("🔗 Using in-cluster authentication")
+		}
 		config, err = rest.InClusterConfig()
 	} else {
-		// Используем существующий kubeconfig
+		// Используем существующий kubeconfig; без --kubeconfig объединяются файлы из KUBECONFIG
 		configPath := kubeconfig
 		if configPath == "" {
 			configPath = viper.GetString("kubeconfig")
 		}
-		fmt.Printf("🔗 Using kubeconfig: %s\n", configPath)
-		config, err = clientcmd.BuildConfigFromFlags("", configPath)
+		loadingRules := k8s.NewLoadingRules(configPath)
+		if verbose {
+			if configPath != "" {
+				fmt.Printf("🔗 Using kubeconfig: %s\n", configPath)
+			} else {
+				fmt.Printf("🔗 Using kubeconfig: %s\n", strings.Join(loadingRules.Precedence, string(filepath.ListSeparator)))
+			}
+		}
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config: %v", err)
 	}
 
+	if err := impersonation().Apply(config); err != nil {
+		return nil, err
+	}
+	if verbose && impersonateUser != "" {
+		fmt.Printf("🎭 Impersonating user: %s\n", impersonateUser)
+	}
+
 	// Настройки производительности для Step 7+
 	config.QPS = 50
 	config.Burst = 100
 
-	clientset, err := kubernetes.NewForConfig(config)
+	return config, nil
+}
+
+// checkNamespace enforces --strict-namespace: when set, commands stop early with the
+// list of available namespaces instead of silently working on one that doesn't exist.
+// It is opt-in so scripts that create a namespace and then use it keep working.
+func checkNamespace(client *k8s.Client) error {
+	if !viper.GetBool("strict-namespace") {
+		return nil
+	}
+
+	ns := resolveNamespace()
+	exists, err := client.NamespaceExists(ns)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset: %v", err)
+		return err
+	}
+	if exists {
+		return nil
 	}
 
-	return clientset, nil
+	notFound := fmt.Errorf("namespace '%s' not found", ns)
+	if available, err := client.Namespaces(); err == nil {
+		notFound = fmt.Errorf("%w; available namespaces: %s", notFound, strings.Join(available, ", "))
+	}
+	return &k8s.Error{Class: k8s.ErrNotFound, Op: "get namespace", Err: notFound}
+}
+
+// resolveNamespace returns the namespace to work in: -n when given, else the
+// namespace of the kubeconfig's current context, else "default", as kubectl does
+func resolveNamespace() string {
+	if ns := viper.GetString("namespace"); ns != "" {
+		return ns
+	}
+	if inCluster {
+		return metav1.NamespaceDefault
+	}
+
+	// This runs for every command, so a broken kubeconfig is left to the
+	// command that needs a client to report
+	return k8s.ContextNamespace(viper.GetString("kubeconfig"))
+}
+
+// checkResourceType rejects a manifest whose kind the server doesn't serve,
+// suggesting the closest known type for typos. A manifest that doesn't decode
+// is left to the command, which reports the YAML error.
+func checkResourceType(client *k8s.Client, yamlData []byte) error {
+	kind, _ := yamlObjectRef(yamlData)
+	if kind == "" {
+		return nil
+	}
+	return client.ValidateResourceType(kind)
 }
 
 // RootCmd экспортируем для использования в других файлах
@@ -87,8 +231,8 @@ func init() {
 
 	// Существующие глобальные флаги
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "путь к kubeconfig файлу")
-	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "namespace для операций")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "формат вывода (table, json, yaml)")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "namespace для операций (по умолчанию namespace текущего контекста kubeconfig)")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "формат вывода (table, json, yaml, name)")
 
 	// Step 7: Добавляем флаг для in-cluster режима
 	rootCmd.PersistentFlags().BoolVar(&inCluster, "in-cluster", false, "использовать in-cluster аутентификацию")
@@ -98,11 +242,25 @@ func init() {
 	viper.BindPFlag("namespace", rootCmd.PersistentFlags().Lookup("namespace"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 	viper.BindPFlag("in-cluster", rootCmd.PersistentFlags().Lookup("in-cluster"))
+
+	rootCmd.PersistentFlags().BoolVar(&strictNamespace, "strict-namespace", false, "fail if the namespace given with -n does not exist")
+	viper.BindPFlag("strict-namespace", rootCmd.PersistentFlags().Lookup("strict-namespace"))
+
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress decorative output of create, delete, apply and list")
+	rootCmd.PersistentFlags().BoolVar(&logJSON, "log-json", false, "print one JSON result object per action instead of decorative output")
+	viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	viper.BindPFlag("log-json", rootCmd.PersistentFlags().Lookup("log-json"))
+
+	rootCmd.PersistentFlags().Bool("show-managed-fields", false, "keep metadata.managedFields in -o json/yaml output")
+	viper.BindPFlag("show-managed-fields", rootCmd.PersistentFlags().Lookup("show-managed-fields"))
+
+	rootCmd.PersistentFlags().StringVar(&impersonateUser, "as", "", "username to impersonate, e.g. system:serviceaccount:ns:sa")
+	rootCmd.PersistentFlags().StringArrayVar(&impersonateGroups, "as-group", nil, "group to impersonate, can be repeated (requires --as)")
 }
 
 func initConfig() {
-	// Установить путь к kubeconfig по умолчанию
-	if kubeconfig == "" {
+	// Установить путь к kubeconfig по умолчанию, если KUBECONFIG не задан
+	if kubeconfig == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
 		if home := homedir.HomeDir(); home != "" {
 			kubeconfig = filepath.Join(home, ".kube", "config")
 			viper.Set("kubeconfig", kubeconfig)
@@ -126,4 +284,8 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Используется конфигурационный файл:", viper.ConfigFileUsed())
 	}
+
+	// Без -n работаем в namespace текущего контекста, как kubectl; -n, K8S_CLI_NAMESPACE
+	// и конфигурационный файл его переопределяют
+	viper.SetDefault("namespace", resolveNamespace())
 }
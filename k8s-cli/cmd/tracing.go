@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelEndpoint is the OTLP/HTTP collector reconcile spans are exported to;
+// empty leaves tracing off
+var otelEndpoint string
+
+// setupTracing returns a tracer exporting to endpoint and a function that
+// flushes and stops it. With no endpoint the tracer is nil, which the
+// reconcilers treat as tracing off, so nothing is recorded or allocated.
+func setupTracing(ctx context.Context, endpoint, serviceName string) (trace.Tracer, func(context.Context) error, error) {
+	if endpoint == "" {
+		return nil, func(context.Context) error { return nil }, nil
+	}
+
+	// The exporter connects lazily, so an unreachable collector only drops spans
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter for %s: %w", endpoint, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		)),
+	)
+	return provider.Tracer("k8s-cli/controllers"), provider.Shutdown, nil
+}
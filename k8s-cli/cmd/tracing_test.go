@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetupTracing(t *testing.T) {
+	tracer, shutdown, err := setupTracing(context.Background(), "", "test")
+	if err != nil || tracer != nil {
+		t.Fatalf("setupTracing(\"\") = %v, %v; want no tracer", tracer, err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	// A fake collector counting OTLP trace exports
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+	}))
+	defer collector.Close()
+
+	tracer, shutdown, err = setupTracing(context.Background(), collector.URL, "test")
+	if err != nil {
+		t.Fatalf("setupTracing() error = %v", err)
+	}
+	_, span := tracer.Start(context.Background(), "FrontendPage.Reconcile")
+	span.End()
+
+	// Shutdown flushes the batched span
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+	if exports.Load() == 0 {
+		t.Error("no spans were exported to the collector")
+	}
+}
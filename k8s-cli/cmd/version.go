@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// Build metadata, injected at build time:
+//
+//	go build -ldflags "-X k8s-cli/cmd.version=v1.2.3 -X k8s-cli/cmd.commit=abc1234 -X k8s-cli/cmd.buildDate=2024-01-01T00:00:00Z"
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// GetBuildInfo returns the injected build metadata, falling back to the VCS
+// stamp embedded by `go build` when the commit wasn't set via -ldflags.
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "unknown" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "unknown" {
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print build information",
+	Long: `Print the version, git commit, build date and Go version of this k8s-cli build.
+
+Use -o json or -o yaml for machine-readable output, e.g. when reporting a bug.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printBuildInfo(GetBuildInfo(), viper.GetString("output"))
+	},
+}
+
+func printBuildInfo(info BuildInfo, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "yaml":
+		data, err := yaml.Marshal(info)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+	default:
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"FIELD", "VALUE"})
+		table.Append([]string{"Version", info.Version})
+		table.Append([]string{"Commit", info.Commit})
+		table.Append([]string{"Build Date", info.BuildDate})
+		table.Append([]string{"Go Version", info.GoVersion})
+		table.Append([]string{"Platform", info.Platform})
+		table.Render()
+	}
+	return nil
+}
+
+func init() {
+	// --version prints the same injected version as the version subcommand
+	info := GetBuildInfo()
+	rootCmd.Version = info.Version
+	rootCmd.SetVersionTemplate(fmt.Sprintf("k8s-cli {{.Version}} (commit %s, built %s)\n", info.Commit, info.BuildDate))
+
+	RootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	// wsSendBuffer is how many events may queue for one connection before it counts as slow
+	wsSendBuffer = 64
+	// wsWriteTimeout bounds every frame written to a client
+	wsWriteTimeout = 10 * time.Second
+	// wsPingPeriod keeps proxies from closing idle connections and finds dead peers
+	wsPingPeriod = 30 * time.Second
+	// wsPongWait is how long a client may stay silent, pongs included, before
+	// it counts as dead; it must be longer than wsPingPeriod
+	wsPongWait = 2 * wsPingPeriod
+	// wsMaxMessageSize limits what a client may send; the stream is one-way
+	wsMaxMessageSize = 512
+)
+
+// wsAllowedOrigins are the browser origins besides the server's own that may
+// open /api/v2/ws/deployments, set with --ws-allowed-origins
+var wsAllowedOrigins []string
+
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: checkWSOrigin,
+}
+
+// checkWSOrigin accepts clients that send no Origin (not a browser), pages
+// served from the same host and the --ws-allowed-origins, so a foreign page
+// can't open the stream from a visitor's browser
+func checkWSOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range wsAllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// DeploymentChangeEvent is streamed to /api/v2/ws/deployments clients for every informer event
+type DeploymentChangeEvent struct {
+	Type       string            `json:"type"` // ADDED, MODIFIED or DELETED
+	Deployment DeploymentSummary `json:"deployment"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+type wsClient struct {
+	send chan []byte
+}
+
+// wsHub fans informer events out to the connected WebSocket clients. The
+// informer never blocks on a client: one whose buffer is full is dropped and
+// has to reconnect.
+type wsHub struct {
+	mu      sync.Mutex
+	clients map[*wsClient]struct{}
+}
+
+func newWSHub() *wsHub {
+	return &wsHub{clients: make(map[*wsClient]struct{})}
+}
+
+func (h *wsHub) register() *wsClient {
+	client := &wsClient{send: make(chan []byte, wsSendBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[client] = struct{}{}
+	return client
+}
+
+// unregister removes client and closes its send channel; it is a no-op for a
+// client that was already dropped
+func (h *wsHub) unregister(client *wsClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(client)
+}
+
+func (h *wsHub) removeLocked(client *wsClient) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	close(client.send)
+}
+
+func (h *wsHub) clientCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+func (h *wsHub) broadcast(message []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.clients {
+		select {
+		case client.send <- message:
+		default:
+			log.Printf("⚠️ Dropping slow WebSocket client (%d events queued)", wsSendBuffer)
+			h.removeLocked(client)
+		}
+	}
+}
+
+// publishDeploymentEvent sends a change to the WebSocket clients; it is called
+// from the informer event handlers and skips the encoding when nobody listens
+func (e *EventProcessor) publishDeploymentEvent(eventType string, deployment *appsv1.Deployment) {
+	if e.wsHub == nil || e.wsHub.clientCount() == 0 {
+		return
+	}
+
+	message, err := json.Marshal(DeploymentChangeEvent{
+		Type:       eventType,
+		Deployment: e.createDeploymentSummary(deployment),
+		Timestamp:  time.Now(),
+	})
+	if err != nil {
+		log.Printf("❌ Failed to encode deployment event: %v", err)
+		return
+	}
+	e.wsHub.broadcast(message)
+}
+
+// handleDeploymentsWebSocket streams DeploymentChangeEvents as JSON text
+// messages to clients whose origin checkWSOrigin accepts
+func (e *EventProcessor) handleDeploymentsWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already answered with an HTTP error
+		logRequestf(r.Context(), "⚠️ WebSocket upgrade from %s failed: %v", r.RemoteAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	client := e.wsHub.register()
+	defer e.wsHub.unregister(client)
+	logRequestf(r.Context(), "🔌 WebSocket client connected from %s", r.RemoteAddr)
+
+	// Clients don't send anything; reading processes their pongs and close
+	// frames and notices when they go away. The read deadline replaces the
+	// API server's read timeout, which would otherwise cut the stream, and
+	// every pong extends it.
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-client.send:
+			if !ok {
+				return // dropped as a slow consumer
+			}
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			logRequestf(r.Context(), "🔌 WebSocket client disconnected")
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentsWebSocketStreamsEvents(t *testing.T) {
+	e := NewEventProcessor(nil, &InformerConfig{})
+	server := httptest.NewServer(withRequestID(e.step8Middleware(http.HandlerFunc(e.handleDeploymentsWebSocket))))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v2/ws/deployments"
+	ws, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {server.URL}})
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer ws.Close()
+
+	// the handler registers the client after the handshake
+	deadline := time.Now().Add(2 * time.Second)
+	for e.wsHub.clientCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	e.publishDeploymentEvent("MODIFIED", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event DeploymentChangeEvent
+	if err := ws.ReadJSON(&event); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if event.Type != "MODIFIED" || event.Deployment.Namespace != "default" || event.Deployment.Name != "web" {
+		t.Errorf("event = %+v, want MODIFIED default/web", event)
+	}
+
+	ws.Close()
+	deadline = time.Now().Add(2 * time.Second)
+	for e.wsHub.clientCount() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not unregistered after closing")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCheckWSOrigin(t *testing.T) {
+	defer func(origins []string) { wsAllowedOrigins = origins }(wsAllowedOrigins)
+	wsAllowedOrigins = []string{"https://dashboard.example.com"}
+
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{name: "no origin", want: true},
+		{name: "same host", origin: "http://localhost:8090", want: true},
+		{name: "allowed origin", origin: "https://dashboard.example.com", want: true},
+		{name: "foreign origin", origin: "https://evil.example.com", want: false},
+		{name: "same host name on another port", origin: "http://localhost:9999", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:8090/api/v2/ws/deployments", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := checkWSOrigin(r); got != tt.want {
+				t.Errorf("checkWSOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeploymentsWebSocketRejectsForeignOrigin(t *testing.T) {
+	e := NewEventProcessor(nil, &InformerConfig{})
+	server := httptest.NewServer(http.HandlerFunc(e.handleDeploymentsWebSocket))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://evil.example.com"}})
+	if err == nil {
+		t.Fatal("Dial() from a foreign origin succeeded")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("response = %v, want 403", resp)
+	}
+	if e.wsHub.clientCount() != 0 {
+		t.Error("rejected client was registered")
+	}
+}
+
+func TestWSHubDropsSlowConsumers(t *testing.T) {
+	hub := newWSHub()
+	slow := hub.register()
+	fast := hub.register()
+
+	message, _ := json.Marshal(DeploymentChangeEvent{Type: "ADDED"})
+	for i := 0; i < wsSendBuffer+1; i++ {
+		hub.broadcast(message)
+		<-fast.send
+	}
+
+	if hub.clientCount() != 1 {
+		t.Fatalf("clientCount() = %d, want 1 after dropping the slow client", hub.clientCount())
+	}
+	for range slow.send {
+		// drain what was queued; the channel is closed once the client is dropped
+	}
+
+	hub.unregister(slow) // already dropped, must not panic
+	hub.unregister(fast)
+	if _, ok := <-fast.send; ok {
+		t.Error("send channel still open after unregister")
+	}
+}
@@ -0,0 +1,31 @@
+// Package crd embeds the FrontendPage CRD so `k8s-cli install crd` and
+// `k8s-cli crd --install-crd` can install it without the repository checked out.
+package crd
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// frontendPagesManifest is regenerated from the kubebuilder markers in api/
+// with `make manifests`; it may carry samples and RBAC after the CRD itself
+//
+//go:embed bases/k8scli.dev_frontendpages.yaml
+var frontendPagesManifest []byte
+
+// FrontendPageCRD returns the CustomResourceDefinition from the first
+// document of bases/k8scli.dev_frontendpages.yaml
+func FrontendPageCRD() (*unstructured.Unstructured, error) {
+	var crd unstructured.Unstructured
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(frontendPagesManifest), 4096).Decode(&crd.Object); err != nil {
+		return nil, fmt.Errorf("error decoding embedded FrontendPage CRD: %w", err)
+	}
+	if crd.GetKind() != "CustomResourceDefinition" {
+		return nil, fmt.Errorf("embedded FrontendPage manifest starts with a %s, not a CustomResourceDefinition", crd.GetKind())
+	}
+	return &crd, nil
+}
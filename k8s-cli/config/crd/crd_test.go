@@ -0,0 +1,183 @@
+package crd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// TestFrontendPageCRDMatchesAPI catches an embedded CRD that drifted from the
+// types and kubebuilder markers in api/: a field missing from the schema is
+// pruned by the API server, a missing default or minimum is not enforced
+func TestFrontendPageCRDMatchesAPI(t *testing.T) {
+	crd, err := FrontendPageCRD()
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+	for _, version := range []string{"v1", "v2"} {
+		types, unserved := parseAPITypes(t, "../../api/"+version)
+		schema, served := versionSchema(t, versions, version)
+		if served == unserved {
+			t.Errorf("%s: served = %v, want %v from +kubebuilder:unservedversion", version, served, !unserved)
+		}
+
+		for typeName, property := range map[string]string{"FrontendPageSpec": "spec", "FrontendPageStatus": "status"} {
+			t.Run(version+"/"+property, func(t *testing.T) {
+				fields, ok := types[typeName]
+				if !ok {
+					t.Fatalf("api/%s has no %s", version, typeName)
+				}
+				properties, _, _ := unstructured.NestedMap(schema, "properties", property, "properties")
+
+				if got, want := sortedKeys(properties), sortedKeys(fields); !reflect.DeepEqual(got, want) {
+					t.Fatalf("schema properties = %v, want the json fields of %s %v", got, typeName, want)
+				}
+				for name, markers := range fields {
+					checkMarkers(t, name, properties[name].(map[string]interface{}), markers)
+				}
+			})
+		}
+	}
+}
+
+// fieldMarkers are the validation markers of one field that end up in its schema
+type fieldMarkers struct {
+	Default string
+	Minimum string
+	Enum    string
+}
+
+func checkMarkers(t *testing.T, name string, property map[string]interface{}, markers fieldMarkers) {
+	t.Helper()
+	if got := schemaValue(property["default"]); got != markers.Default {
+		t.Errorf("%s: default = %q, want %q from +kubebuilder:default", name, got, markers.Default)
+	}
+	if got := schemaValue(property["minimum"]); got != markers.Minimum {
+		t.Errorf("%s: minimum = %q, want %q from +kubebuilder:validation:Minimum", name, got, markers.Minimum)
+	}
+	var enum []string
+	if values, ok := property["enum"].([]interface{}); ok {
+		for _, value := range values {
+			enum = append(enum, schemaValue(value))
+		}
+	}
+	if got := strings.Join(enum, ";"); got != markers.Enum {
+		t.Errorf("%s: enum = %q, want %q from +kubebuilder:validation:Enum", name, got, markers.Enum)
+	}
+}
+
+func schemaValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// versionSchema returns the schema of the named CRD version and whether it is served
+func versionSchema(t *testing.T, versions []interface{}, name string) (map[string]interface{}, bool) {
+	t.Helper()
+	for _, version := range versions {
+		version := version.(map[string]interface{})
+		if version["name"] == name {
+			schema, _, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+			served, _, _ := unstructured.NestedBool(version, "served")
+			return schema, served
+		}
+	}
+	t.Fatalf("CRD has no version %s", name)
+	return nil, false
+}
+
+// parseAPITypes returns the json fields of every struct in dir with the
+// markers from their doc comments, and whether the version is marked unserved
+func parseAPITypes(t *testing.T, dir string) (map[string]map[string]fieldMarkers, bool) {
+	t.Helper()
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := map[string]map[string]fieldMarkers{}
+	unserved := false
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, group := range file.Comments {
+				for _, comment := range group.List {
+					if strings.TrimPrefix(comment.Text, "//") == "+kubebuilder:unservedversion" {
+						unserved = true
+					}
+				}
+			}
+			ast.Inspect(file, func(node ast.Node) bool {
+				spec, ok := node.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				structType, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					return false
+				}
+				fields := map[string]fieldMarkers{}
+				for _, field := range structType.Fields.List {
+					if name := jsonName(field); name != "" {
+						fields[name] = parseMarkers(field.Doc)
+					}
+				}
+				types[spec.Name.Name] = fields
+				return false
+			})
+		}
+	}
+	return types, unserved
+}
+
+func jsonName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name
+}
+
+func parseMarkers(doc *ast.CommentGroup) fieldMarkers {
+	var markers fieldMarkers
+	if doc == nil {
+		return markers
+	}
+	for _, comment := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if value, ok := strings.CutPrefix(line, "+kubebuilder:default="); ok {
+			markers.Default = strings.Trim(value, `"`)
+		}
+		if value, ok := strings.CutPrefix(line, "+kubebuilder:validation:Minimum="); ok {
+			markers.Minimum = value
+		}
+		if value, ok := strings.CutPrefix(line, "+kubebuilder:validation:Enum="); ok {
+			markers.Enum = value
+		}
+	}
+	return markers
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
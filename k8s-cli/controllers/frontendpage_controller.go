@@ -2,19 +2,31 @@ package controllers
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
-	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/time/rate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	k8scliv1 "k8s-cli/api/v1"
 )
@@ -23,6 +35,58 @@ import (
 type FrontendPageReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MaxConcurrentReconciles is how many FrontendPages are reconciled at once; 0 means 1.
+	// The workqueue never hands one FrontendPage to two workers, so more workers don't
+	// conflict with each other. Status updates can still conflict with other writers
+	// (the platform API, kubectl) and are retried, which costs more API calls; together
+	// with the extra workers that spends the client's QPS budget faster.
+	MaxConcurrentReconciles int
+
+	// RateLimiter paces requeues after errors; nil uses controller-runtime's default
+	RateLimiter ratelimiter.RateLimiter
+
+	// Tracer records a span per reconcile and per Deployment/Service write;
+	// nil traces nothing
+	Tracer trace.Tracer
+
+	// APIReader re-reads a FrontendPage after a status update conflict,
+	// bypassing the cache; nil falls back to Client
+	APIReader client.Reader
+}
+
+func (r *FrontendPageReconciler) tracer() trace.Tracer {
+	if r.Tracer == nil {
+		return noop.NewTracerProvider().Tracer("")
+	}
+	return r.Tracer
+}
+
+func (r *FrontendPageReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// endSpan marks span failed when err is set and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewFrontendPageRateLimiter backs off each failing FrontendPage exponentially and
+// caps requeues across all of them at qps with burst, so when many pages fail at
+// once (an API server outage, a mass change) they are retried at a steady rate
+// instead of all at the same moment.
+func NewFrontendPageRateLimiter(qps float64, burst int) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
 }
 
 //+kubebuilder:rbac:groups=k8scli.dev,resources=frontendpages,verbs=get;list;watch;create;update;patch;delete
@@ -33,26 +97,39 @@ type FrontendPageReconciler struct {
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log.Printf("🔄 Step 11: Reconciling FrontendPage %s/%s", req.Namespace, req.Name)
+	ctx, span := r.tracer().Start(ctx, "FrontendPage.Reconcile", trace.WithAttributes(
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8scli.frontendpage.name", req.Name),
+	))
+	result, err := r.reconcile(ctx, req)
+	endSpan(span, err)
+	return result, err
+}
+
+func (r *FrontendPageReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The logger from ctx already carries the request's namespace, name and reconcileID
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling FrontendPage")
 
 	// Fetch the FrontendPage instance
 	var frontendPage k8scliv1.FrontendPage
 	if err := r.Get(ctx, req.NamespacedName, &frontendPage); err != nil {
 		if errors.IsNotFound(err) {
-			log.Printf("🗑️ Step 11: FrontendPage %s/%s not found, probably deleted", req.Namespace, req.Name)
+			logger.Info("FrontendPage not found, probably deleted")
 			return ctrl.Result{}, nil
 		}
-		log.Printf("❌ Error fetching FrontendPage: %v", err)
+		logger.Error(err, "Failed to fetch FrontendPage")
 		return ctrl.Result{}, err
 	}
 
-	log.Printf("📊 Step 11: FrontendPage Details:")
-	log.Printf("   Title: %s", frontendPage.Spec.Title)
-	log.Printf("   Description: %s", frontendPage.Spec.Description)
-	log.Printf("   Path: %s", frontendPage.Spec.Path)
-	log.Printf("   Template: %s", frontendPage.Spec.Template)
-	log.Printf("   Replicas: %d", frontendPage.Spec.Replicas)
-	log.Printf("   Image: %s", frontendPage.Spec.Image)
+	logger = logger.WithValues("phase", frontendPage.Status.Phase)
+	logger.V(1).Info("FrontendPage details",
+		"title", frontendPage.Spec.Title,
+		"description", frontendPage.Spec.Description,
+		"path", frontendPage.Spec.Path,
+		"template", frontendPage.Spec.Template,
+		"replicas", frontendPage.Spec.Replicas,
+		"image", frontendPage.Spec.Image)
 
 	// Update status phase
 	if frontendPage.Status.Phase == "" {
@@ -64,18 +141,25 @@ func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Create or update deployment
 	deployment, err := r.createOrUpdateDeployment(ctx, &frontendPage)
+	var invalid *invalidSpecError
+	if stderrors.As(err, &invalid) {
+		// Retrying can't fix the spec; editing it triggers the next reconcile
+		logger.Info("FrontendPage spec is invalid", "reason", err.Error())
+		r.updateStatus(ctx, &frontendPage, "Failed", false, err.Error())
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
-		log.Printf("❌ Step 11: Failed to create/update deployment: %v", err)
+		logger.Error(err, "Failed to create/update deployment")
 		r.updateStatus(ctx, &frontendPage, "Failed", false, err.Error())
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+		return ctrl.Result{}, err
 	}
 
 	// Create or update service
 	service, err := r.createOrUpdateService(ctx, &frontendPage)
 	if err != nil {
-		log.Printf("❌ Step 11: Failed to create/update service: %v", err)
+		logger.Error(err, "Failed to create/update service")
 		r.updateStatus(ctx, &frontendPage, "Failed", false, err.Error())
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+		return ctrl.Result{}, err
 	}
 
 	// Check deployment readiness
@@ -89,140 +173,156 @@ func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	url := fmt.Sprintf("http://%s.%s.svc.cluster.local%s", service.Name, service.Namespace, frontendPage.Spec.Path)
 
-	frontendPage.Status.Phase = phase
-	frontendPage.Status.Ready = ready
-	frontendPage.Status.URL = url
-	frontendPage.Status.DeploymentName = deployment.Name
-	frontendPage.Status.ServiceName = service.Name
-	frontendPage.Status.LastUpdated = time.Now().Format(time.RFC3339)
-	frontendPage.Status.ObservedGeneration = frontendPage.Generation
-
+	message := fmt.Sprintf("Deployment %s is not ready yet", deployment.Name)
 	if ready {
-		frontendPage.Status.Message = fmt.Sprintf("Deployment %s is ready", deployment.Name)
-	} else {
-		frontendPage.Status.Message = fmt.Sprintf("Deployment %s is not ready yet", deployment.Name)
+		message = fmt.Sprintf("Deployment %s is ready", deployment.Name)
 	}
 
-	if err := r.Status().Update(ctx, &frontendPage); err != nil {
+	// A Service whose selector misses the pod labels routes nowhere even
+	// though the Deployment is healthy, so it overrides readiness
+	degraded := metav1.Condition{
+		Type:               k8scliv1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             k8scliv1.ReasonSelectorMatches,
+		Message:            fmt.Sprintf("Service %s selects the pods of Deployment %s", service.Name, deployment.Name),
+		ObservedGeneration: frontendPage.Generation,
+	}
+	if mismatch := selectorMismatch(service, deployment); mismatch != "" {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = k8scliv1.ReasonSelectorMismatch
+		degraded.Message = mismatch
+		ready, phase, message = false, "Degraded", mismatch
+	}
+
+	if err := UpdateStatusWithRetry(ctx, r.Client, r.apiReader(), &frontendPage, func() error {
+		frontendPage.Status.Phase = phase
+		frontendPage.Status.Ready = ready
+		frontendPage.Status.URL = url
+		frontendPage.Status.Replicas = deployment.Status.Replicas
+		frontendPage.Status.DeploymentName = deployment.Name
+		frontendPage.Status.ServiceName = service.Name
+		frontendPage.Status.LastUpdated = time.Now().Format(time.RFC3339)
+		frontendPage.Status.ObservedGeneration = frontendPage.Generation
+		frontendPage.Status.Message = message
+		meta.SetStatusCondition(&frontendPage.Status.Conditions, degraded)
+		return nil
+	}); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if ready {
-		log.Printf("✅ Step 11: FrontendPage %s/%s is ready at %s", req.Namespace, req.Name, url)
-	} else {
-		log.Printf("⏳ Step 11: FrontendPage %s/%s is not ready yet, requeuing...", req.Namespace, req.Name)
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	// No requeue while the Deployment rolls out: it is owned by the FrontendPage,
+	// so each change to its status triggers the next reconcile
+	logger = logger.WithValues("phase", phase)
+	switch {
+	case ready:
+		logger.Info("FrontendPage is ready", "url", url)
+	case degraded.Status == metav1.ConditionTrue:
+		logger.Info("FrontendPage is degraded", "reason", degraded.Reason, "message", degraded.Message)
+	default:
+		logger.Info("FrontendPage is not ready yet, waiting for the deployment", "deployment", deployment.Name)
 	}
 
-	log.Printf("🎯 Step 11: Reconciliation completed for FrontendPage %s/%s", req.Namespace, req.Name)
+	logger.Info("Reconciliation completed")
 	return ctrl.Result{}, nil
 }
 
-func (r *FrontendPageReconciler) createOrUpdateDeployment(ctx context.Context, frontendPage *k8scliv1.FrontendPage) (*appsv1.Deployment, error) {
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      frontendPage.Name + "-deployment",
-			Namespace: frontendPage.Namespace,
-		},
+// DesiredDeployment builds the Deployment the controller keeps for frontendPage,
+// with frontendPage as its controller. It only reads its arguments, so it can
+// run outside Reconcile, e.g. for `k8s-cli frontendpage reconcile --dry-run`.
+// A page k8scliv1.ValidateFrontendPage rejects, such as a proxy without an
+// upstream, is an error rather than a Deployment that can't start.
+func DesiredDeployment(frontendPage *k8scliv1.FrontendPage, scheme *runtime.Scheme) (*appsv1.Deployment, error) {
+	if err := k8scliv1.ValidateFrontendPage(frontendPage); err != nil {
+		return nil, &invalidSpecError{err: err}
 	}
 
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		// Set owner reference
-		if err := controllerutil.SetControllerReference(frontendPage, deployment, r.Scheme); err != nil {
-			return err
-		}
+	replicas := frontendPage.Spec.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
 
-		// Configure deployment spec
-		replicas := frontendPage.Spec.Replicas
-		if replicas == 0 {
-			replicas = 1
-		}
+	template := templateFor(frontendPage)
+	image := frontendPage.Spec.Image
+	if image == "" {
+		image = template.image
+	}
 
-		image := frontendPage.Spec.Image
-		if image == "" {
-			image = "nginx:1.20"
-		}
+	labels := map[string]string{
+		"app":          frontendPage.Name,
+		"frontendpage": frontendPage.Name,
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "FRONTEND_TITLE",
+			Value: frontendPage.Spec.Title,
+		},
+		{
+			Name:  "FRONTEND_DESCRIPTION",
+			Value: frontendPage.Spec.Description,
+		},
+		{
+			Name:  "FRONTEND_PATH",
+			Value: frontendPage.Spec.Path,
+		},
+	}
+	// Add template and config values as environment variables, config in key
+	// order so an unchanged FrontendPage always renders the same pod template
+	env = append(env, templateEnv(frontendPage)...)
+	for _, key := range sortedKeys(frontendPage.Spec.Config) {
+		env = append(env, corev1.EnvVar{Name: key, Value: frontendPage.Spec.Config[key]})
+	}
 
-		deployment.Spec = appsv1.DeploymentSpec{
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      frontendPage.Name + "-deployment",
+			Namespace: frontendPage.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":          frontendPage.Name,
-					"frontendpage": frontendPage.Name,
-				},
+				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":          frontendPage.Name,
-						"frontendpage": frontendPage.Name,
-					},
+					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  "frontend",
-							Image: image,
+							Name:    "frontend",
+							Image:   image,
+							Command: template.command,
+							Args:    template.args,
 							Ports: []corev1.ContainerPort{
 								{
 									ContainerPort: 80,
 									Name:          "http",
 								},
 							},
-							Env: []corev1.EnvVar{
-								{
-									Name:  "FRONTEND_TITLE",
-									Value: frontendPage.Spec.Title,
-								},
-								{
-									Name:  "FRONTEND_DESCRIPTION",
-									Value: frontendPage.Spec.Description,
-								},
-								{
-									Name:  "FRONTEND_PATH",
-									Value: frontendPage.Spec.Path,
-								},
-							},
+							Env: env,
 						},
 					},
 				},
 			},
-		}
-
-		// Add config as environment variables
-		for key, value := range frontendPage.Spec.Config {
-			deployment.Spec.Template.Spec.Containers[0].Env = append(
-				deployment.Spec.Template.Spec.Containers[0].Env,
-				corev1.EnvVar{Name: key, Value: value},
-			)
-		}
-
-		return nil
-	})
+		},
+	}
 
-	if err != nil {
+	if err := controllerutil.SetControllerReference(frontendPage, deployment, scheme); err != nil {
 		return nil, err
 	}
-
-	log.Printf("🔨 Step 11: Deployment %s %s", deployment.Name, op)
 	return deployment, nil
 }
 
-func (r *FrontendPageReconciler) createOrUpdateService(ctx context.Context, frontendPage *k8scliv1.FrontendPage) (*corev1.Service, error) {
+// DesiredService builds the Service the controller keeps for frontendPage;
+// like DesiredDeployment it has no side effects
+func DesiredService(frontendPage *k8scliv1.FrontendPage, scheme *runtime.Scheme) (*corev1.Service, error) {
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      frontendPage.Name + "-service",
 			Namespace: frontendPage.Namespace,
 		},
-	}
-
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
-		// Set owner reference
-		if err := controllerutil.SetControllerReference(frontendPage, service, r.Scheme); err != nil {
-			return err
-		}
-
-		service.Spec = corev1.ServiceSpec{
+		Spec: corev1.ServiceSpec{
 			Type: corev1.ServiceTypeClusterIP,
 			Selector: map[string]string{
 				"app":          frontendPage.Name,
@@ -236,33 +336,138 @@ func (r *FrontendPageReconciler) createOrUpdateService(ctx context.Context, fron
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(frontendPage, service, scheme); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// selectorMismatch explains why service would not route to deployment's pods,
+// or returns "" when every selector label is on the pod template
+func selectorMismatch(service *corev1.Service, deployment *appsv1.Deployment) string {
+	if len(service.Spec.Selector) == 0 {
+		return fmt.Sprintf("Service %s has no selector, so it routes to no pods of Deployment %s", service.Name, deployment.Name)
+	}
+
+	podLabels := deployment.Spec.Template.Labels
+	var mismatched []string
+	for _, key := range sortedKeys(service.Spec.Selector) {
+		want := service.Spec.Selector[key]
+		got, ok := podLabels[key]
+		switch {
+		case !ok:
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s (pods don't have it)", key, want))
+		case got != want:
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s (pods have %s)", key, want, got))
 		}
+	}
+	if len(mismatched) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Service %s selects no pods of Deployment %s: %s", service.Name, deployment.Name, strings.Join(mismatched, ", "))
+}
 
-		return nil
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MutateDeployment applies desired to the live Deployment the way the
+// controller does: the spec is replaced, other fields are left alone
+func MutateDeployment(live, desired *appsv1.Deployment, owner *k8scliv1.FrontendPage, scheme *runtime.Scheme) error {
+	if err := controllerutil.SetControllerReference(owner, live, scheme); err != nil {
+		return err
+	}
+	live.Spec = desired.Spec
+	return nil
+}
+
+// MutateService is MutateDeployment for the Service
+func MutateService(live, desired *corev1.Service, owner *k8scliv1.FrontendPage, scheme *runtime.Scheme) error {
+	if err := controllerutil.SetControllerReference(owner, live, scheme); err != nil {
+		return err
+	}
+	live.Spec = desired.Spec
+	return nil
+}
+
+func (r *FrontendPageReconciler) createOrUpdateDeployment(ctx context.Context, frontendPage *k8scliv1.FrontendPage) (_ *appsv1.Deployment, err error) {
+	ctx, span := r.tracer().Start(ctx, "FrontendPage.createOrUpdateDeployment")
+	defer func() { endSpan(span, err) }()
+
+	desired, err := DesiredDeployment(frontendPage, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("k8s.deployment.name", desired.Name))
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		return MutateDeployment(deployment, desired, frontendPage, r.Scheme)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("k8scli.operation", string(op)))
+	log.FromContext(ctx).Info("Deployment reconciled", "deployment", deployment.Name, "operation", op)
+	return deployment, nil
+}
+
+func (r *FrontendPageReconciler) createOrUpdateService(ctx context.Context, frontendPage *k8scliv1.FrontendPage) (_ *corev1.Service, err error) {
+	ctx, span := r.tracer().Start(ctx, "FrontendPage.createOrUpdateService")
+	defer func() { endSpan(span, err) }()
+
+	desired, err := DesiredService(frontendPage, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("k8s.service.name", desired.Name))
 
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		return MutateService(service, desired, frontendPage, r.Scheme)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("🔨 Step 11: Service %s %s", service.Name, op)
+	span.SetAttributes(attribute.String("k8scli.operation", string(op)))
+	log.FromContext(ctx).Info("Service reconciled", "service", service.Name, "operation", op)
 	return service, nil
 }
 
 func (r *FrontendPageReconciler) updateStatus(ctx context.Context, frontendPage *k8scliv1.FrontendPage, phase string, ready bool, message string) {
-	frontendPage.Status.Phase = phase
-	frontendPage.Status.Ready = ready
-	frontendPage.Status.LastUpdated = time.Now().Format(time.RFC3339)
-	frontendPage.Status.Message = message
-	frontendPage.Status.ObservedGeneration = frontendPage.Generation
-	r.Status().Update(ctx, frontendPage)
+	if err := UpdateStatusWithRetry(ctx, r.Client, r.apiReader(), frontendPage, func() error {
+		frontendPage.Status.Phase = phase
+		frontendPage.Status.Ready = ready
+		frontendPage.Status.LastUpdated = time.Now().Format(time.RFC3339)
+		frontendPage.Status.Message = message
+		frontendPage.Status.ObservedGeneration = frontendPage.Generation
+		return nil
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update FrontendPage status", "phase", phase)
+	}
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. Owns watches the
+// Deployment and Service through their controller reference, which is how
+// readiness changes reach the owning FrontendPage.
 func (r *FrontendPageReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&k8scliv1.FrontendPage{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter,
+		}).
 		Complete(r)
 }
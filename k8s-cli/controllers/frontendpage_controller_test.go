@@ -0,0 +1,345 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+// TestFrontendPageReadinessFollowsDeployment walks a Deployment becoming ready:
+// the update event maps to the owning FrontendPage the way Owns wires it, and
+// the reconcile it triggers marks the page Ready without any timed requeue.
+func TestFrontendPageReadinessFollowsDeployment(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Path: "/shop", Replicas: 2},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page).WithStatusSubresource(page).Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "web", Name: "shop"}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.Requeue || result.RequeueAfter != 0 {
+		t.Errorf("Reconcile() = %+v, want no timed requeue while the deployment rolls out", result)
+	}
+	assertPageReady(t, c, req.NamespacedName, false)
+
+	// The Deployment reports its replicas ready
+	var deployment appsv1.Deployment
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "web", Name: "shop-deployment"}, &deployment); err != nil {
+		t.Fatalf("Get(deployment) error = %v", err)
+	}
+	before := deployment.DeepCopy()
+	deployment.Status.Replicas, deployment.Status.ReadyReplicas = 2, 2
+	if err := c.Status().Update(ctx, &deployment); err != nil {
+		t.Fatalf("Update(deployment status) error = %v", err)
+	}
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	owner := handler.EnqueueRequestForOwner(scheme, testrestmapper.TestOnlyStaticRESTMapper(scheme), &k8scliv1.FrontendPage{}, handler.OnlyControllerOwner())
+	owner.Update(ctx, event.UpdateEvent{ObjectOld: before, ObjectNew: &deployment}, queue)
+	if queue.Len() != 1 {
+		t.Fatalf("deployment update enqueued %d requests, want 1", queue.Len())
+	}
+	item, _ := queue.Get()
+	if item != req {
+		t.Fatalf("deployment update enqueued %v, want %v", item, req)
+	}
+
+	if _, err := r.Reconcile(ctx, item.(ctrl.Request)); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertPageReady(t, c, req.NamespacedName, true)
+}
+
+// TestFrontendPageProxyWithoutUpstreamFails covers a page that got past a
+// missing validating webhook: it is marked Failed, and no Deployment with an
+// nginx config that can't start is rendered
+func TestFrontendPageProxyWithoutUpstreamFails(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "API", Path: "/api", Template: k8scliv1.TemplateProxy},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page).WithStatusSubresource(page).Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "web", Name: "api"}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil || result.Requeue || result.RequeueAfter != 0 {
+		t.Fatalf("Reconcile() = %+v, %v; want no error and no requeue for an invalid spec", result, err)
+	}
+
+	var got k8scliv1.FrontendPage
+	if err := c.Get(ctx, key, &got); err != nil {
+		t.Fatalf("Get(frontendpage) error = %v", err)
+	}
+	if got.Status.Phase != "Failed" || !strings.Contains(got.Status.Message, "upstream") {
+		t.Errorf("status = phase %q, message %q; want Failed with the missing upstream", got.Status.Phase, got.Status.Message)
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := c.List(ctx, &deployments); err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments.Items) != 0 {
+		t.Errorf("rendered %d deployments for a proxy without an upstream", len(deployments.Items))
+	}
+}
+
+func assertPageReady(t *testing.T, c client.Client, key types.NamespacedName, want bool) {
+	t.Helper()
+
+	var page k8scliv1.FrontendPage
+	if err := c.Get(context.Background(), key, &page); err != nil {
+		t.Fatalf("Get(frontendpage) error = %v", err)
+	}
+	wantPhase := "Pending"
+	if want {
+		wantPhase = "Running"
+	}
+	if page.Status.Ready != want || page.Status.Phase != wantPhase {
+		t.Errorf("status = ready %v, phase %q; want ready %v, phase %q", page.Status.Ready, page.Status.Phase, want, wantPhase)
+	}
+}
+
+// TestDesiredDeploymentIsDeterministic keeps config env vars in key order, so
+// reconciling an unchanged FrontendPage never rewrites its pod template
+func TestDesiredDeploymentIsDeterministic(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec: k8scliv1.FrontendPageSpec{
+			Title:  "Shop",
+			Path:   "/shop",
+			Config: map[string]string{"THEME": "dark", "API_URL": "http://api", "LOCALE": "en", "CDN": "https://cdn"},
+		},
+	}
+	first, err := DesiredDeployment(page, scheme)
+	if err != nil {
+		t.Fatalf("DesiredDeployment() error = %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, _ := DesiredDeployment(page, scheme)
+		if !equality.Semantic.DeepEqual(first.Spec, again.Spec) {
+			t.Fatalf("DesiredDeployment() differs between calls:\n%+v\n%+v", first.Spec.Template.Spec.Containers[0].Env, again.Spec.Template.Spec.Containers[0].Env)
+		}
+	}
+
+	var names []string
+	for _, env := range first.Spec.Template.Spec.Containers[0].Env[3:] {
+		names = append(names, env.Name)
+	}
+	if want := []string{"API_URL", "CDN", "LOCALE", "THEME"}; !slices.Equal(names, want) {
+		t.Errorf("config env = %v, want %v", names, want)
+	}
+	if owner := metav1.GetControllerOf(first); owner == nil || owner.Name != "shop" {
+		t.Errorf("controller = %+v, want the FrontendPage", owner)
+	}
+}
+
+func TestSelectorMismatch(t *testing.T) {
+	selector := map[string]string{"app": "shop", "frontendpage": "shop"}
+
+	tests := []struct {
+		name      string
+		selector  map[string]string
+		podLabels map[string]string
+		want      string
+	}{
+		{
+			name:      "matching labels",
+			selector:  selector,
+			podLabels: map[string]string{"app": "shop", "frontendpage": "shop"},
+		},
+		{
+			name:      "pods carry extra labels",
+			selector:  selector,
+			podLabels: map[string]string{"app": "shop", "frontendpage": "shop", "tier": "web"},
+		},
+		{
+			name:      "label changed on the pods",
+			selector:  selector,
+			podLabels: map[string]string{"app": "shop-v2", "frontendpage": "shop"},
+			want:      "Service shop-service selects no pods of Deployment shop-deployment: app=shop (pods have shop-v2)",
+		},
+		{
+			name:      "label missing on the pods",
+			selector:  selector,
+			podLabels: map[string]string{"app": "shop"},
+			want:      "Service shop-service selects no pods of Deployment shop-deployment: frontendpage=shop (pods don't have it)",
+		},
+		{
+			name:      "empty selector",
+			podLabels: map[string]string{"app": "shop"},
+			want:      "Service shop-service has no selector, so it routes to no pods of Deployment shop-deployment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "shop-service"}, Spec: corev1.ServiceSpec{Selector: tt.selector}}
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "shop-deployment"}}
+			deployment.Spec.Template.Labels = tt.podLabels
+
+			if got := selectorMismatch(service, deployment); got != tt.want {
+				t.Errorf("selectorMismatch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFrontendPageDegradedOnSelectorMismatch has an admission step relabel the
+// pods, as a mutating webhook might, and expects the page to report Degraded
+// instead of Ready
+func TestFrontendPageDegradedOnSelectorMismatch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Path: "/shop"},
+	}
+	relabel := func(obj client.Object) {
+		if deployment, ok := obj.(*appsv1.Deployment); ok {
+			deployment.Spec.Template.Labels["app"] = "shop-canary"
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page).WithStatusSubresource(page).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				relabel(obj)
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				relabel(obj)
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+	key := types.NamespacedName{Namespace: "web", Name: "shop"}
+
+	// Even with every replica ready the page must not report Ready
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	var deployment appsv1.Deployment
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "web", Name: "shop-deployment"}, &deployment); err != nil {
+		t.Fatalf("Get(deployment) error = %v", err)
+	}
+	deployment.Status.Replicas, deployment.Status.ReadyReplicas = 1, 1
+	if err := c.Status().Update(context.Background(), &deployment); err != nil {
+		t.Fatalf("Update(deployment status) error = %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	var got k8scliv1.FrontendPage
+	if err := c.Get(context.Background(), key, &got); err != nil {
+		t.Fatalf("Get(frontendpage) error = %v", err)
+	}
+	if got.Status.Ready || got.Status.Phase != "Degraded" {
+		t.Errorf("status = ready %v, phase %q; want not ready, phase Degraded", got.Status.Ready, got.Status.Phase)
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, k8scliv1.ConditionDegraded)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != k8scliv1.ReasonSelectorMismatch {
+		t.Fatalf("Degraded condition = %+v, want True/%s", condition, k8scliv1.ReasonSelectorMismatch)
+	}
+	if !strings.Contains(condition.Message, "app=shop (pods have shop-canary)") {
+		t.Errorf("Degraded message = %q", condition.Message)
+	}
+}
+
+func TestFrontendPageReconcileSpans(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Path: "/shop"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page).WithStatusSubresource(page).Build()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme, Tracer: provider.Tracer("test")}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "web", Name: "shop"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	root, ok := spans["FrontendPage.Reconcile"]
+	if !ok {
+		t.Fatalf("no FrontendPage.Reconcile span in %v", spans)
+	}
+	attrs := map[attribute.Key]string{}
+	for _, kv := range root.Attributes() {
+		attrs[kv.Key] = kv.Value.AsString()
+	}
+	if attrs["k8s.namespace.name"] != "web" || attrs["k8scli.frontendpage.name"] != "shop" {
+		t.Errorf("Reconcile span attributes = %v", attrs)
+	}
+
+	for _, name := range []string{"FrontendPage.createOrUpdateDeployment", "FrontendPage.createOrUpdateService"} {
+		child, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span", name)
+			continue
+		}
+		if child.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the Reconcile span", name)
+		}
+	}
+}
+
+func TestFrontendPageReconcileWithoutTracer(t *testing.T) {
+	r := &FrontendPageReconciler{}
+	if _, span := r.tracer().Start(context.Background(), "x"); span.IsRecording() {
+		t.Error("a reconciler without Tracer should not record spans")
+	}
+}
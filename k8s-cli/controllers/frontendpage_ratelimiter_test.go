@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFrontendPageRateLimiter(t *testing.T) {
+	limiter := NewFrontendPageRateLimiter(1, 3)
+
+	// The burst covers the first requeues; after that the shared bucket paces them
+	for i := 0; i < 3; i++ {
+		if delay := limiter.When(fmt.Sprintf("page-%d", i)); delay > time.Second/10 {
+			t.Errorf("requeue %d delayed %s inside the burst", i, delay)
+		}
+	}
+	if delay := limiter.When("page-3"); delay < time.Second/2 {
+		t.Errorf("requeue past the burst delayed %s, want about 1s", delay)
+	}
+
+	// Each page also tracks its own failures for exponential backoff
+	limiter.When("page-0")
+	if got := limiter.NumRequeues("page-0"); got != 2 {
+		t.Errorf("NumRequeues = %d, want 2", got)
+	}
+	limiter.Forget("page-0")
+	if got := limiter.NumRequeues("page-0"); got != 0 {
+		t.Errorf("NumRequeues after Forget = %d, want 0", got)
+	}
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+// frontendTemplate is how a FrontendPage template renders the frontend container
+type frontendTemplate struct {
+	// image is used when Spec.Image is empty
+	image string
+	// command and args replace the image's entrypoint when set
+	command []string
+	args    []string
+}
+
+// spaConfig serves index.html for any path that isn't a file, so client-side
+// routes survive a reload
+const spaConfig = `cat > /etc/nginx/conf.d/default.conf <<'EOF'
+server {
+    listen 80;
+    root /usr/share/nginx/html;
+    location / {
+        try_files $uri $uri/ /index.html;
+    }
+}
+EOF
+exec nginx -g 'daemon off;'`
+
+// proxyConfig forwards every request to $UPSTREAM_URL. The URL is passed to
+// printf as an argument rather than expanded into the config by the shell.
+const proxyConfig = `printf 'server {\n    listen 80;\n    location / {\n        proxy_pass %s;\n        proxy_set_header Host $proxy_host;\n        proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n    }\n}\n' "$UPSTREAM_URL" > /etc/nginx/conf.d/default.conf
+exec nginx -g 'daemon off;'`
+
+var frontendTemplates = map[string]frontendTemplate{
+	k8scliv1.TemplateStatic: {image: "nginx:1.20"},
+	k8scliv1.TemplateSPA: {
+		image:   "nginx:1.25-alpine",
+		command: []string{"/bin/sh", "-c"},
+		args:    []string{spaConfig},
+	},
+	k8scliv1.TemplateProxy: {
+		image:   "nginx:1.25-alpine",
+		command: []string{"/bin/sh", "-c"},
+		args:    []string{proxyConfig},
+	},
+}
+
+// invalidSpecError is a FrontendPage that k8scliv1.ValidateFrontendPage
+// rejects; reconciling it again can't help until its spec changes
+type invalidSpecError struct {
+	err error
+}
+
+func (e *invalidSpecError) Error() string { return e.err.Error() }
+func (e *invalidSpecError) Unwrap() error { return e.err }
+
+// templateFor returns the template a FrontendPage renders with. Unknown names
+// fall back to the default; DesiredDeployment rejects them before getting here.
+func templateFor(frontendPage *k8scliv1.FrontendPage) frontendTemplate {
+	if template, ok := frontendTemplates[frontendPage.Spec.Template]; ok {
+		return template
+	}
+	return frontendTemplates[k8scliv1.DefaultTemplate]
+}
+
+// templateEnv is the extra environment a template needs; proxy reads its
+// upstream from Spec.Config
+func templateEnv(frontendPage *k8scliv1.FrontendPage) []corev1.EnvVar {
+	if frontendPage.Spec.Template != k8scliv1.TemplateProxy {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name:  "UPSTREAM_URL",
+		Value: frontendPage.Spec.Config[k8scliv1.UpstreamConfigKey],
+	}}
+}
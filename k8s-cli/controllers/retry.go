@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RetryOnConflict calls write until it stops failing with a conflict.
+// write is expected to mutate obj and persist it. The first attempt uses obj as the caller fetched it;
+// after a conflict obj is re-read through reader, which should be uncached (the manager's APIReader)
+// so the retry sees the version that won instead of a cache that may not have caught up yet.
+func RetryOnConflict(ctx context.Context, reader client.Reader, obj client.Object, write func() error) error {
+	key := client.ObjectKeyFromObject(obj)
+	conflicted := false
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if conflicted {
+			if err := reader.Get(ctx, key, obj); err != nil {
+				return err
+			}
+		}
+		conflicted = true
+		return write()
+	})
+}
+
+// UpdateWithRetry applies mutate to obj and updates it, re-reading obj through reader and
+// re-applying mutate on conflicts
+func UpdateWithRetry(ctx context.Context, c client.Client, reader client.Reader, obj client.Object, mutate func() error) error {
+	return RetryOnConflict(ctx, reader, obj, func() error {
+		if err := mutate(); err != nil {
+			return err
+		}
+		return c.Update(ctx, obj)
+	})
+}
+
+// UpdateStatusWithRetry is UpdateWithRetry for the status subresource
+func UpdateStatusWithRetry(ctx context.Context, c client.Client, reader client.Reader, obj client.Object, mutate func() error) error {
+	return RetryOnConflict(ctx, reader, obj, func() error {
+		if err := mutate(); err != nil {
+			return err
+		}
+		return c.Status().Update(ctx, obj)
+	})
+}
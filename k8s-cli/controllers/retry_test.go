@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+// countingReader counts the re-reads RetryOnConflict makes
+type countingReader struct {
+	client.Reader
+	gets int
+}
+
+func (r *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	r.gets++
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func TestUpdateWithRetryReadsOnlyAfterConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(k8scliv1.AddToScheme(scheme))
+	page := &k8scliv1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Replicas: 1},
+	}
+
+	tests := []struct {
+		name      string
+		conflicts int
+		wantGets  int
+	}{
+		{name: "no conflict", conflicts: 0, wantGets: 0},
+		{name: "one conflict", conflicts: 1, wantGets: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := tt.conflicts
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(page.DeepCopy()).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if conflicts > 0 {
+						conflicts--
+						return apierrors.NewConflict(k8scliv1.GroupVersion.WithResource("frontendpages").GroupResource(), obj.GetName(), nil)
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			}).Build()
+			reader := &countingReader{Reader: c}
+			ctx := context.Background()
+
+			var current k8scliv1.FrontendPage
+			if err := c.Get(ctx, client.ObjectKeyFromObject(page), &current); err != nil {
+				t.Fatal(err)
+			}
+			if err := UpdateWithRetry(ctx, c, reader, &current, func() error {
+				current.Spec.Replicas = 3
+				return nil
+			}); err != nil {
+				t.Fatalf("UpdateWithRetry() error = %v", err)
+			}
+			if reader.gets != tt.wantGets {
+				t.Errorf("reader Get calls = %d, want %d", reader.gets, tt.wantGets)
+			}
+
+			var updated k8scliv1.FrontendPage
+			if err := c.Get(ctx, client.ObjectKeyFromObject(page), &updated); err != nil {
+				t.Fatal(err)
+			}
+			if updated.Spec.Replicas != 3 {
+				t.Errorf("replicas = %d, want 3", updated.Spec.Replicas)
+			}
+		})
+	}
+}
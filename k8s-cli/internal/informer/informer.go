@@ -0,0 +1,393 @@
+package informer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EventType says what happened to a Deployment
+type EventType string
+
+const (
+	Added    EventType = "ADDED"
+	Modified EventType = "MODIFIED"
+	Deleted  EventType = "DELETED"
+)
+
+// Event is one change to a watched Deployment. Old is the previous version
+// and is only set for Modified.
+type Event struct {
+	Type       EventType
+	Deployment *appsv1.Deployment
+	Old        *appsv1.Deployment
+}
+
+// Index names for ByIndex. NamespaceIndex is always present; the others are
+// added with AddIndexers(Indexers()).
+const (
+	NamespaceIndex = cache.NamespaceIndex
+	ImageIndex     = "byImage"
+	LabelIndex     = "byLabel"
+)
+
+// Indexers returns the image and label indexers
+func Indexers() cache.Indexers {
+	return cache.Indexers{
+		ImageIndex: ImageIndexFunc,
+		LabelIndex: LabelIndexFunc,
+	}
+}
+
+// ImageIndexFunc indexes a Deployment under the image of every container
+func ImageIndexFunc(obj interface{}) ([]string, error) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil, nil
+	}
+	var images []string
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images, nil
+}
+
+// LabelIndexFunc indexes a Deployment under "key=value" for every label
+func LabelIndexFunc(obj interface{}) ([]string, error) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil, nil
+	}
+	values := make([]string, 0, len(deployment.Labels))
+	for key, value := range deployment.Labels {
+		values = append(values, key+"="+value)
+	}
+	return values, nil
+}
+
+// Options configures an Informer
+type Options struct {
+	// ResyncPeriod replays the cache as Modified events; 0 disables resync
+	ResyncPeriod time.Duration
+	// Namespace limits the watch to one namespace; empty watches all
+	Namespace string
+}
+
+// Informer watches Deployments with a shared informer, keeps them in a local
+// cache and fans changes out to subscribers. It knows nothing about how the
+// changes are served, so a CLI, an HTTP API or a test can all embed it.
+type Informer struct {
+	factory  informers.SharedInformerFactory
+	informer cache.SharedIndexInformer
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	synced   atomic.Bool
+
+	mu          sync.RWMutex
+	subscribers []*subscriber
+
+	// Watch health, reported by Status
+	statusMu        sync.Mutex
+	lastEvent       time.Time
+	resourceVersion string
+	watchErr        error
+	watchErrVersion string
+}
+
+// WatchStatus describes the informer's connection to the API server
+type WatchStatus struct {
+	// Connected is false before the cache has synced and after a failed
+	// list or watch until the informer makes progress again
+	Connected bool
+	// LastEventTime is when the informer last delivered an event or a
+	// resync, or saw a newer resource version
+	LastEventTime time.Time
+	// LastError is the list or watch failure that disconnected it
+	LastError string
+}
+
+// New creates an Informer for clientset; nothing is listed or watched until Start
+func New(clientset kubernetes.Interface, opts Options) *Informer {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, opts.ResyncPeriod, informers.WithNamespace(opts.Namespace))
+	i := &Informer{
+		factory:  factory,
+		informer: factory.Apps().V1().Deployments().Informer(),
+		stop:     make(chan struct{}),
+	}
+
+	// The reflector retries on its own; remember the failure so Status can
+	// report the watch as disconnected meanwhile
+	_ = i.informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		i.recordWatchError(err)
+		cache.DefaultWatchErrorHandler(r, err)
+	})
+
+	i.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			i.recordEvent()
+			if deployment, ok := obj.(*appsv1.Deployment); ok {
+				i.publish(Event{Type: Added, Deployment: deployment})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			i.recordEvent()
+			oldDeployment, oldOK := oldObj.(*appsv1.Deployment)
+			newDeployment, newOK := newObj.(*appsv1.Deployment)
+			if oldOK && newOK {
+				i.publish(Event{Type: Modified, Deployment: newDeployment, Old: oldDeployment})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			i.recordEvent()
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if deployment, ok := obj.(*appsv1.Deployment); ok {
+				i.publish(Event{Type: Deleted, Deployment: deployment})
+			}
+		},
+	})
+	return i
+}
+
+// SubscribeOptions sets what publish does while a subscriber's channel is full
+type SubscribeOptions struct {
+	// Block waits until the channel receives, Unsubscribe or Stop. A blocking
+	// subscriber that stops reading holds up every later event for all
+	// subscribers, so it must keep reading.
+	Block bool
+	// Timeout is how long a non-blocking send waits before the event is
+	// dropped; zero drops it at once, so an unbuffered channel without Block
+	// needs a Timeout
+	Timeout time.Duration
+	// OnDrop, if set, is called with each dropped event and the number
+	// dropped so far for this subscriber
+	OnDrop func(event Event, dropped int64)
+}
+
+type subscriber struct {
+	ch   chan<- Event
+	opts SubscribeOptions
+
+	// sendMu is held for each send, so Unsubscribe can wait out one in flight
+	sendMu   sync.Mutex
+	removed  bool
+	done     chan struct{}
+	doneOnce sync.Once
+	dropped  int64
+}
+
+// Subscribe delivers every later event to ch, in order, with the overflow
+// policy of opts. Subscribe before Start to also get an Added event for each
+// Deployment listed at startup. The Deployments are shared with the cache and
+// must not be modified.
+func (i *Informer) Subscribe(ch chan<- Event, opts SubscribeOptions) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.subscribers = append(i.subscribers, &subscriber{ch: ch, opts: opts, done: make(chan struct{})})
+}
+
+// Unsubscribe stops delivery to ch, releasing a send blocked on it. Once it
+// returns nothing is sent to ch any more, so the caller may close it.
+// Unknown channels are ignored.
+func (i *Informer) Unsubscribe(ch chan<- Event) {
+	i.mu.Lock()
+	var sub *subscriber
+	for n, s := range i.subscribers {
+		if s.ch == ch {
+			sub = s
+			i.subscribers = append(i.subscribers[:n:n], i.subscribers[n+1:]...)
+			break
+		}
+	}
+	i.mu.Unlock()
+	if sub == nil {
+		return
+	}
+
+	sub.doneOnce.Do(func() { close(sub.done) })
+	sub.sendMu.Lock()
+	sub.removed = true
+	sub.sendMu.Unlock()
+}
+
+// publish sends event to a snapshot of the subscribers, so a slow subscriber
+// never holds the lock that Subscribe and Unsubscribe need
+func (i *Informer) publish(event Event) {
+	i.mu.RLock()
+	subscribers := make([]*subscriber, len(i.subscribers))
+	copy(subscribers, i.subscribers)
+	i.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if !i.send(sub, event) {
+			return
+		}
+	}
+}
+
+// send delivers event to sub according to its options; it returns false once
+// the informer is stopped
+func (i *Informer) send(sub *subscriber, event Event) bool {
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+	if sub.removed {
+		return true
+	}
+
+	if sub.opts.Block {
+		select {
+		case sub.ch <- event:
+		case <-sub.done:
+		case <-i.stop:
+			return false
+		}
+		return true
+	}
+
+	select {
+	case sub.ch <- event:
+		return true
+	default:
+	}
+	if sub.opts.Timeout > 0 {
+		timer := time.NewTimer(sub.opts.Timeout)
+		defer timer.Stop()
+		select {
+		case sub.ch <- event:
+			return true
+		case <-sub.done:
+			return true
+		case <-i.stop:
+			return false
+		case <-timer.C:
+		}
+	}
+
+	sub.dropped++
+	if sub.opts.OnDrop != nil {
+		sub.opts.OnDrop(event, sub.dropped)
+	}
+	return true
+}
+
+// Start starts watching and returns once the cache has synced, or with an
+// error if ctx ends first. The informer runs until Stop.
+func (i *Informer) Start(ctx context.Context) error {
+	i.factory.Start(i.stop)
+	if !cache.WaitForCacheSync(ctx.Done(), i.informer.HasSynced) {
+		return fmt.Errorf("failed to sync informer cache")
+	}
+	i.recordEvent()
+	i.synced.Store(true)
+	return nil
+}
+
+// Stop stops the watch and unblocks pending sends to subscribers; it is safe
+// to call more than once
+func (i *Informer) Stop() {
+	i.stopOnce.Do(func() {
+		close(i.stop)
+		i.factory.Shutdown()
+	})
+}
+
+// HasSynced reports whether Start has filled the cache
+func (i *Informer) HasSynced() bool {
+	return i.synced.Load()
+}
+
+// Status reports whether the watch is connected and when it was last active.
+// A reconnect is noticed by the resource version moving past the one cached
+// when the watch failed.
+func (i *Informer) Status() WatchStatus {
+	version := i.informer.LastSyncResourceVersion()
+
+	i.statusMu.Lock()
+	defer i.statusMu.Unlock()
+	if version != i.resourceVersion {
+		i.resourceVersion = version
+		i.lastEvent = time.Now()
+	}
+	if i.watchErr != nil && version != i.watchErrVersion {
+		i.watchErr = nil
+	}
+
+	status := WatchStatus{
+		Connected:     i.HasSynced() && i.watchErr == nil,
+		LastEventTime: i.lastEvent,
+	}
+	if i.watchErr != nil {
+		status.LastError = i.watchErr.Error()
+	}
+	return status
+}
+
+func (i *Informer) recordEvent() {
+	i.statusMu.Lock()
+	defer i.statusMu.Unlock()
+	i.lastEvent = time.Now()
+}
+
+func (i *Informer) recordWatchError(err error) {
+	version := i.informer.LastSyncResourceVersion()
+
+	i.statusMu.Lock()
+	defer i.statusMu.Unlock()
+	i.watchErr = err
+	i.watchErrVersion = version
+	i.resourceVersion = version
+}
+
+// AddIndexers adds indexers to the cache; it fails once Start has been called
+func (i *Informer) AddIndexers(indexers cache.Indexers) error {
+	return i.informer.AddIndexers(indexers)
+}
+
+// ByIndex returns the cached Deployments indexed under value in indexName
+func (i *Informer) ByIndex(indexName, value string) ([]*appsv1.Deployment, error) {
+	objects, err := i.informer.GetIndexer().ByIndex(indexName, value)
+	if err != nil {
+		return nil, err
+	}
+	return toDeployments(objects), nil
+}
+
+// IndexValues returns the distinct values indexed in indexName, such as every
+// image in use for ImageIndex
+func (i *Informer) IndexValues(indexName string) []string {
+	return i.informer.GetIndexer().ListIndexFuncValues(indexName)
+}
+
+// Get returns the cached Deployment with key "namespace/name"
+func (i *Informer) Get(key string) (*appsv1.Deployment, bool) {
+	obj, exists, err := i.informer.GetIndexer().GetByKey(key)
+	if err != nil || !exists {
+		return nil, false
+	}
+	deployment, ok := obj.(*appsv1.Deployment)
+	return deployment, ok
+}
+
+// List returns every cached Deployment, in no particular order
+func (i *Informer) List() []*appsv1.Deployment {
+	return toDeployments(i.informer.GetIndexer().List())
+}
+
+func toDeployments(objects []interface{}) []*appsv1.Deployment {
+	var deployments []*appsv1.Deployment
+	for _, obj := range objects {
+		if deployment, ok := obj.(*appsv1.Deployment); ok {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments
+}
@@ -0,0 +1,283 @@
+package informer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDeployment(namespace, name string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestInformerCache(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newDeployment("default", "web"), newDeployment("team-a", "api"))
+
+	tests := []struct {
+		name      string
+		namespace string
+		wantCount int
+		wantKey   string
+		wantFound bool
+	}{
+		{name: "all namespaces", wantCount: 2, wantKey: "team-a/api", wantFound: true},
+		{name: "one namespace", namespace: "default", wantCount: 1, wantKey: "team-a/api", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(clientset, Options{Namespace: tt.namespace})
+			defer i.Stop()
+			if i.HasSynced() {
+				t.Fatal("HasSynced() = true before Start")
+			}
+			if err := i.Start(context.Background()); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if !i.HasSynced() {
+				t.Error("HasSynced() = false after Start")
+			}
+			if got := len(i.List()); got != tt.wantCount {
+				t.Errorf("len(List()) = %d, want %d", got, tt.wantCount)
+			}
+			if _, found := i.Get(tt.wantKey); found != tt.wantFound {
+				t.Errorf("Get(%q) found = %v, want %v", tt.wantKey, found, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestInformerSubscribe(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(newDeployment("default", "web"))
+	i := New(clientset, Options{})
+	defer i.Stop()
+
+	events := make(chan Event)
+	i.Subscribe(events, SubscribeOptions{Block: true})
+	started := make(chan error, 1)
+	go func() { started <- i.Start(ctx) }()
+
+	if event := nextEvent(t, events); event.Type != Added || event.Deployment.Name != "web" {
+		t.Fatalf("first event = %s %s, want ADDED web", event.Type, event.Deployment.Name)
+	}
+	if err := <-started; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	updated := newDeployment("default", "web")
+	updated.Labels = map[string]string{"version": "v2"}
+	if _, err := clientset.AppsV1().Deployments("default").Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	event := nextEvent(t, events)
+	if event.Type != Modified || event.Deployment.Labels["version"] != "v2" || event.Old == nil || event.Old.Labels != nil {
+		t.Fatalf("event = %+v, want MODIFIED web with the previous version in Old", event)
+	}
+
+	if err := clientset.AppsV1().Deployments("default").Delete(ctx, "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if event := nextEvent(t, events); event.Type != Deleted || event.Deployment.Name != "web" {
+		t.Fatalf("event = %s %s, want DELETED web", event.Type, event.Deployment.Name)
+	}
+}
+
+func TestInformerStopUnblocksSubscribers(t *testing.T) {
+	i := New(fake.NewSimpleClientset(newDeployment("default", "web")), Options{})
+
+	// Nobody reads events, so Start blocks delivering the initial Added event
+	// until Stop releases it
+	i.Subscribe(make(chan Event), SubscribeOptions{Block: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = i.Start(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		i.Stop()
+		i.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() did not return")
+	}
+}
+
+func TestInformerSubscribeOverflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        SubscribeOptions
+		wantNames   []string
+		wantDropped int64
+	}{
+		{name: "drop", wantNames: []string{"a"}, wantDropped: 2},
+		{name: "timeout", opts: SubscribeOptions{Timeout: time.Millisecond}, wantNames: []string{"a"}, wantDropped: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(fake.NewSimpleClientset(), Options{})
+			defer i.Stop()
+
+			var dropped int64
+			tt.opts.OnDrop = func(_ Event, n int64) { dropped = n }
+			events := make(chan Event, 1)
+			i.Subscribe(events, tt.opts)
+			for _, name := range []string{"a", "b", "c"} {
+				i.publish(Event{Type: Added, Deployment: newDeployment("default", name)})
+			}
+
+			var got []string
+			for len(events) > 0 {
+				got = append(got, (<-events).Deployment.Name)
+			}
+			if !reflect.DeepEqual(got, tt.wantNames) || dropped != tt.wantDropped {
+				t.Errorf("received %v with %d dropped, want %v with %d dropped", got, dropped, tt.wantNames, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestInformerUnsubscribeReleasesBlockedSend(t *testing.T) {
+	i := New(fake.NewSimpleClientset(), Options{})
+	defer i.Stop()
+
+	blocked := make(chan Event)
+	other := make(chan Event, 1)
+	i.Subscribe(blocked, SubscribeOptions{Block: true})
+	i.Subscribe(other, SubscribeOptions{})
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		i.publish(Event{Type: Added, Deployment: newDeployment("default", "web")})
+	}()
+
+	// Subscribing while a send is blocked must not wait for it
+	subscribed := make(chan struct{})
+	go func() {
+		i.Subscribe(make(chan Event, 1), SubscribeOptions{})
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe() blocked behind a slow subscriber")
+	}
+
+	i.Unsubscribe(blocked)
+	close(blocked) // safe once Unsubscribe has returned
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Unsubscribe() did not release the blocked send")
+	}
+	if len(other) != 1 {
+		t.Error("the subscriber after the unsubscribed one missed the event")
+	}
+}
+
+func TestInformerIndexes(t *testing.T) {
+	web := newDeployment("default", "web")
+	web.Labels = map[string]string{"app": "web", "tier": "frontend"}
+	web.Spec.Template.Spec.Containers = []corev1.Container{{Name: "web", Image: "nginx:1.25"}, {Name: "proxy", Image: "envoy:v1.28"}}
+	api := newDeployment("team-a", "api")
+	api.Labels = map[string]string{"app": "api"}
+	api.Spec.Template.Spec.Containers = []corev1.Container{{Name: "api", Image: "nginx:1.25"}}
+
+	i := New(fake.NewSimpleClientset(web, api), Options{})
+	defer i.Stop()
+	if err := i.AddIndexers(Indexers()); err != nil {
+		t.Fatalf("AddIndexers() error = %v", err)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	tests := []struct {
+		index string
+		value string
+		want  []string
+	}{
+		{index: ImageIndex, value: "nginx:1.25", want: []string{"api", "web"}},
+		{index: ImageIndex, value: "envoy:v1.28", want: []string{"web"}},
+		{index: LabelIndex, value: "app=api", want: []string{"api"}},
+		{index: LabelIndex, value: "tier=backend", want: nil},
+		{index: NamespaceIndex, value: "team-a", want: []string{"api"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.index+"/"+tt.value, func(t *testing.T) {
+			deployments, err := i.ByIndex(tt.index, tt.value)
+			if err != nil {
+				t.Fatalf("ByIndex() error = %v", err)
+			}
+			var got []string
+			for _, deployment := range deployments {
+				got = append(got, deployment.Name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ByIndex(%s, %s) = %v, want %v", tt.index, tt.value, got, tt.want)
+			}
+		})
+	}
+
+	images := i.IndexValues(ImageIndex)
+	sort.Strings(images)
+	if want := []string{"envoy:v1.28", "nginx:1.25"}; !reflect.DeepEqual(images, want) {
+		t.Errorf("IndexValues(ImageIndex) = %v, want %v", images, want)
+	}
+	if err := i.AddIndexers(Indexers()); err == nil {
+		t.Error("AddIndexers() after Start succeeded, want an error")
+	}
+}
+
+func TestInformerStatus(t *testing.T) {
+	i := New(fake.NewSimpleClientset(), Options{})
+	defer i.Stop()
+
+	if status := i.Status(); status.Connected || !status.LastEventTime.IsZero() {
+		t.Errorf("Status() before Start = %+v, want disconnected and no events", status)
+	}
+	if err := i.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	status := i.Status()
+	if !status.Connected || status.LastEventTime.IsZero() {
+		t.Fatalf("Status() after Start = %+v, want connected with the sync time", status)
+	}
+
+	i.recordWatchError(errors.New("connection refused"))
+	if status := i.Status(); status.Connected || status.LastError != "connection refused" {
+		t.Errorf("Status() after a watch error = %+v, want disconnected with the error", status)
+	}
+
+	// A newer resource version means the reflector listed or watched again
+	i.statusMu.Lock()
+	i.watchErrVersion = "stale"
+	i.statusMu.Unlock()
+	if status := i.Status(); !status.Connected || status.LastError != "" {
+		t.Errorf("Status() after progress = %+v, want connected again", status)
+	}
+}
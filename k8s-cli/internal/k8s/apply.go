@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/util/retry"
+)
+
+// FieldManager identifies k8s-cli in managedFields for server-side apply
+const FieldManager = "k8s-cli"
+
+// ApplyOptions configures ServerSideApplyFromYAML
+type ApplyOptions struct {
+	// ForceConflicts takes ownership of fields managed by someone else instead of failing
+	ForceConflicts bool
+	// FieldManager defaults to FieldManager
+	FieldManager string
+}
+
+// ServerSideApplyFromYAML applies the first object in yamlData with server-side
+// apply. Unlike create, the API server merges the object, so there's no
+// last-applied-configuration annotation that large CRDs would overflow.
+// Transient server errors are retried; field conflicts are returned as an
+// error listing the conflicting fields and their managers.
+func (c *Client) ServerSideApplyFromYAML(yamlData []byte, namespace string, opts ApplyOptions) (*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(string(yamlData)), 4096)
+
+	var obj unstructured.Unstructured
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, wrapError(ErrDecodeYAML, "error decoding YAML", err)
+	}
+
+	gvk := obj.GroupVersionKind()
+	if obj.GetNamespace() == "" && namespace != "" && !isClusterScoped(gvk.Kind) {
+		obj.SetNamespace(namespace)
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding object: %w", err)
+	}
+
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = FieldManager
+	}
+	patchOptions := metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &opts.ForceConflicts,
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    gvk.Group,
+		Version:  gvk.Version,
+		Resource: getResourceName(gvk.Kind),
+	}
+
+	var applied *unstructured.Unstructured
+	err = retry.OnError(retry.DefaultBackoff, isRetriableApplyError, func() error {
+		var err error
+		if isClusterScoped(gvk.Kind) {
+			applied, err = c.dynamicClient.Resource(gvr).Patch(
+				context.TODO(), obj.GetName(), types.ApplyPatchType, data, patchOptions)
+		} else {
+			applied, err = c.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Patch(
+				context.TODO(), obj.GetName(), types.ApplyPatchType, data, patchOptions)
+		}
+		return err
+	})
+
+	if apierrors.IsConflict(err) {
+		return nil, wrapError(ErrRequest, fmt.Sprintf("apply conflicts with other field managers (use --force-conflicts to take ownership): %s", applyConflicts(err)), nil)
+	}
+	if err != nil {
+		return nil, requestError("error applying resource", err)
+	}
+
+	return applied, nil
+}
+
+func isRetriableApplyError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err)
+}
+
+// applyConflicts lists the conflicting fields from a 409 apply response, e.g.
+// `.spec.replicas (conflict with "kubectl-client-side-apply" using apps/v1)`
+func applyConflicts(err error) string {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil || len(statusErr.ErrStatus.Details.Causes) == 0 {
+		return err.Error()
+	}
+
+	conflicts := make([]string, 0, len(statusErr.ErrStatus.Details.Causes))
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
+	}
+	if len(conflicts) == 0 {
+		return err.Error()
+	}
+	return strings.Join(conflicts, "; ")
+}
@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const applyTestDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+`
+
+func TestServerSideApplyFromYAML(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	var gotAction k8stesting.PatchAction
+	dynamicClient.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gotAction = action.(k8stesting.PatchAction)
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(gotAction.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	})
+
+	c := &Client{dynamicClient: dynamicClient}
+	applied, err := c.ServerSideApplyFromYAML([]byte(applyTestDeployment), "team-a", ApplyOptions{})
+	if err != nil {
+		t.Fatalf("ServerSideApplyFromYAML() error = %v", err)
+	}
+
+	if gotAction.GetPatchType() != types.ApplyPatchType {
+		t.Errorf("patch type = %s, want %s", gotAction.GetPatchType(), types.ApplyPatchType)
+	}
+	if gotAction.GetNamespace() != "team-a" || gotAction.GetName() != "web" {
+		t.Errorf("patched %s/%s, want team-a/web", gotAction.GetNamespace(), gotAction.GetName())
+	}
+	if applied.GetNamespace() != "team-a" {
+		t.Errorf("applied namespace = %q, want team-a", applied.GetNamespace())
+	}
+}
+
+func TestServerSideApplyReportsConflicts(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		conflict := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", nil)
+		conflict.ErrStatus.Details.Causes = []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Field:   ".spec.replicas",
+			Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+		}}
+		return true, nil, conflict
+	})
+
+	c := &Client{dynamicClient: dynamicClient}
+	_, err := c.ServerSideApplyFromYAML([]byte(applyTestDeployment), "default", ApplyOptions{})
+	if err == nil {
+		t.Fatal("ServerSideApplyFromYAML() error = nil, want conflict")
+	}
+	for _, want := range []string{"--force-conflicts", ".spec.replicas", "kubectl-client-side-apply"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// AccessCheck is the question asked by CanI
+type AccessCheck struct {
+	Verb string
+	// Resource is TYPE[.GROUP][/NAME], e.g. "deployments.apps/web", or a
+	// non-resource URL such as "/metrics"
+	Resource    string
+	Subresource string
+	Namespace   string
+}
+
+// AccessResult is the API server's answer to an AccessCheck
+type AccessResult struct {
+	Allowed bool
+	Reason  string
+}
+
+// CanI asks the API server whether the current user, or the one impersonated
+// with NewClientAs, may perform check, like kubectl auth can-i
+func (c *Client) CanI(ctx context.Context, check AccessCheck) (*AccessResult, error) {
+	return reviewAccess(ctx, c.clientset.AuthorizationV1().SelfSubjectAccessReviews(), c.accessReviewSpec(check))
+}
+
+// accessReviewSpec turns check into review attributes. Resource types without
+// a group are resolved through discovery, so "deployments" is checked in the
+// apps group rather than the core group.
+func (c *Client) accessReviewSpec(check AccessCheck) authorizationv1.SelfSubjectAccessReviewSpec {
+	if strings.HasPrefix(check.Resource, "/") {
+		return authorizationv1.SelfSubjectAccessReviewSpec{
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: check.Resource,
+				Verb: check.Verb,
+			},
+		}
+	}
+
+	typ, name, _ := strings.Cut(check.Resource, "/")
+	resource := schema.ParseGroupResource(strings.ToLower(typ))
+	if resource.Group == "" && c.discoveryClient != nil {
+		if types, err := c.resourceTypes(); err == nil {
+			if rt, ok := types[resource.Resource]; ok {
+				resource = rt.resource
+			}
+		}
+	}
+
+	return authorizationv1.SelfSubjectAccessReviewSpec{
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace:   check.Namespace,
+			Verb:        check.Verb,
+			Group:       resource.Group,
+			Resource:    resource.Resource,
+			Subresource: check.Subresource,
+			Name:        name,
+		},
+	}
+}
+
+func reviewAccess(ctx context.Context, reviews authorizationv1client.SelfSubjectAccessReviewInterface, spec authorizationv1.SelfSubjectAccessReviewSpec) (*AccessResult, error) {
+	review, err := reviews.Create(ctx, &authorizationv1.SelfSubjectAccessReview{Spec: spec}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, requestError("error creating SelfSubjectAccessReview", err)
+	}
+
+	reason := review.Status.Reason
+	if review.Status.EvaluationError != "" {
+		reason = strings.TrimSpace(reason + " " + review.Status.EvaluationError)
+	}
+	return &AccessResult{Allowed: review.Status.Allowed, Reason: reason}, nil
+}
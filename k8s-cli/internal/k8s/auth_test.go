@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAccessReviewSpec(t *testing.T) {
+	client := newDiscoveryTestClient()
+
+	tests := []struct {
+		name  string
+		check AccessCheck
+		want  authorizationv1.SelfSubjectAccessReviewSpec
+	}{
+		{
+			name:  "group resolved through discovery",
+			check: AccessCheck{Verb: "create", Resource: "deployments", Namespace: "team-a"},
+			want: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "team-a", Verb: "create", Group: "apps", Resource: "deployments",
+			}},
+		},
+		{
+			name:  "short name with object name",
+			check: AccessCheck{Verb: "delete", Resource: "po/web-0", Namespace: "team-a"},
+			want: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "team-a", Verb: "delete", Resource: "pods", Name: "web-0",
+			}},
+		},
+		{
+			name:  "explicit group and subresource",
+			check: AccessCheck{Verb: "update", Resource: "frontendpages.k8scli.dev", Subresource: "status", Namespace: "default"},
+			want: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "default", Verb: "update", Group: "k8scli.dev", Resource: "frontendpages", Subresource: "status",
+			}},
+		},
+		{
+			name:  "non-resource URL",
+			check: AccessCheck{Verb: "get", Resource: "/metrics", Namespace: "default"},
+			want: authorizationv1.SelfSubjectAccessReviewSpec{NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: "/metrics", Verb: "get",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.accessReviewSpec(tt.check); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("accessReviewSpec() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewAccess(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status = authorizationv1.SubjectAccessReviewStatus{
+			Allowed: review.Spec.ResourceAttributes.Verb == "get",
+			Reason:  "RBAC: checked",
+		}
+		return true, review, nil
+	})
+
+	reviews := clientset.AuthorizationV1().SelfSubjectAccessReviews()
+	for verb, want := range map[string]bool{"get": true, "delete": false} {
+		result, err := reviewAccess(context.TODO(), reviews, authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: verb, Resource: "pods"},
+		})
+		if err != nil {
+			t.Fatalf("reviewAccess(%s) error = %v", verb, err)
+		}
+		if result.Allowed != want || result.Reason != "RBAC: checked" {
+			t.Errorf("reviewAccess(%s) = %+v, want allowed %v", verb, result, want)
+		}
+	}
+}
@@ -3,55 +3,108 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Client wrapper for Kubernetes client
 type Client struct {
-	clientset     *kubernetes.Clientset
-	dynamicClient dynamic.Interface
-	config        clientcmd.ClientConfig
+	clientset       *kubernetes.Clientset
+	dynamicClient   dynamic.Interface
+	discoveryClient discovery.DiscoveryInterface
+	config          clientcmd.ClientConfig
+}
+
+// NewLoadingRules follows kubectl precedence: an explicit kubeconfig path wins,
+// otherwise the files in the colon-separated KUBECONFIG are merged, falling back to ~/.kube/config
+func NewLoadingRules(kubeconfigPath string) *clientcmd.ClientConfigLoadingRules {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
+	return loadingRules
+}
+
+// ContextNamespace returns the namespace of the current context in the
+// kubeconfig, or "default" when it sets none or can't be read: the namespace
+// kubectl would use. Only the kubeconfig file is read, nothing is contacted.
+func ContextNamespace(kubeconfigPath string) string {
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(NewLoadingRules(kubeconfigPath), &clientcmd.ConfigOverrides{})
+	namespace, _, err := config.Namespace()
+	if err != nil || namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
+
+// Impersonation sends requests as another user, like kubectl --as and --as-group
+type Impersonation struct {
+	UserName string
+	Groups   []string
+}
+
+// Apply sets the impersonation headers on config. Groups need a user name,
+// the API server rejects impersonating groups alone.
+func (i Impersonation) Apply(config *rest.Config) error {
+	if i.UserName == "" {
+		if len(i.Groups) > 0 {
+			return wrapError(ErrClientConfig, "impersonating groups requires a user name", nil)
+		}
+		return nil
+	}
+	config.Impersonate.UserName = i.UserName
+	config.Impersonate.Groups = i.Groups
+	return nil
 }
 
 // NewClient creates a new Kubernetes client
 func NewClient(kubeconfigPath string) (*Client, error) {
-	// If kubeconfigPath is empty, use default kubeconfig location
-	if kubeconfigPath == "" {
-		kubeconfigPath = clientcmd.RecommendedHomeFile
-	}
+	return NewClientAs(kubeconfigPath, Impersonation{})
+}
 
+// NewClientAs creates a Kubernetes client whose requests impersonate as
+func NewClientAs(kubeconfigPath string, as Impersonation) (*Client, error) {
 	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		NewLoadingRules(kubeconfigPath),
 		&clientcmd.ConfigOverrides{},
 	)
 
 	restConfig, err := config.ClientConfig()
 	if err != nil {
-		return nil, fmt.Errorf("error creating configuration: %w", err)
+		return nil, wrapError(ErrClientConfig, "error creating configuration", err)
+	}
+
+	if err := as.Apply(restConfig); err != nil {
+		return nil, err
 	}
 
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating clientset: %w", err)
+		return nil, wrapError(ErrClientConfig, "error creating clientset", err)
 	}
 
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+		return nil, wrapError(ErrClientConfig, "error creating dynamic client", err)
 	}
 
 	return &Client{
-		clientset:     clientset,
-		dynamicClient: dynamicClient,
-		config:        config,
+		clientset:       clientset,
+		dynamicClient:   dynamicClient,
+		discoveryClient: memory.NewMemCacheClient(clientset.Discovery()),
+		config:          config,
 	}, nil
 }
 
@@ -88,35 +141,95 @@ func (c *Client) GetContexts() ([]string, error) {
 	return contexts, nil
 }
 
-// SetContext switches the context
-func (c *Client) SetContext(contextName, kubeconfigPath string) error {
-	if kubeconfigPath == "" {
-		kubeconfigPath = clientcmd.RecommendedHomeFile
-	}
-
-	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+// SetContext switches the context. With merged KUBECONFIG files the
+// current-context is written to the file kubectl would update.
+func (c *Client) SetContext(contextName string) error {
+	config, err := c.config.RawConfig()
 	if err != nil {
-		return fmt.Errorf("error loading kubeconfig: %w", err)
+		return wrapError(ErrClientConfig, "error loading kubeconfig", err)
 	}
 
 	if _, exists := config.Contexts[contextName]; !exists {
-		return fmt.Errorf("context '%s' not found", contextName)
+		return wrapError(ErrClientConfig, fmt.Sprintf("context '%s' not found", contextName), nil)
 	}
 
 	config.CurrentContext = contextName
 
-	return clientcmd.WriteToFile(*config, kubeconfigPath)
+	return clientcmd.ModifyConfig(c.config.ConfigAccess(), config, true)
 }
 
 // TestConnection tests the connection to the cluster
 func (c *Client) TestConnection() error {
 	_, err := c.clientset.Discovery().ServerVersion()
 	if err != nil {
-		return fmt.Errorf("unable to connect to cluster: %w", err)
+		return wrapError(ErrConnection, "unable to connect to cluster", err)
 	}
 	return nil
 }
 
+// ConnectWithRetry is TestConnection with retries, for use while the cluster is still starting
+func (c *Client) ConnectWithRetry(ctx context.Context, attempts int, backoff time.Duration) error {
+	_, err := WaitForServer(ctx, c.clientset.Discovery(), attempts, backoff)
+	return err
+}
+
+// WaitForServer queries the server version up to attempts times, doubling the
+// wait after each failure. The last error is returned if every attempt fails.
+func WaitForServer(ctx context.Context, d discovery.ServerVersionInterface, attempts int, backoff time.Duration) (*version.Info, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		info, err := d.ServerVersion()
+		if err == nil {
+			return info, nil
+		}
+		lastErr = err
+
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, wrapError(ErrConnection, "unable to connect to cluster", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return nil, wrapError(ErrConnection, fmt.Sprintf("unable to connect to cluster after %d attempt(s)", attempts), lastErr)
+}
+
+// Namespaces returns the names of all namespaces in the cluster, sorted
+func (c *Client) Namespaces() ([]string, error) {
+	list, err := c.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, requestError("error listing namespaces", err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// NamespaceExists reports whether the namespace exists
+func (c *Client) NamespaceExists(name string) (bool, error) {
+	_, err := c.clientset.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, requestError(fmt.Sprintf("error checking namespace '%s'", name), err)
+	}
+	return true, nil
+}
+
 // CreateFromYAML creates a resource from YAML
 func (c *Client) CreateFromYAML(yamlData []byte, namespace string) error {
 	// Decode YAML into unstructured object
@@ -124,7 +237,7 @@ func (c *Client) CreateFromYAML(yamlData []byte, namespace string) error {
 
 	var obj unstructured.Unstructured
 	if err := decoder.Decode(&obj); err != nil {
-		return fmt.Errorf("error decoding YAML: %w", err)
+		return wrapError(ErrDecodeYAML, "error decoding YAML", err)
 	}
 
 	// Get GVK from object
@@ -158,7 +271,7 @@ func (c *Client) CreateFromYAML(yamlData []byte, namespace string) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("error creating resource: %w", err)
+		return wrapError(ErrResourceCreate, "error creating resource", err)
 	}
 
 	return nil
@@ -171,7 +284,7 @@ func (c *Client) DeleteFromYAML(yamlData []byte, namespace string) error {
 
 	var obj unstructured.Unstructured
 	if err := decoder.Decode(&obj); err != nil {
-		return fmt.Errorf("error decoding YAML: %w", err)
+		return wrapError(ErrDecodeYAML, "error decoding YAML", err)
 	}
 
 	// Get GVK from object
@@ -205,7 +318,7 @@ func (c *Client) DeleteFromYAML(yamlData []byte, namespace string) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("error deleting resource: %w", err)
+		return requestError("error deleting resource", err)
 	}
 
 	return nil
@@ -218,7 +331,7 @@ func (c *Client) ListDeployments(namespace string) error {
 		metav1.ListOptions{},
 	)
 	if err != nil {
-		return fmt.Errorf("error listing deployments: %w", err)
+		return requestError("error listing deployments", err)
 	}
 
 	fmt.Printf("Deployments in namespace '%s':\n", namespace)
@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type flakyDiscovery struct {
+	failures int
+	calls    int
+}
+
+func (d *flakyDiscovery) ServerVersion() (*version.Info, error) {
+	d.calls++
+	if d.calls <= d.failures {
+		return nil, errors.New("connection refused")
+	}
+	return &version.Info{GitVersion: "v1.29.0"}, nil
+}
+
+func TestWaitForServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		attempts  int
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "first attempt succeeds", failures: 0, attempts: 3, wantCalls: 1},
+		{name: "succeeds after transient failures", failures: 2, attempts: 3, wantCalls: 3},
+		{name: "all attempts fail", failures: 5, attempts: 3, wantErr: true, wantCalls: 3},
+		{name: "zero attempts still tries once", failures: 0, attempts: 0, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &flakyDiscovery{failures: tt.failures}
+			info, err := WaitForServer(context.Background(), d, tt.attempts, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WaitForServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && info.GitVersion != "v1.29.0" {
+				t.Errorf("WaitForServer() version = %q, want v1.29.0", info.GitVersion)
+			}
+			if d.calls != tt.wantCalls {
+				t.Errorf("ServerVersion called %d times, want %d", d.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForServerStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d := &flakyDiscovery{failures: 5}
+	_, err := WaitForServer(ctx, d, 5, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitForServer() error = %v, want context.Canceled", err)
+	}
+	if d.calls != 1 {
+		t.Errorf("ServerVersion called %d times, want 1", d.calls)
+	}
+}
+
+func TestImpersonationApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		as         Impersonation
+		wantUser   string
+		wantGroups []string
+		wantErr    bool
+	}{
+		{name: "none"},
+		{
+			name:     "service account",
+			as:       Impersonation{UserName: "system:serviceaccount:team-a:deployer"},
+			wantUser: "system:serviceaccount:team-a:deployer",
+		},
+		{
+			name:       "user with groups",
+			as:         Impersonation{UserName: "jane", Groups: []string{"developers", "qa"}},
+			wantUser:   "jane",
+			wantGroups: []string{"developers", "qa"},
+		},
+		{name: "groups without user", as: Impersonation{Groups: []string{"developers"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &rest.Config{}
+			err := tt.as.Apply(config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if config.Impersonate.UserName != tt.wantUser {
+				t.Errorf("UserName = %q, want %q", config.Impersonate.UserName, tt.wantUser)
+			}
+			if !reflect.DeepEqual(config.Impersonate.Groups, tt.wantGroups) {
+				t.Errorf("Groups = %v, want %v", config.Impersonate.Groups, tt.wantGroups)
+			}
+		})
+	}
+}
+
+func TestContextNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		want      string
+	}{
+		{name: "context namespace", namespace: "team-a", want: "team-a"},
+		{name: "context without namespace", want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := clientcmdapi.NewConfig()
+			config.Clusters["test"] = &clientcmdapi.Cluster{Server: "https://test.example.com"}
+			config.Contexts["test"] = &clientcmdapi.Context{Cluster: "test", Namespace: tt.namespace}
+			config.CurrentContext = "test"
+			path := filepath.Join(t.TempDir(), "config")
+			if err := clientcmd.WriteToFile(*config, path); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := ContextNamespace(path); got != tt.want {
+				t.Errorf("ContextNamespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := ContextNamespace(filepath.Join(t.TempDir(), "missing")); got != "default" {
+		t.Errorf("ContextNamespace() for a missing kubeconfig = %q, want default", got)
+	}
+}
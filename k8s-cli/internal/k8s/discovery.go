@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceType is a served resource as found through discovery
+type resourceType struct {
+	singular string
+	resource schema.GroupResource
+}
+
+// resourceTypes maps the lowercase kinds, resource names, singular names and
+// short names the API server serves to their resource. Where two groups serve
+// the same name (events, for example) the first one discovered wins. Partial
+// discovery results, e.g. when an aggregated API is down, are still used.
+func (c *Client) resourceTypes() (map[string]resourceType, error) {
+	_, resourceLists, err := c.discoveryClient.ServerGroupsAndResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, requestError("error discovering resource types", err)
+	}
+
+	types := make(map[string]resourceType)
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue // subresources such as pods/log
+			}
+			singular := resource.SingularName
+			if singular == "" {
+				singular = strings.ToLower(resource.Kind)
+			}
+			rt := resourceType{
+				singular: singular,
+				resource: schema.GroupResource{Group: gv.Group, Resource: resource.Name},
+			}
+			names := append([]string{strings.ToLower(resource.Kind), resource.Name, singular}, resource.ShortNames...)
+			for _, name := range names {
+				if _, ok := types[name]; !ok {
+					types[name] = rt
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+// IsResourceRegistered reports whether the API server serves kind, matched
+// case-insensitively against kinds, plural and singular names and short names.
+// If discovery itself fails it returns true and leaves the error to the
+// request that follows.
+func (c *Client) IsResourceRegistered(kind string) bool {
+	types, err := c.resourceTypes()
+	if err != nil {
+		return true
+	}
+	_, ok := types[strings.ToLower(kind)]
+	return ok
+}
+
+// ValidateResourceType returns a friendly error for a kind the server doesn't
+// serve, suggesting the closest known type to catch typos before the
+// confusing "the server could not find the requested resource" error.
+func (c *Client) ValidateResourceType(kind string) error {
+	if kind == "" {
+		return fmt.Errorf("resource type is missing")
+	}
+
+	types, err := c.resourceTypes()
+	if err != nil {
+		return nil
+	}
+	if _, ok := types[strings.ToLower(kind)]; ok {
+		return nil
+	}
+	if suggestion := suggestResourceType(strings.ToLower(kind), types); suggestion != "" {
+		return fmt.Errorf("unknown resource type '%s', did you mean '%s'?", kind, suggestion)
+	}
+	return fmt.Errorf("unknown resource type '%s'", kind)
+}
+
+// suggestResourceType picks the closest known type within a few edits, so
+// unrelated names don't produce a misleading suggestion
+func suggestResourceType(kind string, types map[string]resourceType) string {
+	maxDistance := len(kind) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for name, rt := range types {
+		distance := levenshtein(kind, name)
+		if distance < bestDistance || (distance == bestDistance && rt.singular < best) {
+			best, bestDistance = rt.singular, distance
+		}
+	}
+	return best
+}
+
+// levenshtein is the number of single-character edits between a and b
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
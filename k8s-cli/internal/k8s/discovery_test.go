@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newDiscoveryTestClient() *Client {
+	discoveryClient := &discoveryfake.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", SingularName: "pod", Kind: "Pod", ShortNames: []string{"po"}},
+				{Name: "pods/log", Kind: "Pod"},
+				{Name: "services", SingularName: "service", Kind: "Service", ShortNames: []string{"svc"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", SingularName: "deployment", Kind: "Deployment", ShortNames: []string{"deploy"}},
+			},
+		},
+	}
+	return &Client{discoveryClient: discoveryClient}
+}
+
+func TestIsResourceRegistered(t *testing.T) {
+	client := newDiscoveryTestClient()
+
+	tests := []struct {
+		kind string
+		want bool
+	}{
+		{kind: "Deployment", want: true},
+		{kind: "deployments", want: true},
+		{kind: "svc", want: true},
+		{kind: "Pod", want: true},
+		{kind: "FrontendPage", want: false},
+		{kind: "pods/log", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			if got := client.IsResourceRegistered(tt.kind); got != tt.want {
+				t.Errorf("IsResourceRegistered(%q) = %v, want %v", tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResourceType(t *testing.T) {
+	client := newDiscoveryTestClient()
+
+	tests := []struct {
+		kind    string
+		wantErr string
+	}{
+		{kind: "Deployment"},
+		{kind: "deploymnet", wantErr: "unknown resource type 'deploymnet', did you mean 'deployment'?"},
+		{kind: "Servcie", wantErr: "unknown resource type 'Servcie', did you mean 'service'?"},
+		{kind: "FrontendPage", wantErr: "unknown resource type 'FrontendPage'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			err := client.ValidateResourceType(tt.kind)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateResourceType(%q) error = %v", tt.kind, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateResourceType(%q) error = %v, want %q", tt.kind, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "pod", want: 3},
+		{a: "pod", b: "pod", want: 0},
+		{a: "deploymnet", b: "deployment", want: 2},
+		{a: "service", b: "services", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"errors"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Failure classes of the Client. An error returned for one of these reasons
+// matches its class with errors.Is, so callers can branch without parsing the
+// message. Failed API calls are classified by requestError, so the status
+// error from the server stays reachable for apierrors.IsForbidden and friends.
+//
+// Not classified: arguments rejected before any request is made (a missing
+// prune selector, an unknown resource type), timeouts of WaitForReady and
+// DrainNode, a drain refused because of DaemonSet pods, and a rollback with no
+// previous revision.
+var (
+	ErrClientConfig   = errors.New("invalid client configuration")
+	ErrConnection     = errors.New("unable to connect to cluster")
+	ErrDecodeYAML     = errors.New("invalid YAML")
+	ErrResourceCreate = errors.New("resource not created")
+	ErrNotFound       = errors.New("not found")
+	ErrRequest        = errors.New("API request failed")
+)
+
+// Error is a failure of class Class. Op describes what failed and Err, if
+// any, the cause; errors.Is matches both the class and the cause.
+type Error struct {
+	Class error
+	Op    string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Op
+	}
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Class}
+	}
+	return []error{e.Class, e.Err}
+}
+
+func wrapError(class error, op string, err error) error {
+	return &Error{Class: class, Op: op, Err: err}
+}
+
+// requestError classifies a failed API call: ErrConnection when the request
+// never got an answer, ErrNotFound for a missing object and ErrRequest for
+// anything else the server refused
+func requestError(op string, err error) error {
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &urlErr):
+		return wrapError(ErrConnection, op, err)
+	case apierrors.IsNotFound(err):
+		return wrapError(ErrNotFound, op, err)
+	}
+	return wrapError(ErrRequest, op, err)
+}
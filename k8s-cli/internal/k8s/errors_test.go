@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+type failingServer struct{ err error }
+
+func (s failingServer) ServerVersion() (*version.Info, error) { return nil, s.err }
+
+func TestErrorClasses(t *testing.T) {
+	refused := errors.New("connection refused")
+
+	_, configErr := NewClient(filepath.Join(t.TempDir(), "missing-kubeconfig"))
+	_, connErr := WaitForServer(context.Background(), failingServer{err: refused}, 2, time.Millisecond)
+	decodeErr := (&Client{}).CreateFromYAML([]byte("kind: [Pod"), "default")
+	transportErr := &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/api/v1/namespaces", Err: refused}
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "n1")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", errors.New("rbac"))
+
+	tests := []struct {
+		name      string
+		err       error
+		class     error
+		cause     error
+		wantError string
+	}{
+		{name: "config", err: configErr, class: ErrClientConfig},
+		{name: "impersonation", err: Impersonation{Groups: []string{"admins"}}.Apply(nil), class: ErrClientConfig, wantError: "impersonating groups requires a user name"},
+		{name: "connection", err: connErr, class: ErrConnection, cause: refused, wantError: "unable to connect to cluster after 2 attempt(s): connection refused"},
+		{name: "decode", err: decodeErr, class: ErrDecodeYAML},
+		{name: "request transport", err: requestError("error listing namespaces", transportErr), class: ErrConnection, cause: refused},
+		{name: "request not found", err: requestError("error getting node 'n1'", notFound), class: ErrNotFound, cause: notFound},
+		{name: "request forbidden", err: requestError("error listing namespaces", forbidden), class: ErrRequest, cause: forbidden, wantError: "error listing namespaces: " + forbidden.Error()},
+	}
+
+	classes := []error{ErrClientConfig, ErrConnection, ErrDecodeYAML, ErrResourceCreate, ErrNotFound, ErrRequest}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, class := range classes {
+				if got := errors.Is(tt.err, class); got != (class == tt.class) {
+					t.Errorf("errors.Is(%v, %v) = %v", tt.err, class, got)
+				}
+			}
+			if tt.cause != nil && !errors.Is(tt.err, tt.cause) {
+				t.Errorf("errors.Is(%v, cause) = false", tt.err)
+			}
+
+			var k8sErr *Error
+			if !errors.As(tt.err, &k8sErr) || k8sErr.Class != tt.class {
+				t.Errorf("errors.As(%v) did not give an *Error of class %v", tt.err, tt.class)
+			}
+			if tt.wantError != "" && tt.err.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", tt.err.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// evictionRetryInterval is how long to wait before retrying an eviction
+// that a PodDisruptionBudget refused, matching kubectl drain
+const evictionRetryInterval = 5 * time.Second
+
+// DrainOptions controls how DrainNode evicts pods
+type DrainOptions struct {
+	// GracePeriodSeconds overrides the pod's termination grace period; negative keeps the pod's own
+	GracePeriodSeconds int
+	// IgnoreDaemonSets skips DaemonSet-managed pods instead of refusing to drain
+	IgnoreDaemonSets bool
+	// Timeout bounds the whole drain; zero waits indefinitely
+	Timeout time.Duration
+}
+
+// DrainResult lists the pods handled by DrainNode as namespace/name
+type DrainResult struct {
+	Evicted []string
+	Skipped []string
+}
+
+// CordonNode marks the node unschedulable
+func (c *Client) CordonNode(name string) error {
+	return c.setUnschedulable(name, true)
+}
+
+// UncordonNode marks the node schedulable again
+func (c *Client) UncordonNode(name string) error {
+	return c.setUnschedulable(name, false)
+}
+
+func (c *Client) setUnschedulable(name string, unschedulable bool) error {
+	node, err := c.clientset.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return requestError(fmt.Sprintf("error getting node '%s'", name), err)
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err = c.clientset.CoreV1().Nodes().Patch(context.TODO(), name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return requestError(fmt.Sprintf("error updating node '%s'", name), err)
+	}
+	return nil
+}
+
+// DrainNode cordons the node and evicts its pods through the eviction API,
+// so PodDisruptionBudgets are respected. Evictions refused by a budget are
+// retried until they succeed or the timeout expires.
+func (c *Client) DrainNode(ctx context.Context, name string, opts DrainOptions) (*DrainResult, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if err := c.CordonNode(name); err != nil {
+		return nil, err
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, requestError(fmt.Sprintf("error listing pods on node '%s'", name), err)
+	}
+
+	toEvict, skipped, err := podsToEvict(pods.Items, opts.IgnoreDaemonSets)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DrainResult{}
+	for _, pod := range skipped {
+		result.Skipped = append(result.Skipped, pod.Namespace+"/"+pod.Name)
+	}
+
+	for _, pod := range toEvict {
+		if err := c.evictPod(ctx, pod, opts.GracePeriodSeconds); err != nil {
+			return result, err
+		}
+		result.Evicted = append(result.Evicted, pod.Namespace+"/"+pod.Name)
+	}
+
+	for _, pod := range toEvict {
+		if err := c.waitForPodDeletion(ctx, pod); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) evictPod(ctx context.Context, pod corev1.Pod, gracePeriodSeconds int) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+	}
+	if gracePeriodSeconds >= 0 {
+		grace := int64(gracePeriodSeconds)
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &grace}
+	}
+
+	for {
+		err := c.clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+			return nil
+		case apierrors.IsTooManyRequests(err):
+			// Blocked by a PodDisruptionBudget, try again later
+		default:
+			return requestError(fmt.Sprintf("error evicting pod %s/%s", pod.Namespace, pod.Name), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out evicting pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+}
+
+func (c *Client) waitForPodDeletion(ctx context.Context, pod corev1.Pod) error {
+	for {
+		current, err := c.clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			return nil
+		}
+		if err != nil {
+			return requestError(fmt.Sprintf("error waiting for pod %s/%s", pod.Namespace, pod.Name), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod %s/%s to terminate", pod.Namespace, pod.Name)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// podsToEvict splits the node's pods like kubectl drain: mirror pods are
+// always skipped, DaemonSet pods are skipped only with ignoreDaemonSets.
+func podsToEvict(pods []corev1.Pod, ignoreDaemonSets bool) (evict, skipped []corev1.Pod, err error) {
+	var daemonSetPods []string
+	for _, pod := range pods {
+		if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+			skipped = append(skipped, pod)
+			continue
+		}
+
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			if !ignoreDaemonSets {
+				daemonSetPods = append(daemonSetPods, pod.Namespace+"/"+pod.Name)
+			}
+			skipped = append(skipped, pod)
+			continue
+		}
+
+		evict = append(evict, pod)
+	}
+
+	if len(daemonSetPods) > 0 {
+		return nil, nil, fmt.Errorf("cannot drain node: DaemonSet-managed pods found (use --ignore-daemonsets to skip them): %v", daemonSetPods)
+	}
+	return evict, skipped, nil
+}
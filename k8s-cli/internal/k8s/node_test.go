@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPod(name string, mutate func(*corev1.Pod)) corev1.Pod {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	if mutate != nil {
+		mutate(&pod)
+	}
+	return pod
+}
+
+func ownedBy(kind string) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: "owner", Controller: &controller}}
+	}
+}
+
+func mirror(pod *corev1.Pod) {
+	pod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestPodsToEvict(t *testing.T) {
+	pods := []corev1.Pod{
+		testPod("web", ownedBy("ReplicaSet")),
+		testPod("standalone", nil),
+		testPod("kube-apiserver", mirror),
+		testPod("fluentd", ownedBy("DaemonSet")),
+	}
+
+	evict, skipped, err := podsToEvict(pods, true)
+	if err != nil {
+		t.Fatalf("podsToEvict() error = %v", err)
+	}
+	if got := podNames(evict); len(got) != 2 || got[0] != "web" || got[1] != "standalone" {
+		t.Errorf("evict = %v, want [web standalone]", got)
+	}
+	if got := podNames(skipped); len(got) != 2 || got[0] != "kube-apiserver" || got[1] != "fluentd" {
+		t.Errorf("skipped = %v, want [kube-apiserver fluentd]", got)
+	}
+}
+
+func TestPodsToEvictRefusesDaemonSetPods(t *testing.T) {
+	pods := []corev1.Pod{
+		testPod("web", ownedBy("ReplicaSet")),
+		testPod("fluentd", ownedBy("DaemonSet")),
+	}
+
+	if _, _, err := podsToEvict(pods, false); err == nil {
+		t.Fatal("podsToEvict() expected error for DaemonSet pods without ignoreDaemonSets")
+	}
+}
+
+func TestPodsToEvictSkipsMirrorPodsWithoutFlag(t *testing.T) {
+	pods := []corev1.Pod{testPod("etcd", mirror)}
+
+	evict, skipped, err := podsToEvict(pods, false)
+	if err != nil {
+		t.Fatalf("podsToEvict() error = %v", err)
+	}
+	if len(evict) != 0 || len(skipped) != 1 {
+		t.Errorf("evict = %v, skipped = %v, want mirror pod skipped", podNames(evict), podNames(skipped))
+	}
+}
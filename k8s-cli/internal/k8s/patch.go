@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// PatchManifests applies every document in patchData as a strategic-merge
+// patch onto the document in baseData with the same kind and name, like a
+// kustomize overlay, and returns all base documents as JSON. Kinds without Go
+// types, such as custom resources, get a JSON merge patch, as kubectl does.
+// A patch whose target is not in the base is an error.
+func PatchManifests(baseData, patchData []byte) ([][]byte, error) {
+	baseDocuments, err := SplitYAMLDocuments(baseData)
+	if err != nil {
+		return nil, err
+	}
+	patchDocuments, err := SplitYAMLDocuments(patchData)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([][]byte, len(baseDocuments))
+	objects := make([]*unstructured.Unstructured, len(baseDocuments))
+	for i, document := range baseDocuments {
+		if documents[i], objects[i], err = decodeManifest(document); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, document := range patchDocuments {
+		patch, target, err := decodeManifest(document)
+		if err != nil {
+			return nil, fmt.Errorf("error reading patch: %w", err)
+		}
+
+		matched := false
+		for i, obj := range objects {
+			if obj.GetKind() != target.GetKind() || obj.GetName() != target.GetName() {
+				continue
+			}
+			if documents[i], err = mergeManifest(documents[i], patch, obj); err != nil {
+				return nil, fmt.Errorf("error patching %s %s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+			matched = true
+		}
+		if !matched {
+			return nil, fmt.Errorf("patch targets %s %s, which is not in the base manifests", target.GetKind(), target.GetName())
+		}
+	}
+	return documents, nil
+}
+
+// decodeManifest converts a YAML or JSON document to JSON and reads its kind and name
+func decodeManifest(document []byte) ([]byte, *unstructured.Unstructured, error) {
+	data, err := yaml.YAMLToJSON(document)
+	if err != nil {
+		return nil, nil, wrapError(ErrDecodeYAML, "error decoding YAML", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, nil, wrapError(ErrDecodeYAML, "error decoding YAML", err)
+	}
+	return data, obj, nil
+}
+
+func mergeManifest(original, patch []byte, obj *unstructured.Unstructured) ([]byte, error) {
+	dataStruct, err := scheme.Scheme.New(obj.GroupVersionKind())
+	if err != nil {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+}
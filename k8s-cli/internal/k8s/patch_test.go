@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const patchTestBase = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+        env:
+        - name: MODE
+          value: dev
+      - name: sidecar
+        image: envoy:1.28
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: k8scli.dev/v1
+kind: FrontendPage
+metadata:
+  name: shop
+spec:
+  title: Shop
+  replicas: 1
+`
+
+func patchTestDocument(t *testing.T, documents [][]byte, i int) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(documents[i]); err != nil {
+		t.Fatalf("document %d is not JSON: %v", i, err)
+	}
+	return obj
+}
+
+func TestPatchManifests(t *testing.T) {
+	patch := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.26
+---
+kind: FrontendPage
+metadata:
+  name: shop
+spec:
+  replicas: 2
+`
+	documents, err := PatchManifests([]byte(patchTestBase), []byte(patch))
+	if err != nil {
+		t.Fatalf("PatchManifests() error = %v", err)
+	}
+	if len(documents) != 3 {
+		t.Fatalf("got %d documents, want the 3 base documents", len(documents))
+	}
+
+	deployment := patchTestDocument(t, documents, 0)
+	if replicas, _, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas"); replicas != 3 {
+		t.Errorf("replicas = %d, want 3", replicas)
+	}
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	if len(containers) != 2 {
+		t.Fatalf("got %d containers, want the strategic merge to keep both by name: %v", len(containers), containers)
+	}
+	web := containers[0].(map[string]interface{})
+	if web["image"] != "nginx:1.26" || web["env"] == nil {
+		t.Errorf("web container = %v, want the new image and the base env", web)
+	}
+
+	if service := patchTestDocument(t, documents, 1); service.GetKind() != "Service" {
+		t.Errorf("document 1 kind = %s, want the unpatched Service", service.GetKind())
+	}
+
+	page := patchTestDocument(t, documents, 2)
+	title, _, _ := unstructured.NestedString(page.Object, "spec", "title")
+	replicas, _, _ := unstructured.NestedInt64(page.Object, "spec", "replicas")
+	if title != "Shop" || replicas != 2 {
+		t.Errorf("FrontendPage spec = %v, want the merge patch applied over the base", page.Object["spec"])
+	}
+}
+
+func TestPatchManifestsMissingTarget(t *testing.T) {
+	patch := "kind: Deployment\nmetadata:\n  name: api\nspec:\n  replicas: 2\n"
+	_, err := PatchManifests([]byte(patchTestBase), []byte(patch))
+	if err == nil || !strings.Contains(err.Error(), "Deployment api") {
+		t.Errorf("PatchManifests() error = %v, want it to name the missing target", err)
+	}
+}
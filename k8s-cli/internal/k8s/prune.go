@@ -0,0 +1,247 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ObjectRef identifies an applied object so it can be kept when pruning
+type ObjectRef struct {
+	Resource  schema.GroupVersionResource
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// NewObjectRef returns the identity of obj, e.g. from ServerSideApplyFromYAML
+func NewObjectRef(obj *unstructured.Unstructured) ObjectRef {
+	gvk := obj.GroupVersionKind()
+	return ObjectRef{
+		Resource:  schema.GroupVersionResource{Group: gvk.Group, Version: gvk.Version, Resource: getResourceName(gvk.Kind)},
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Resource.Resource, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Resource.Resource, r.Namespace, r.Name)
+}
+
+// defaultPruneKinds are checked even when no manifest contains them any more,
+// so that removing the last ConfigMap from the directory still prunes it
+var defaultPruneKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "Service"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+}
+
+// PruneOptions scopes Prune
+type PruneOptions struct {
+	// Selector is required: only objects carrying these labels are candidates
+	Selector string
+	// Namespace is searched for namespaced kinds next to the namespaces of the applied objects
+	Namespace string
+	// FieldManager defaults to FieldManager; only objects it applied are pruned
+	FieldManager string
+}
+
+// SplitYAMLDocuments splits a multi-document manifest on "---", dropping empty documents
+func SplitYAMLDocuments(data []byte) ([][]byte, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var documents [][]byte
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, wrapError(ErrDecodeYAML, "error reading YAML document", err)
+		}
+		if len(bytes.TrimSpace(stripYAMLComments(document))) > 0 {
+			documents = append(documents, document)
+		}
+	}
+}
+
+func stripYAMLComments(document []byte) []byte {
+	var out bytes.Buffer
+	for _, line := range strings.Split(string(document), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			out.WriteString(line)
+			out.WriteByte('\n')
+		}
+	}
+	return out.Bytes()
+}
+
+// Prune deletes objects that match opts.Selector but are not in applied, like
+// kubectl apply --prune. It lists the applied kinds plus defaultPruneKinds,
+// and namespaced kinds only in the namespaces the manifests were applied to,
+// so a broad selector can't reach into unrelated namespaces. Objects that
+// opts.FieldManager never applied are left alone even if the selector matches
+// them. The returned refs are the objects deleted before any error.
+func (c *Client) Prune(applied []ObjectRef, opts PruneOptions) ([]ObjectRef, error) {
+	if strings.TrimSpace(opts.Selector) == "" {
+		return nil, fmt.Errorf("prune requires a label selector")
+	}
+	if _, err := labels.Parse(opts.Selector); err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", opts.Selector, err)
+	}
+
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = FieldManager
+	}
+
+	// A kind is listed at one version, the first it was applied at; the
+	// server returns every object of the kind at that version, so objects
+	// are kept by group, kind, namespace and name, whatever their version
+	keep := make(map[pruneKey]bool, len(applied))
+	kinds := make(map[schema.GroupKind]ObjectRef)
+	namespaces := make(map[string]bool)
+	if opts.Namespace != "" {
+		namespaces[opts.Namespace] = true
+	}
+	for _, ref := range applied {
+		keep[newPruneKey(ref)] = true
+		groupKind := schema.GroupKind{Group: ref.Resource.Group, Kind: ref.Kind}
+		if _, ok := kinds[groupKind]; !ok {
+			kinds[groupKind] = ObjectRef{Resource: ref.Resource, Kind: ref.Kind}
+		}
+		if ref.Namespace != "" {
+			namespaces[ref.Namespace] = true
+		}
+	}
+	for _, gvk := range defaultPruneKinds {
+		if _, ok := kinds[gvk.GroupKind()]; !ok {
+			kinds[gvk.GroupKind()] = ObjectRef{
+				Resource: schema.GroupVersionResource{Group: gvk.Group, Version: gvk.Version, Resource: getResourceName(gvk.Kind)},
+				Kind:     gvk.Kind,
+			}
+		}
+	}
+
+	var candidates []ObjectRef
+	for _, kind := range kinds {
+		var found []ObjectRef
+		var err error
+		if isClusterScoped(kind.Kind) {
+			found, err = c.listPruneCandidates(kind, "", opts.Selector, fieldManager)
+		} else {
+			for namespace := range namespaces {
+				var inNamespace []ObjectRef
+				inNamespace, err = c.listPruneCandidates(kind, namespace, opts.Selector, fieldManager)
+				if err != nil {
+					break
+				}
+				found = append(found, inNamespace...)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range found {
+			if !keep[newPruneKey(ref)] {
+				candidates = append(candidates, ref)
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].String() < candidates[j].String() })
+
+	var pruned []ObjectRef
+	propagation := metav1.DeletePropagationBackground
+	for _, ref := range candidates {
+		err := c.dynamicClient.Resource(ref.Resource).Namespace(ref.Namespace).Delete(
+			context.TODO(),
+			ref.Name,
+			metav1.DeleteOptions{PropagationPolicy: &propagation},
+		)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return pruned, requestError(fmt.Sprintf("error pruning %s", ref), err)
+		}
+		pruned = append(pruned, ref)
+	}
+
+	return pruned, nil
+}
+
+// pruneKey identifies an object independent of the API version it was
+// applied or listed at
+type pruneKey struct {
+	GroupKind schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+func newPruneKey(ref ObjectRef) pruneKey {
+	return pruneKey{
+		GroupKind: schema.GroupKind{Group: ref.Resource.Group, Kind: ref.Kind},
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+	}
+}
+
+// listPruneCandidates lists objects of kind matching selector that fieldManager
+// applied. Kinds the server doesn't serve are skipped, objects already being
+// deleted are left alone.
+func (c *Client) listPruneCandidates(kind ObjectRef, namespace, selector, fieldManager string) ([]ObjectRef, error) {
+	list, err := c.dynamicClient.Resource(kind.Resource).Namespace(namespace).List(
+		context.TODO(),
+		metav1.ListOptions{LabelSelector: selector},
+	)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, requestError(fmt.Sprintf("error listing %s", kind.Resource.Resource), err)
+	}
+
+	refs := make([]ObjectRef, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.GetDeletionTimestamp() != nil || !appliedBy(&item, fieldManager) {
+			continue
+		}
+		refs = append(refs, ObjectRef{
+			Resource:  kind.Resource,
+			Kind:      kind.Kind,
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+		})
+	}
+	return refs, nil
+}
+
+// appliedBy reports whether fieldManager owns fields of obj through
+// server-side apply, i.e. the object was applied by k8s-cli and not created
+// by hand or by another tool that happens to use the same labels
+func appliedBy(obj *unstructured.Unstructured, fieldManager string) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == fieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
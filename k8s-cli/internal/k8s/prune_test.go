@@ -0,0 +1,162 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func pruneTestObject(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+// appliedPruneTestObject is pruneTestObject as left behind by ServerSideApplyFromYAML
+func appliedPruneTestObject(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := pruneTestObject(apiVersion, kind, namespace, name, labels)
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:    FieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: apiVersion,
+	}})
+	return obj
+}
+
+func newPruneTestClient(objects ...runtime.Object) (*Client, *dynamicfake.FakeDynamicClient) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}:                                     "ConfigMapList",
+		{Version: "v1", Resource: "secrets"}:                                        "SecretList",
+		{Version: "v1", Resource: "services"}:                                       "ServiceList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                     "DeploymentList",
+		{Group: "autoscaling", Version: "v1", Resource: "horizontalpodautoscalers"}: "HorizontalPodAutoscalerList",
+		{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}: "HorizontalPodAutoscalerList",
+		{Group: "a.example.com", Version: "v1", Resource: "widgets"}:                "WidgetList",
+		{Group: "b.example.com", Version: "v1", Resource: "widgets"}:                "WidgetList",
+	}, objects...)
+	return &Client{dynamicClient: dynamicClient}, dynamicClient
+}
+
+func TestPrune(t *testing.T) {
+	app := map[string]string{"app": "myapp"}
+	client, dynamicClient := newPruneTestClient(
+		appliedPruneTestObject("apps/v1", "Deployment", "team-a", "web", app),
+		appliedPruneTestObject("apps/v1", "Deployment", "team-a", "old-web", app),
+		appliedPruneTestObject("v1", "ConfigMap", "team-a", "old-config", app),
+		appliedPruneTestObject("v1", "ConfigMap", "team-a", "unrelated", map[string]string{"app": "other"}),
+		appliedPruneTestObject("v1", "ConfigMap", "team-b", "elsewhere", app),
+		// Matches the selector but was created by hand, so it's not ours to prune
+		pruneTestObject("v1", "ConfigMap", "team-a", "hand-made", app),
+	)
+
+	applied := []ObjectRef{
+		NewObjectRef(pruneTestObject("apps/v1", "Deployment", "team-a", "web", app)),
+	}
+
+	pruned, err := client.Prune(applied, PruneOptions{Selector: "app=myapp", Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	var got []string
+	for _, ref := range pruned {
+		got = append(got, ref.String())
+	}
+	want := []string{"configmaps/team-a/old-config", "deployments/team-a/old-web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pruned = %v, want %v", got, want)
+	}
+
+	remaining := map[schema.GroupVersionResource][]string{
+		{Group: "apps", Version: "v1", Resource: "deployments"}: {"web"},
+		{Version: "v1", Resource: "configmaps"}:                 {"elsewhere", "hand-made", "unrelated"},
+	}
+	for gvr, wantNames := range remaining {
+		list, err := dynamicClient.Resource(gvr).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("List(%s) error = %v", gvr.Resource, err)
+		}
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.GetName())
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, wantNames) {
+			t.Errorf("remaining %s = %v, want %v", gvr.Resource, names, wantNames)
+		}
+	}
+}
+
+func TestPruneKindAcrossVersionsAndGroups(t *testing.T) {
+	app := map[string]string{"app": "myapp"}
+	// The server answers a list at autoscaling/v2 with every HPA, including
+	// the one applied at autoscaling/v1
+	client, _ := newPruneTestClient(
+		appliedPruneTestObject("autoscaling/v2", "HorizontalPodAutoscaler", "team-a", "web", app),
+		appliedPruneTestObject("autoscaling/v2", "HorizontalPodAutoscaler", "team-a", "api", app),
+		appliedPruneTestObject("autoscaling/v2", "HorizontalPodAutoscaler", "team-a", "worker", app),
+		appliedPruneTestObject("a.example.com/v1", "Widget", "team-a", "widget", app),
+		appliedPruneTestObject("b.example.com/v1", "Widget", "team-a", "widget", app),
+		appliedPruneTestObject("b.example.com/v1", "Widget", "team-a", "old-widget", app),
+	)
+
+	applied := []ObjectRef{
+		NewObjectRef(pruneTestObject("autoscaling/v2", "HorizontalPodAutoscaler", "team-a", "web", app)),
+		NewObjectRef(pruneTestObject("autoscaling/v1", "HorizontalPodAutoscaler", "team-a", "api", app)),
+		NewObjectRef(pruneTestObject("autoscaling/v2", "HorizontalPodAutoscaler", "team-a", "worker", app)),
+		NewObjectRef(pruneTestObject("b.example.com/v1", "Widget", "team-a", "widget", app)),
+		NewObjectRef(pruneTestObject("a.example.com/v1", "Widget", "team-a", "widget", app)),
+	}
+
+	pruned, err := client.Prune(applied, PruneOptions{Selector: "app=myapp", Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Resource.Group != "b.example.com" || pruned[0].Name != "old-widget" {
+		t.Errorf("pruned = %v, want only the b.example.com old-widget", pruned)
+	}
+}
+
+func TestPruneRequiresSelector(t *testing.T) {
+	client, _ := newPruneTestClient()
+
+	for _, selector := range []string{"", "  ", "app in (("} {
+		if _, err := client.Prune(nil, PruneOptions{Selector: selector, Namespace: "default"}); err == nil {
+			t.Errorf("Prune() with selector %q: expected error", selector)
+		}
+	}
+}
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	data := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+# only a comment
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`)
+
+	documents, err := SplitYAMLDocuments(data)
+	if err != nil {
+		t.Fatalf("SplitYAMLDocuments() error = %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("got %d documents, want 2", len(documents))
+	}
+}
@@ -0,0 +1,118 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RevisionAnnotation is set by the deployment controller on each ReplicaSet
+const RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// DeploymentReplicaSets returns the ReplicaSets controlled by the deployment
+func (c *Client) DeploymentReplicaSets(namespace, name string) ([]appsv1.ReplicaSet, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, requestError(fmt.Sprintf("error getting deployment '%s'", name), err)
+	}
+	return c.ownedReplicaSets(deployment)
+}
+
+func (c *Client) ownedReplicaSets(deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on deployment '%s': %w", deployment.Name, err)
+	}
+
+	list, err := c.clientset.AppsV1().ReplicaSets(deployment.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, requestError("error listing replicasets", err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if owner := metav1.GetControllerOf(&rs); owner != nil && owner.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+	return owned, nil
+}
+
+// RollbackDeployment restores the pod template of the ReplicaSet with the
+// given revision. A toRevision of 0 means the revision before the current one.
+// It returns the revision rolled back to.
+func (c *Client) RollbackDeployment(namespace, name string, toRevision int64) (int64, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return 0, requestError(fmt.Sprintf("error getting deployment '%s'", name), err)
+	}
+
+	replicaSets, err := c.ownedReplicaSets(deployment)
+	if err != nil {
+		return 0, err
+	}
+
+	target, revision, err := findRevision(replicaSets, toRevision)
+	if err != nil {
+		return 0, fmt.Errorf("deployment '%s': %w", name, err)
+	}
+
+	// The controller adds pod-template-hash to each ReplicaSet's template;
+	// it must not leak back into the deployment
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error building rollback patch: %w", err)
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(context.TODO(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return 0, requestError(fmt.Sprintf("error rolling back deployment '%s'", name), err)
+	}
+	return revision, nil
+}
+
+// findRevision picks the ReplicaSet for toRevision, or the newest one older
+// than the current revision when toRevision is 0
+func findRevision(replicaSets []appsv1.ReplicaSet, toRevision int64) (*appsv1.ReplicaSet, int64, error) {
+	revisions := make(map[int64]*appsv1.ReplicaSet, len(replicaSets))
+	var current int64
+	for i := range replicaSets {
+		revision, err := strconv.ParseInt(replicaSets[i].Annotations[RevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		revisions[revision] = &replicaSets[i]
+		if revision > current {
+			current = revision
+		}
+	}
+
+	if toRevision == 0 {
+		for revision := range revisions {
+			if revision < current && revision > toRevision {
+				toRevision = revision
+			}
+		}
+		if toRevision == 0 {
+			return nil, 0, fmt.Errorf("no previous revision to roll back to")
+		}
+	}
+
+	rs, ok := revisions[toRevision]
+	if !ok {
+		return nil, 0, wrapError(ErrNotFound, fmt.Sprintf("revision %d not found", toRevision), nil)
+	}
+	return rs, toRevision, nil
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func revisionRS(name, revision string) appsv1.ReplicaSet {
+	return appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Annotations: map[string]string{RevisionAnnotation: revision},
+	}}
+}
+
+func TestFindRevision(t *testing.T) {
+	replicaSets := []appsv1.ReplicaSet{
+		revisionRS("web-a", "1"),
+		revisionRS("web-c", "3"),
+		revisionRS("web-b", "2"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-unannotated"}},
+	}
+
+	tests := []struct {
+		name         string
+		toRevision   int64
+		wantName     string
+		wantRevision int64
+		wantErr      bool
+	}{
+		{name: "previous revision by default", toRevision: 0, wantName: "web-b", wantRevision: 2},
+		{name: "explicit revision", toRevision: 1, wantName: "web-a", wantRevision: 1},
+		{name: "current revision", toRevision: 3, wantName: "web-c", wantRevision: 3},
+		{name: "missing revision", toRevision: 7, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, revision, err := findRevision(replicaSets, tt.toRevision)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findRevision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if rs.Name != tt.wantName || revision != tt.wantRevision {
+				t.Errorf("findRevision() = %s (revision %d), want %s (revision %d)", rs.Name, revision, tt.wantName, tt.wantRevision)
+			}
+		})
+	}
+}
+
+func TestFindRevisionWithoutHistory(t *testing.T) {
+	if _, _, err := findRevision([]appsv1.ReplicaSet{revisionRS("web-a", "1")}, 0); err == nil {
+		t.Fatal("findRevision() expected error when only the current revision exists")
+	}
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// IsWorkload reports whether WaitForReady waits for objects of kind; other
+// kinds are ready as soon as they are created
+func IsWorkload(kind string) bool {
+	switch kind {
+	case "Deployment", "StatefulSet", "Pod":
+		return true
+	}
+	return false
+}
+
+// ManifestObjectRef identifies the object a manifest creates, defaulting the
+// namespace the way CreateFromYAML does
+func ManifestObjectRef(yamlData []byte, namespace string) (ObjectRef, error) {
+	var obj unstructured.Unstructured
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(string(yamlData)), 4096).Decode(&obj); err != nil {
+		return ObjectRef{}, wrapError(ErrDecodeYAML, "error decoding YAML", err)
+	}
+	if obj.GetNamespace() == "" && !isClusterScoped(obj.GetKind()) {
+		obj.SetNamespace(namespace)
+	}
+	return NewObjectRef(&obj), nil
+}
+
+// WaitForReady polls ref every interval until it is ready: a Deployment or
+// StatefulSet with every replica updated and ready, a Pod Running. It fails
+// early for a Pod that has Failed and otherwise gives up when ctx ends.
+func (c *Client) WaitForReady(ctx context.Context, ref ObjectRef, interval time.Duration) error {
+	if !IsWorkload(ref.Kind) {
+		return nil
+	}
+
+	var reason string
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		obj, err := c.dynamicClient.Resource(ref.Resource).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			reason = err.Error()
+			return false, nil
+		}
+		ready, why, err := workloadReady(obj)
+		reason = why
+		return ready, err
+	})
+	if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return fmt.Errorf("timed out waiting for %s to be ready: %s", ref, reason)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not ready: %w", ref, err)
+	}
+	return nil
+}
+
+// workloadReady reports whether obj is ready and, when it is not, why
+func workloadReady(obj *unstructured.Unstructured) (bool, string, error) {
+	if obj.GetKind() == "Pod" {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Running":
+			return true, "", nil
+		case "Failed":
+			return false, "", fmt.Errorf("pod phase is Failed")
+		}
+		return false, fmt.Sprintf("pod phase is %q", phase), nil
+	}
+
+	observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if observed < obj.GetGeneration() {
+		return false, "waiting for the controller to observe the latest spec", nil
+	}
+
+	desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		desired = 1
+	}
+	updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	total, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+
+	switch {
+	case updated < desired:
+		return false, fmt.Sprintf("%d of %d replicas updated", updated, desired), nil
+	case total > updated:
+		return false, fmt.Sprintf("%d old replicas pending termination", total-updated), nil
+	case ready < desired:
+		return false, fmt.Sprintf("%d of %d replicas ready", ready, desired), nil
+	}
+	return true, "", nil
+}
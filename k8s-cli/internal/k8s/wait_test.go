@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func waitTestObject(apiVersion, kind, name string, content map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace("team-a")
+	obj.SetName(name)
+	return obj
+}
+
+func TestWorkloadReady(t *testing.T) {
+	deployment := func(replicas, updated, ready, total int64) *unstructured.Unstructured {
+		return waitTestObject("apps/v1", "Deployment", "web", map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": replicas},
+			"status": map[string]interface{}{"updatedReplicas": updated, "readyReplicas": ready, "replicas": total},
+		})
+	}
+	pod := func(phase string) *unstructured.Unstructured {
+		return waitTestObject("v1", "Pod", "job", map[string]interface{}{"status": map[string]interface{}{"phase": phase}})
+	}
+	stale := deployment(2, 2, 2, 2)
+	stale.SetGeneration(3)
+
+	tests := []struct {
+		name      string
+		obj       *unstructured.Unstructured
+		wantReady bool
+		wantErr   bool
+	}{
+		{name: "rolled out", obj: deployment(3, 3, 3, 3), wantReady: true},
+		{name: "replicas updating", obj: deployment(3, 1, 3, 4)},
+		{name: "old replicas terminating", obj: deployment(3, 3, 3, 4)},
+		{name: "replicas not ready", obj: deployment(3, 3, 2, 3)},
+		{name: "spec not observed", obj: stale},
+		{name: "statefulset ready", obj: waitTestObject("apps/v1", "StatefulSet", "db", map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"updatedReplicas": int64(2), "readyReplicas": int64(2), "replicas": int64(2)},
+		}), wantReady: true},
+		{name: "pod running", obj: pod("Running"), wantReady: true},
+		{name: "pod pending", obj: pod("Pending")},
+		{name: "pod failed", obj: pod("Failed"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, reason, err := workloadReady(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("workloadReady() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ready != tt.wantReady {
+				t.Errorf("workloadReady() = %v (%s), want %v", ready, reason, tt.wantReady)
+			}
+			if !ready && !tt.wantErr && reason == "" {
+				t.Error("workloadReady() gave no reason for not being ready")
+			}
+		})
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	ready := waitTestObject("apps/v1", "Deployment", "web", map[string]interface{}{
+		"spec":   map[string]interface{}{"replicas": int64(1)},
+		"status": map[string]interface{}{"updatedReplicas": int64(1), "readyReplicas": int64(1), "replicas": int64(1)},
+	})
+	pending := waitTestObject("v1", "Pod", "job", map[string]interface{}{"status": map[string]interface{}{"phase": "Pending"}})
+	client := &Client{dynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), ready, pending)}
+
+	if err := client.WaitForReady(context.Background(), NewObjectRef(ready), time.Millisecond); err != nil {
+		t.Errorf("WaitForReady(ready deployment) error = %v", err)
+	}
+
+	configMap := waitTestObject("v1", "ConfigMap", "settings", map[string]interface{}{})
+	if err := client.WaitForReady(context.Background(), NewObjectRef(configMap), time.Millisecond); err != nil {
+		t.Errorf("WaitForReady(configmap) error = %v, want non-workloads skipped", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.WaitForReady(ctx, NewObjectRef(pending), 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for pods/team-a/job") || !strings.Contains(err.Error(), `"Pending"`) {
+		t.Errorf("WaitForReady(pending pod) error = %v, want a timeout naming the pod and its phase", err)
+	}
+}
+
+func TestManifestObjectRef(t *testing.T) {
+	ref, err := ManifestObjectRef([]byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"), "team-a")
+	if err != nil {
+		t.Fatalf("ManifestObjectRef() error = %v", err)
+	}
+	if got, want := ref.String(), "deployments/team-a/web"; got != want {
+		t.Errorf("ManifestObjectRef() = %s, want %s", got, want)
+	}
+
+	ref, err = ManifestObjectRef([]byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: team-b\n"), "team-a")
+	if err != nil {
+		t.Fatalf("ManifestObjectRef() error = %v", err)
+	}
+	if got, want := ref.String(), "namespaces/team-b"; got != want {
+		t.Errorf("ManifestObjectRef() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/yaml"
+)
+
+// ClusterInfo краткая сводка состояния кластера
+type ClusterInfo struct {
+	ServerVersion string `json:"serverVersion"`
+	Nodes         int    `json:"nodes"`
+	ReadyNodes    int    `json:"readyNodes"`
+	Namespaces    int    `json:"namespaces"`
+	Deployments   int    `json:"deployments"`
+	Pods          int    `json:"pods"`
+}
+
+// PrintClusterInfo выводит сводку кластера в указанном формате
+func PrintClusterInfo(info ClusterInfo, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling cluster info to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	case "yaml":
+		data, err := yaml.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("error marshaling cluster info to YAML: %w", err)
+		}
+		fmt.Print(string(data))
+	default:
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"FIELD", "VALUE"})
+		table.Append([]string{"Server Version", info.ServerVersion})
+		table.Append([]string{"Nodes (ready/total)", fmt.Sprintf("%d/%d", info.ReadyNodes, info.Nodes)})
+		table.Append([]string{"Namespaces", fmt.Sprintf("%d", info.Namespaces)})
+		table.Append([]string{"Deployments", fmt.Sprintf("%d", info.Deployments)})
+		table.Append([]string{"Pods", fmt.Sprintf("%d", info.Pods)})
+		table.Render()
+	}
+	return nil
+}
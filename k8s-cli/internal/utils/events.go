@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// eventLineFormat колонки потокового вывода, совпадают с таблицей
+const eventLineFormat = "%-8s %-24s %-40s %-6s %s\n"
+
+// PrintEvents выводит список событий в указанном формате
+func PrintEvents(events []corev1.Event, format string) error {
+	switch format {
+	case "json":
+		printEventsJSON(events)
+	case "yaml":
+		fmt.Println("# Events YAML output")
+		printEventsJSON(events)
+	default:
+		printEventsTable(events)
+	}
+	return nil
+}
+
+// PrintEventHeader выводит заголовок для потокового вывода событий
+func PrintEventHeader() {
+	fmt.Printf(eventLineFormat, "TYPE", "REASON", "OBJECT", "AGE", "MESSAGE")
+}
+
+// PrintEventLine выводит одно событие строкой для режима --watch
+func PrintEventLine(event corev1.Event) {
+	row := eventRow(event)
+	fmt.Printf(eventLineFormat, row[0], row[1], row[2], row[4], row[3])
+}
+
+// EventTimestamp возвращает время последнего появления события
+func EventTimestamp(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func printEventsTable(events []corev1.Event) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"TYPE", "REASON", "OBJECT", "MESSAGE", "AGE"})
+
+	for _, event := range events {
+		table.Append(eventRow(event))
+	}
+
+	table.Render()
+}
+
+func printEventsJSON(events []corev1.Event) {
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling events to JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func eventRow(event corev1.Event) []string {
+	object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+	return []string{
+		event.Type,
+		event.Reason,
+		object,
+		event.Message,
+		formatAge(metav1.NewTime(EventTimestamp(event))),
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/yaml"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+// PrintFrontendPages выводит список FrontendPage в указанном формате
+func PrintFrontendPages(w io.Writer, pages []k8scliv1.FrontendPage, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(pages, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling frontendpages to JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(pages)
+		if err != nil {
+			return fmt.Errorf("error marshaling frontendpages to YAML: %w", err)
+		}
+		fmt.Fprint(w, string(data))
+	case "name":
+		for _, page := range pages {
+			fmt.Fprintln(w, ResourceName("frontendpage", k8scliv1.GroupVersion.Group, page.Name))
+		}
+	default:
+		printFrontendPagesTable(w, pages)
+	}
+	return nil
+}
+
+func printFrontendPagesTable(w io.Writer, pages []k8scliv1.FrontendPage) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"NAME", "NAMESPACE", "TITLE", "PATH", "PHASE", "READY", "URL", "AGE"})
+
+	for _, page := range pages {
+		table.Append([]string{
+			page.Name,
+			page.Namespace,
+			page.Spec.Title,
+			page.Spec.Path,
+			page.Status.Phase,
+			strconv.FormatBool(page.Status.Ready),
+			page.Status.URL,
+			formatAge(page.CreationTimestamp),
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8scliv1 "k8s-cli/api/v1"
+)
+
+func TestPrintFrontendPages(t *testing.T) {
+	pages := []k8scliv1.FrontendPage{{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "web"},
+		Spec:       k8scliv1.FrontendPageSpec{Title: "Shop", Path: "/shop"},
+		Status:     k8scliv1.FrontendPageStatus{Phase: "Ready", Ready: true, URL: "http://shop.web.svc.cluster.local/shop"},
+	}}
+
+	var table bytes.Buffer
+	if err := PrintFrontendPages(&table, pages, "table"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"TITLE", "PATH", "PHASE", "READY", "URL", "Shop", "/shop", "Ready", "true", "http://shop.web.svc.cluster.local/shop"} {
+		if !strings.Contains(table.String(), want) {
+			t.Errorf("table is missing %q:\n%s", want, table.String())
+		}
+	}
+
+	var names bytes.Buffer
+	if err := PrintFrontendPages(&names, pages, "name"); err != nil {
+		t.Fatal(err)
+	}
+	if got := names.String(); got != "frontendpage.k8scli.dev/shop\n" {
+		t.Errorf("name output = %q", got)
+	}
+
+	var yamlOut bytes.Buffer
+	if err := PrintFrontendPages(&yamlOut, pages, "yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(yamlOut.String(), "title: Shop") {
+		t.Errorf("yaml output = %q", yamlOut.String())
+	}
+}
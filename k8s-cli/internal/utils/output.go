@@ -20,6 +20,10 @@ func PrintPods(pods []corev1.Pod, format string) error {
 		printPodsJSON(pods)
 	case "yaml":
 		printPodsYAML(pods)
+	case "name":
+		for _, item := range pods {
+			fmt.Println(ResourceName("pod", "", item.Name))
+		}
 	default:
 		printPodsTable(pods)
 	}
@@ -33,6 +37,10 @@ func PrintDeployments(deployments []appsv1.Deployment, format string) error {
 		printDeploymentsJSON(deployments)
 	case "yaml":
 		printDeploymentsYAML(deployments)
+	case "name":
+		for _, item := range deployments {
+			fmt.Println(ResourceName("deployment", "apps", item.Name))
+		}
 	default:
 		printDeploymentsTable(deployments)
 	}
@@ -46,86 +54,114 @@ func PrintServices(services []corev1.Service, format string) error {
 		printServicesJSON(services)
 	case "yaml":
 		printServicesYAML(services)
+	case "name":
+		for _, item := range services {
+			fmt.Println(ResourceName("service", "", item.Name))
+		}
 	default:
 		printServicesTable(services)
 	}
 	return nil
 }
 
+// StripManagedFields очищает metadata.managedFields у объектов: kubectl тоже
+// скрывает их в -o json/yaml, если не задан --show-managed-fields
+func StripManagedFields[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) {
+	for i := range items {
+		PT(&items[i]).SetManagedFields(nil)
+	}
+}
+
+// ResourceName форматирует имя как kubectl -o name: "<resource>.<group>/<name>",
+// для core группы просто "<resource>/<name>"
+func ResourceName(resource, group, name string) string {
+	if group != "" {
+		resource += "." + group
+	}
+	return resource + "/" + name
+}
+
+// Заголовки таблиц; потоковый вывод --watch использует те же колонки
+var (
+	podColumns        = []string{"NAME", "NAMESPACE", "STATUS", "READY", "RESTARTS", "AGE"}
+	deploymentColumns = []string{"NAME", "NAMESPACE", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"}
+	serviceColumns    = []string{"NAME", "NAMESPACE", "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE"}
+)
+
 func printPodsTable(pods []corev1.Pod) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"NAME", "NAMESPACE", "STATUS", "READY", "RESTARTS", "AGE"})
+	table.SetHeader(podColumns)
 
 	for _, pod := range pods {
-		ready := fmt.Sprintf("%d/%d", countReadyContainers(pod), len(pod.Spec.Containers))
-		restarts := fmt.Sprintf("%d", countRestarts(pod))
-		age := formatAge(pod.CreationTimestamp)
-
-		table.Append([]string{
-			pod.Name,
-			pod.Namespace,
-			string(pod.Status.Phase),
-			ready,
-			restarts,
-			age,
-		})
+		table.Append(podRow(pod))
 	}
 
 	table.Render()
 }
 
+func podRow(pod corev1.Pod) []string {
+	return []string{
+		pod.Name,
+		pod.Namespace,
+		string(pod.Status.Phase),
+		fmt.Sprintf("%d/%d", countReadyContainers(pod), len(pod.Spec.Containers)),
+		fmt.Sprintf("%d", countRestarts(pod)),
+		formatAge(pod.CreationTimestamp),
+	}
+}
+
 func printDeploymentsTable(deployments []appsv1.Deployment) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"NAME", "NAMESPACE", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"})
+	table.SetHeader(deploymentColumns)
 
 	for _, deployment := range deployments {
-		replicas := int32(0)
-		if deployment.Spec.Replicas != nil {
-			replicas = *deployment.Spec.Replicas
-		}
-		ready := fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, replicas)
-		upToDate := fmt.Sprintf("%d", deployment.Status.UpdatedReplicas)
-		available := fmt.Sprintf("%d", deployment.Status.AvailableReplicas)
-		age := formatAge(deployment.CreationTimestamp)
-
-		table.Append([]string{
-			deployment.Name,
-			deployment.Namespace,
-			ready,
-			upToDate,
-			available,
-			age,
-		})
+		table.Append(deploymentRow(deployment))
 	}
 
 	table.Render()
 }
 
+func deploymentRow(deployment appsv1.Deployment) []string {
+	replicas := int32(0)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return []string{
+		deployment.Name,
+		deployment.Namespace,
+		fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, replicas),
+		fmt.Sprintf("%d", deployment.Status.UpdatedReplicas),
+		fmt.Sprintf("%d", deployment.Status.AvailableReplicas),
+		formatAge(deployment.CreationTimestamp),
+	}
+}
+
 func printServicesTable(services []corev1.Service) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"NAME", "NAMESPACE", "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE"})
+	table.SetHeader(serviceColumns)
 
 	for _, service := range services {
-		serviceType := string(service.Spec.Type)
-		clusterIP := service.Spec.ClusterIP
-		externalIP := getExternalIP(service)
-		ports := getPorts(service)
-		age := formatAge(service.CreationTimestamp)
-
-		table.Append([]string{
-			service.Name,
-			service.Namespace,
-			serviceType,
-			clusterIP,
-			externalIP,
-			ports,
-			age,
-		})
+		table.Append(serviceRow(service))
 	}
 
 	table.Render()
 }
 
+func serviceRow(service corev1.Service) []string {
+	return []string{
+		service.Name,
+		service.Namespace,
+		string(service.Spec.Type),
+		service.Spec.ClusterIP,
+		getExternalIP(service),
+		getPorts(service),
+		formatAge(service.CreationTimestamp),
+	}
+}
+
 func printPodsJSON(pods []corev1.Pod) {
 	data, err := json.MarshalIndent(pods, "", "  ")
 	if err != nil {
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		resource, group, name string
+		want                  string
+	}{
+		{"deployment", "apps", "foo", "deployment.apps/foo"},
+		{"pod", "", "nginx", "pod/nginx"},
+		{"service", "", "web", "service/web"},
+	}
+
+	for _, tt := range tests {
+		if got := ResourceName(tt.resource, tt.group, tt.name); got != tt.want {
+			t.Errorf("ResourceName(%q, %q, %q) = %q, want %q", tt.resource, tt.group, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStripManagedFields(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api"}},
+	}
+
+	StripManagedFields(pods)
+	for _, pod := range pods {
+		if pod.ManagedFields != nil {
+			t.Errorf("pod %s still has managedFields %v", pod.Name, pod.ManagedFields)
+		}
+	}
+	if pods[0].Name != "web" {
+		t.Errorf("StripManagedFields changed the name to %q", pods[0].Name)
+	}
+}
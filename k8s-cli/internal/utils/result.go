@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ActionResult результат одной операции над ресурсом для --log-json
+type ActionResult struct {
+	Action    string `json:"action"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Result    string `json:"result"`
+	URL       string `json:"url,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PrintActionResult выводит результат одной строкой JSON, чтобы вывод можно было читать построчно
+func PrintActionResult(w io.Writer, result ActionResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("error marshaling result to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
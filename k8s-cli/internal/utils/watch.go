@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Типы событий в колонке EVENT потокового вывода
+const (
+	WatchAdded    = "ADDED"
+	WatchModified = "MODIFIED"
+	WatchDeleted  = "DELETED"
+)
+
+// watchColumnWidth ширина колонок потокового вывода; таблицу нельзя выровнять
+// заранее, поэтому колонки фиксированной ширины, как у events --watch
+const watchColumnWidth = 20
+
+// WatchPrinter печатает поток list --watch: заголовок один раз, затем строку
+// на каждое событие с колонкой EVENT перед колонками обычной таблицы.
+// Безопасен для вызова из обработчиков информера.
+type WatchPrinter struct {
+	w      io.Writer
+	header []string
+	row    func(obj interface{}) ([]string, bool)
+
+	mu            sync.Mutex
+	headerPrinted bool
+}
+
+// NewPodWatchPrinter печатает события подов колонками list pods
+func NewPodWatchPrinter(w io.Writer) *WatchPrinter {
+	return &WatchPrinter{w: w, header: podColumns, row: func(obj interface{}) ([]string, bool) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return nil, false
+		}
+		return podRow(*pod), true
+	}}
+}
+
+// NewDeploymentWatchPrinter печатает события деплойментов колонками list deployments
+func NewDeploymentWatchPrinter(w io.Writer) *WatchPrinter {
+	return &WatchPrinter{w: w, header: deploymentColumns, row: func(obj interface{}) ([]string, bool) {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return nil, false
+		}
+		return deploymentRow(*deployment), true
+	}}
+}
+
+// NewServiceWatchPrinter печатает события сервисов колонками list services
+func NewServiceWatchPrinter(w io.Writer) *WatchPrinter {
+	return &WatchPrinter{w: w, header: serviceColumns, row: func(obj interface{}) ([]string, bool) {
+		service, ok := obj.(*corev1.Service)
+		if !ok {
+			return nil, false
+		}
+		return serviceRow(*service), true
+	}}
+}
+
+// Print выводит строку события; объекты чужого типа пропускаются
+func (p *WatchPrinter) Print(event string, obj interface{}) {
+	row, ok := p.row(obj)
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.headerPrinted {
+		p.headerPrinted = true
+		p.printLine("EVENT", p.header)
+	}
+	p.printLine(event, row)
+}
+
+func (p *WatchPrinter) printLine(event string, columns []string) {
+	var line strings.Builder
+	fmt.Fprintf(&line, "%-10s", event)
+	for i, column := range columns {
+		if i == len(columns)-1 {
+			line.WriteString(column)
+			break
+		}
+		fmt.Fprintf(&line, "%-*s ", watchColumnWidth-1, column)
+	}
+	fmt.Fprintln(p.w, line.String())
+}
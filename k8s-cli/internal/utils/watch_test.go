@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWatchPrinter(t *testing.T) {
+	var out bytes.Buffer
+	printer := NewDeploymentWatchPrinter(&out)
+
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	printer.Print(WatchAdded, deployment)
+	deployment.Status.ReadyReplicas = 3
+	printer.Print(WatchModified, deployment)
+	printer.Print(WatchDeleted, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "not-a-deployment"}})
+	printer.Print(WatchDeleted, deployment)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want a header and 3 rows:\n%s", len(lines), out.String())
+	}
+	for i, want := range [][]string{
+		{"EVENT", "NAME", "NAMESPACE", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"},
+		{"ADDED", "web", "shop", "1/3"},
+		{"MODIFIED", "web", "shop", "3/3"},
+		{"DELETED", "web", "shop", "3/3"},
+	} {
+		fields := strings.Fields(lines[i])
+		for j, field := range want {
+			if j >= len(fields) || fields[j] != field {
+				t.Errorf("line %d = %q, want columns %v", i, lines[i], want)
+				break
+			}
+		}
+	}
+}